@@ -747,6 +747,177 @@ func (a *CorecontractsAPIService) BlocklogGetEventsOfBlockExecute(r ApiBlocklogG
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiBlocklogGetEventsOfContractRequest struct {
+	ctx context.Context
+	ApiService *CorecontractsAPIService
+	contractHname string
+	fromBlock *uint32
+	toBlock *uint32
+	limit *uint32
+	topic *string
+	after *string
+	block *string
+}
+
+// The first block of the range (default: toBlock - 999)
+func (r ApiBlocklogGetEventsOfContractRequest) FromBlock(fromBlock uint32) ApiBlocklogGetEventsOfContractRequest {
+	r.fromBlock = &fromBlock
+	return r
+}
+
+// The last block of the range (default: latest block). The range cannot exceed 1000 blocks
+func (r ApiBlocklogGetEventsOfContractRequest) ToBlock(toBlock uint32) ApiBlocklogGetEventsOfContractRequest {
+	r.toBlock = &toBlock
+	return r
+}
+
+// The maximum amount of events returned (default and maximum: 1000)
+func (r ApiBlocklogGetEventsOfContractRequest) Limit(limit uint32) ApiBlocklogGetEventsOfContractRequest {
+	r.limit = &limit
+	return r
+}
+
+// Only return the events with this topic
+func (r ApiBlocklogGetEventsOfContractRequest) Topic(topic string) ApiBlocklogGetEventsOfContractRequest {
+	r.topic = &topic
+	return r
+}
+
+// Only return the events after this one (Hex), used for pagination
+func (r ApiBlocklogGetEventsOfContractRequest) After(after string) ApiBlocklogGetEventsOfContractRequest {
+	r.after = &after
+	return r
+}
+
+// Block index or trie root
+func (r ApiBlocklogGetEventsOfContractRequest) Block(block string) ApiBlocklogGetEventsOfContractRequest {
+	r.block = &block
+	return r
+}
+
+func (r ApiBlocklogGetEventsOfContractRequest) Execute() (*ContractEventsResponse, *http.Response, error) {
+	return r.ApiService.BlocklogGetEventsOfContractExecute(r)
+}
+
+/*
+BlocklogGetEventsOfContract Get events emitted by a contract in a range of blocks
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param contractHname The contract hname (Hex)
+ @return ApiBlocklogGetEventsOfContractRequest
+*/
+func (a *CorecontractsAPIService) BlocklogGetEventsOfContract(ctx context.Context, contractHname string) ApiBlocklogGetEventsOfContractRequest {
+	return ApiBlocklogGetEventsOfContractRequest{
+		ApiService: a,
+		ctx: ctx,
+		contractHname: contractHname,
+	}
+}
+
+// Execute executes the request
+//  @return ContractEventsResponse
+func (a *CorecontractsAPIService) BlocklogGetEventsOfContractExecute(r ApiBlocklogGetEventsOfContractRequest) (*ContractEventsResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *ContractEventsResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CorecontractsAPIService.BlocklogGetEventsOfContract")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/chain/core/blocklog/events/contract/{contractHname}"
+	localVarPath = strings.Replace(localVarPath, "{"+"contractHname"+"}", url.PathEscape(parameterValueToString(r.contractHname, "contractHname")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.fromBlock != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fromBlock", r.fromBlock, "", "")
+	}
+	if r.toBlock != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "toBlock", r.toBlock, "", "")
+	}
+	if r.limit != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "limit", r.limit, "", "")
+	}
+	if r.topic != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "topic", r.topic, "", "")
+	}
+	if r.after != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "after", r.after, "", "")
+	}
+	if r.block != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "block", r.block, "", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiBlocklogGetEventsOfLatestBlockRequest struct {
 	ctx context.Context
 	ApiService *CorecontractsAPIService
@@ -1958,6 +2129,125 @@ func (a *CorecontractsAPIService) ErrorsGetErrorMessageFormatExecute(r ApiErrors
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiGovernanceGetBlockKeepAmountRequest struct {
+	ctx context.Context
+	ApiService *CorecontractsAPIService
+	block *string
+}
+
+// Block index or trie root
+func (r ApiGovernanceGetBlockKeepAmountRequest) Block(block string) ApiGovernanceGetBlockKeepAmountRequest {
+	r.block = &block
+	return r
+}
+
+func (r ApiGovernanceGetBlockKeepAmountRequest) Execute() (*GovBlockKeepAmountResponse, *http.Response, error) {
+	return r.ApiService.GovernanceGetBlockKeepAmountExecute(r)
+}
+
+/*
+GovernanceGetBlockKeepAmount Get the block keep amount
+
+Returns the amount of blocks kept in the chain state
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiGovernanceGetBlockKeepAmountRequest
+*/
+func (a *CorecontractsAPIService) GovernanceGetBlockKeepAmount(ctx context.Context) ApiGovernanceGetBlockKeepAmountRequest {
+	return ApiGovernanceGetBlockKeepAmountRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return GovBlockKeepAmountResponse
+func (a *CorecontractsAPIService) GovernanceGetBlockKeepAmountExecute(r ApiGovernanceGetBlockKeepAmountRequest) (*GovBlockKeepAmountResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *GovBlockKeepAmountResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CorecontractsAPIService.GovernanceGetBlockKeepAmount")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/chain/core/governance/blockkeepamount"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.block != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "block", r.block, "", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGovernanceGetChainAdminRequest struct {
 	ctx context.Context
 	ApiService *CorecontractsAPIService
@@ -2195,3 +2485,122 @@ func (a *CorecontractsAPIService) GovernanceGetChainInfoExecute(r ApiGovernanceG
 
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
+
+type ApiGovernanceGetFeeMultipliersRequest struct {
+	ctx context.Context
+	ApiService *CorecontractsAPIService
+	block *string
+}
+
+// Block index or trie root
+func (r ApiGovernanceGetFeeMultipliersRequest) Block(block string) ApiGovernanceGetFeeMultipliersRequest {
+	r.block = &block
+	return r
+}
+
+func (r ApiGovernanceGetFeeMultipliersRequest) Execute() (*GovFeeMultipliersResponse, *http.Response, error) {
+	return r.ApiService.GovernanceGetFeeMultipliersExecute(r)
+}
+
+/*
+GovernanceGetFeeMultipliers Get the fee multipliers
+
+Returns the senders that pay a discounted gas fee, and their multipliers
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiGovernanceGetFeeMultipliersRequest
+*/
+func (a *CorecontractsAPIService) GovernanceGetFeeMultipliers(ctx context.Context) ApiGovernanceGetFeeMultipliersRequest {
+	return ApiGovernanceGetFeeMultipliersRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return GovFeeMultipliersResponse
+func (a *CorecontractsAPIService) GovernanceGetFeeMultipliersExecute(r ApiGovernanceGetFeeMultipliersRequest) (*GovFeeMultipliersResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *GovFeeMultipliersResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CorecontractsAPIService.GovernanceGetFeeMultipliers")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/chain/core/governance/feemultipliers"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.block != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "block", r.block, "", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
@@ -24,6 +24,8 @@ type AssetsResponse struct {
 	// The base tokens (uint64 as string)
 	BaseTokens string `json:"baseTokens"`
 	Coins []CoinJSON `json:"coins"`
+	// The coins of the balance locked by vesting lockups
+	LockedCoins []CoinJSON `json:"lockedCoins,omitempty"`
 	Objects []IotaObjectJSON `json:"objects"`
 }
 
@@ -97,6 +99,38 @@ func (o *AssetsResponse) SetCoins(v []CoinJSON) {
 	o.Coins = v
 }
 
+// GetLockedCoins returns the LockedCoins field value if set, zero value otherwise.
+func (o *AssetsResponse) GetLockedCoins() []CoinJSON {
+	if o == nil || IsNil(o.LockedCoins) {
+		var ret []CoinJSON
+		return ret
+	}
+	return o.LockedCoins
+}
+
+// GetLockedCoinsOk returns a tuple with the LockedCoins field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AssetsResponse) GetLockedCoinsOk() ([]CoinJSON, bool) {
+	if o == nil || IsNil(o.LockedCoins) {
+		return nil, false
+	}
+	return o.LockedCoins, true
+}
+
+// HasLockedCoins returns a boolean if a field has been set.
+func (o *AssetsResponse) HasLockedCoins() bool {
+	if o != nil && !IsNil(o.LockedCoins) {
+		return true
+	}
+
+	return false
+}
+
+// SetLockedCoins gets a reference to the given []CoinJSON and assigns it to the LockedCoins field.
+func (o *AssetsResponse) SetLockedCoins(v []CoinJSON) {
+	o.LockedCoins = v
+}
+
 // GetObjects returns the Objects field value
 func (o *AssetsResponse) GetObjects() []IotaObjectJSON {
 	if o == nil {
@@ -133,6 +167,9 @@ func (o AssetsResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["baseTokens"] = o.BaseTokens
 	toSerialize["coins"] = o.Coins
+	if !IsNil(o.LockedCoins) {
+		toSerialize["lockedCoins"] = o.LockedCoins
+	}
 	toSerialize["objects"] = o.Objects
 	return toSerialize, nil
 }
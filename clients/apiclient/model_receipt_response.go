@@ -23,6 +23,8 @@ var _ MappedNullable = &ReceiptResponse{}
 type ReceiptResponse struct {
 	BlockIndex uint32 `json:"blockIndex"`
 	ErrorMessage *string `json:"errorMessage,omitempty"`
+	// The discount applied to the gas fee of the sender in basis points (if any)
+	FeeMultiplier *int32 `json:"feeMultiplier,omitempty"`
 	// The gas budget (uint64 as string)
 	GasBudget string `json:"gasBudget"`
 	GasBurnLog []BurnRecord `json:"gasBurnLog"`
@@ -33,6 +35,8 @@ type ReceiptResponse struct {
 	RawError *UnresolvedVMErrorJSON `json:"rawError,omitempty"`
 	Request RequestJSON `json:"request"`
 	RequestIndex uint32 `json:"requestIndex"`
+	// The account that paid the gas fee instead of the sender (if any)
+	Sponsor *string `json:"sponsor,omitempty"`
 	// Storage deposit charged (uint64 as string)
 	StorageDepositCharged string `json:"storageDepositCharged"`
 }
@@ -120,6 +124,38 @@ func (o *ReceiptResponse) SetErrorMessage(v string) {
 	o.ErrorMessage = &v
 }
 
+// GetFeeMultiplier returns the FeeMultiplier field value if set, zero value otherwise.
+func (o *ReceiptResponse) GetFeeMultiplier() int32 {
+	if o == nil || IsNil(o.FeeMultiplier) {
+		var ret int32
+		return ret
+	}
+	return *o.FeeMultiplier
+}
+
+// GetFeeMultiplierOk returns a tuple with the FeeMultiplier field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ReceiptResponse) GetFeeMultiplierOk() (*int32, bool) {
+	if o == nil || IsNil(o.FeeMultiplier) {
+		return nil, false
+	}
+	return o.FeeMultiplier, true
+}
+
+// HasFeeMultiplier returns a boolean if a field has been set.
+func (o *ReceiptResponse) HasFeeMultiplier() bool {
+	if o != nil && !IsNil(o.FeeMultiplier) {
+		return true
+	}
+
+	return false
+}
+
+// SetFeeMultiplier gets a reference to the given int32 and assigns it to the FeeMultiplier field.
+func (o *ReceiptResponse) SetFeeMultiplier(v int32) {
+	o.FeeMultiplier = &v
+}
+
 // GetGasBudget returns the GasBudget field value
 func (o *ReceiptResponse) GetGasBudget() string {
 	if o == nil {
@@ -296,6 +332,38 @@ func (o *ReceiptResponse) SetRequestIndex(v uint32) {
 	o.RequestIndex = v
 }
 
+// GetSponsor returns the Sponsor field value if set, zero value otherwise.
+func (o *ReceiptResponse) GetSponsor() string {
+	if o == nil || IsNil(o.Sponsor) {
+		var ret string
+		return ret
+	}
+	return *o.Sponsor
+}
+
+// GetSponsorOk returns a tuple with the Sponsor field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ReceiptResponse) GetSponsorOk() (*string, bool) {
+	if o == nil || IsNil(o.Sponsor) {
+		return nil, false
+	}
+	return o.Sponsor, true
+}
+
+// HasSponsor returns a boolean if a field has been set.
+func (o *ReceiptResponse) HasSponsor() bool {
+	if o != nil && !IsNil(o.Sponsor) {
+		return true
+	}
+
+	return false
+}
+
+// SetSponsor gets a reference to the given string and assigns it to the Sponsor field.
+func (o *ReceiptResponse) SetSponsor(v string) {
+	o.Sponsor = &v
+}
+
 // GetStorageDepositCharged returns the StorageDepositCharged field value
 func (o *ReceiptResponse) GetStorageDepositCharged() string {
 	if o == nil {
@@ -334,6 +402,9 @@ func (o ReceiptResponse) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ErrorMessage) {
 		toSerialize["errorMessage"] = o.ErrorMessage
 	}
+	if !IsNil(o.FeeMultiplier) {
+		toSerialize["feeMultiplier"] = o.FeeMultiplier
+	}
 	toSerialize["gasBudget"] = o.GasBudget
 	toSerialize["gasBurnLog"] = o.GasBurnLog
 	toSerialize["gasBurned"] = o.GasBurned
@@ -343,6 +414,9 @@ func (o ReceiptResponse) ToMap() (map[string]interface{}, error) {
 	}
 	toSerialize["request"] = o.Request
 	toSerialize["requestIndex"] = o.RequestIndex
+	if !IsNil(o.Sponsor) {
+		toSerialize["sponsor"] = o.Sponsor
+	}
 	toSerialize["storageDepositCharged"] = o.StorageDepositCharged
 	return toSerialize, nil
 }
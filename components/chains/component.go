@@ -95,6 +95,14 @@ func provide(c *dig.Container) error {
 	}
 
 	if err := c.Provide(func(deps chainsDeps) chainsResult {
+		proposalStrategy, err := mempool.NewProposalStrategy(ParamsChains.MempoolProposalStrategy, ParamsChains.MempoolMaxProposePerAccount)
+		if err != nil {
+			Component.LogPanic(err.Error())
+		}
+		if ParamsChains.MempoolMinReplacementFeeBump < 0 {
+			Component.LogPanicf("invalid mempool min replacement fee bump: %d, it cannot be negative", ParamsChains.MempoolMinReplacementFeeBump)
+		}
+
 		return chainsResult{
 			Chains: chains.New(
 				Component.Logger,
@@ -125,8 +133,11 @@ func provide(c *dig.Container) error {
 				ParamsSnapshotManager.SnapshotsToLoad,
 				ParamsSnapshotManager.Period,
 				ParamsSnapshotManager.Delay,
+				ParamsSnapshotManager.Deltas,
 				ParamsSnapshotManager.LocalPath,
 				ParamsSnapshotManager.NetworkPaths,
+				ParamsSnapshotManager.Sign,
+				ParamsSnapshotManager.TrustedSigners,
 				deps.ChainRecordRegistryProvider,
 				deps.DKShareRegistryProvider,
 				deps.NodeIdentityProvider,
@@ -141,6 +152,9 @@ func provide(c *dig.Container) error {
 					MaxOnledgerToPropose:       ParamsChains.MempoolMaxOnledgerToPropose,
 					MaxOffledgerToPropose:      ParamsChains.MempoolMaxOffledgerToPropose,
 					MaxOffledgerPerAccount:     ParamsChains.MempoolMaxOffledgerPerAccount,
+					MinReplacementFeeBump:      ParamsChains.MempoolMinReplacementFeeBump,
+					JournalEnabled:             ParamsChains.MempoolJournalEnabled,
+					ProposalStrategy:           proposalStrategy,
 				},
 				ParamsChains.BroadcastInterval,
 				shutdown.NewCoordinator("chains", Component.NewChildLogger("Shutdown")),
@@ -31,6 +31,10 @@ type ParametersChains struct {
 	MempoolMaxOnledgerToPropose       int           `default:"100" usage:"Maximum number of on-ledger requests to propose for the next block (includes timed requests)"`
 	MempoolMaxOffledgerPerAccount     int           `default:"100" usage:"Maximum number of off-ledger requests per account in mempool"`
 	MempoolOnLedgerRefreshMinInterval time.Duration `default:"10m" usage:"Minimum interval to try to refresh the list of on-ledger requests after some have been dropped from the pool (this interval is introduced to avoid dropping/refreshing cycle if there are too many requests on L1 to process)"`
+	MempoolJournalEnabled             bool          `default:"false" usage:"whether the off-ledger requests in the mempool are persisted, so that they survive node restarts"`
+	MempoolProposalStrategy           string        `default:"feePriority" usage:"Strategy used to select the off-ledger requests to propose for the next block (feePriority, arrival)"`
+	MempoolMaxProposePerAccount       int           `default:"0" usage:"Maximum number of off-ledger requests of a single account to propose for the next block (0 = no limit)"`
+	MempoolMinReplacementFeeBump      int           `default:"10" usage:"Minimum gas price increase, in percent, required to replace a pending EVM transaction with the same nonce (cannot be negative)"`
 }
 
 type ParametersWAL struct {
@@ -60,8 +64,11 @@ type ParametersSnapshotManager struct {
 	SnapshotsToLoad []string `default:"" usage:"list of snapshots to load; can be either single block hash of a snapshot (if a single chain has to be configured) or list of '<chainID>:<blockHash>' to configure many chains"`
 	Period          uint32   `default:"0" usage:"how often state snapshots should be made: 1000 meaning \"every 1000th state\", 0 meaning \"making snapshots is disabled\""`
 	Delay           uint32   `default:"20" usage:"how many states should pass before snapshot is produced"`
+	Deltas          uint32   `default:"0" usage:"how many delta snapshots, containing only the changes since the previous snapshot, should be made after each full snapshot; 0 meaning \"all snapshots are full\""`
 	LocalPath       string   `default:"waspdb/snap" usage:"the path to the snapshots folder in this node's disk"`
 	NetworkPaths    []string `default:"" usage:"the list of paths to the remote (http(s)) snapshot locations; each of listed locations must contain 'INDEX' file with list of snapshot files"`
+	Sign            bool     `default:"true" usage:"whether the snapshots made by this node should be signed with its identity key"`
+	TrustedSigners  []string `default:"" usage:"the list of public keys of the nodes, whose snapshots are trusted; if not empty, only snapshots signed by one of these nodes are loaded"`
 }
 
 var (
@@ -260,7 +260,10 @@ func provide(c *dig.Container) error {
 			publisher.ISCEventKindReceipt,
 			publisher.ISCEventIssuerVM,
 			publisher.ISCEventKindBlockEvents,
-		}, deps.Publisher, websocket.WithMaxTopicSubscriptionsPerClient(ParamsWebAPI.Limits.MaxTopicSubscriptionsPerClient))
+		}, deps.Publisher,
+			websocket.WithMaxTopicSubscriptionsPerClient(ParamsWebAPI.Limits.MaxTopicSubscriptionsPerClient),
+			websocket.WithChainProvider(deps.Chains.GetFirst),
+		)
 
 		if ParamsWebAPI.DebugRequestLoggerEnabled {
 			echoSwagger.Echo().Use(middleware.BodyDump(func(c echo.Context, reqBody, resBody []byte) {
@@ -298,6 +301,9 @@ func provide(c *dig.Container) error {
 			jsonrpc.NewParameters(
 				ParamsWebAPI.Limits.Jsonrpc.MaxBlocksInLogsFilterRange,
 				ParamsWebAPI.Limits.Jsonrpc.MaxLogsInResult,
+				ParamsWebAPI.Limits.Jsonrpc.MaxFiltersPerClient,
+				ParamsWebAPI.Limits.Jsonrpc.FilterTimeout,
+				ParamsWebAPI.Limits.Jsonrpc.MaxBlocksInFeeHistory,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketRateLimitMessagesPerSecond,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketRateLimitBurst,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketConnectionCleanupDuration,
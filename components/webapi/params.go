@@ -31,6 +31,11 @@ type ParametersJSONRPC struct {
 	MaxBlocksInLogsFilterRange int `default:"1000" usage:"maximum amount of blocks in eth_getLogs filter range"`
 	MaxLogsInResult            int `default:"10000" usage:"maximum amount of logs in eth_getLogs result"`
 
+	MaxFiltersPerClient int           `default:"100" usage:"maximum amount of filters (eth_newFilter, etc.) installed per client (IP address, or websocket connection). 0 = unlimited"`
+	FilterTimeout       time.Duration `default:"5m" usage:"the duration after which a filter that has not been polled is uninstalled. 0 = no timeout"`
+
+	MaxBlocksInFeeHistory int `default:"1024" usage:"maximum amount of blocks in eth_feeHistory result"`
+
 	WebsocketRateLimitMessagesPerSecond int           `default:"20" usage:"the websocket rate limit (messages per second)"`
 	WebsocketRateLimitBurst             int           `default:"5" usage:"the websocket burst limit"`
 	WebsocketRateLimitEnabled           bool          `default:"true" usage:"enable rate limiting on the websocket"`
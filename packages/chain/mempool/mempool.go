@@ -37,10 +37,15 @@
 // to the proposal based on a tangle time. The tangle time is received from the
 // L1 with the milestones.
 //
-// NOTE: A node looses its off-ledger requests on restart. The on-ledger requests
-// will be added back to the mempool by reading them from the L1 node.
+// NOTE: A node looses its off-ledger requests on restart, unless they are
+// persisted in an [OffLedgerJournal]. In that case they are restored on start,
+// and the ones processed in the meantime are dropped when the first chain head
+// is received. The on-ledger requests will be added back to the mempool by
+// reading them from the L1 node.
 //
-// TODO: Propose subset of the requests. That's for the next release.
+// The off-ledger requests to propose are chosen by a [ProposalStrategy], by
+// default ranking them by the gas price while respecting the nonce order of
+// each account.
 package mempool
 
 import (
@@ -69,6 +74,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 const (
@@ -112,7 +118,11 @@ type Mempool interface {
 	ServerNodesUpdated(committeePubKeys []*cryptolib.PublicKey, serverNodePubKeys []*cryptolib.PublicKey)
 	AccessNodesUpdated(committeePubKeys []*cryptolib.PublicKey, accessNodePubKeys []*cryptolib.PublicKey)
 
-	Iterate(f func(req isc.Request) bool)
+	// Iterate calls f for each request in the mempool, with the time it was
+	// received. Off-ledger requests that were replaced are not included. The
+	// requests are copied by the mempool event loop, f is called afterwards,
+	// so it can take its time.
+	Iterate(f func(req isc.Request, ts time.Time) bool)
 }
 
 type Settings struct {
@@ -124,6 +134,9 @@ type Settings struct {
 	MaxOnledgerToPropose       int // (including timed-requests)
 	MaxOffledgerToPropose      int
 	MaxOffledgerPerAccount     int
+	MinReplacementFeeBump      int              // in percent, not negative, see [OffLedgerPool.CheckReplacement]
+	JournalEnabled             bool             // persist the off-ledger requests, see [OffLedgerJournal]
+	ProposalStrategy           ProposalStrategy // selects the off-ledger requests to propose, [NewFeePriorityStrategy] if nil
 }
 
 // This implementation tracks single branch of the chain only. I.e. all the consensus
@@ -133,6 +146,7 @@ type Settings struct {
 // In general we can track several branches, but then we have to remember, which
 // requests are available in which branches. Can be implemented later, if needed.
 type mempoolImpl struct {
+	ctx                            context.Context
 	chainID                        isc.ChainID
 	tangleTime                     time.Time
 	onLedgerPool                   RequestPool[isc.OnLedgerRequest] // TODO limit this pool
@@ -154,6 +168,7 @@ type mempoolImpl struct {
 	reqReceiveOffLedgerRequestPipe pipe.Pipe[isc.OffLedgerRequest]
 	reqTangleTimeUpdatedPipe       pipe.Pipe[time.Time]
 	reqTrackNewChainHeadPipe       pipe.Pipe[*reqTrackNewChainHead]
+	reqIteratePipe                 pipe.Pipe[*reqIterate]
 	netRecvPipe                    pipe.Pipe[*peering.PeerMessageIn]
 	netPeeringID                   peering.PeeringID
 	netPeerPubs                    map[gpa.NodeID]*cryptolib.PublicKey
@@ -165,6 +180,7 @@ type mempoolImpl struct {
 	listener                       ChainListener
 	refreshOnLedgerRequests        func()
 	lastRefreshTimestamp           time.Time
+	proposalStrategy               ProposalStrategy
 }
 
 var _ Mempool = &mempoolImpl{}
@@ -201,6 +217,15 @@ type reqConsensusRequests struct {
 	responseCh  chan<- []isc.Request
 }
 
+type reqIterate struct {
+	responseCh chan<- []*iteratedRequest
+}
+
+type iteratedRequest struct {
+	req isc.Request
+	ts  time.Time
+}
+
 type reqTrackNewChainHead struct {
 	st         state.State
 	from       *isc.StateAnchor
@@ -222,14 +247,16 @@ func New(
 	settings Settings,
 	broadcastInterval time.Duration,
 	refreshOnLedgerRequests func(),
+	journal *OffLedgerJournal,
 ) Mempool {
 	netPeeringID := peering.HashPeeringIDFromBytes(chainID.Bytes(), []byte("Mempool")) // ChainID × Mempool
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
 	mpi := &mempoolImpl{
+		ctx:                            ctx,
 		chainID:                        chainID,
 		tangleTime:                     time.Time{},
 		onLedgerPool:                   NewTypedPool[isc.OnLedgerRequest](settings.MaxOnledgerInPool, waitReq, metrics.SetOnLedgerPoolSize, metrics.SetOnLedgerReqTime, log.NewChildLogger("ONL")),
-		offLedgerPool:                  NewOffledgerPool(settings.MaxOffledgerInPool, settings.MaxOffledgerPerAccount, waitReq, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.NewChildLogger("OFF")),
+		offLedgerPool:                  NewOffledgerPool(settings.MaxOffledgerInPool, settings.MaxOffledgerPerAccount, settings.MinReplacementFeeBump, waitReq, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.NewChildLogger("OFF")),
 		chainHeadAnchor:                nil,
 		serverNodesUpdatedPipe:         pipe.NewInfinitePipe[*reqServerNodesUpdated](),
 		serverNodes:                    []*cryptolib.PublicKey{},
@@ -244,6 +271,7 @@ func New(
 		reqReceiveOffLedgerRequestPipe: pipe.NewInfinitePipe[isc.OffLedgerRequest](),
 		reqTangleTimeUpdatedPipe:       pipe.NewInfinitePipe[time.Time](),
 		reqTrackNewChainHeadPipe:       pipe.NewInfinitePipe[*reqTrackNewChainHead](),
+		reqIteratePipe:                 pipe.NewInfinitePipe[*reqIterate](),
 		netRecvPipe:                    pipe.NewInfinitePipe[*peering.PeerMessageIn](),
 		netPeeringID:                   netPeeringID,
 		netPeerPubs:                    map[gpa.NodeID]*cryptolib.PublicKey{},
@@ -255,6 +283,16 @@ func New(
 		listener:                       listener,
 		refreshOnLedgerRequests:        refreshOnLedgerRequests,
 		lastRefreshTimestamp:           time.Now(),
+		proposalStrategy:               settings.ProposalStrategy,
+	}
+	if mpi.proposalStrategy == nil {
+		mpi.proposalStrategy = NewFeePriorityStrategy(0)
+	}
+
+	if journal != nil {
+		if err := mpi.offLedgerPool.RestoreFromJournal(journal, settings.TTL); err != nil {
+			log.LogErrorf("cannot restore off-ledger requests from the journal: %v", err)
+		}
 	}
 
 	pipeMetrics.TrackPipeLen("mp-serverNodesUpdatedPipe", mpi.serverNodesUpdatedPipe.Len)
@@ -265,6 +303,7 @@ func New(
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOffLedgerRequestPipe", mpi.reqReceiveOffLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTangleTimeUpdatedPipe", mpi.reqTangleTimeUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTrackNewChainHeadPipe", mpi.reqTrackNewChainHeadPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqIteratePipe", mpi.reqIteratePipe.Len)
 	pipeMetrics.TrackPipeLen("mp-netRecvPipe", mpi.netRecvPipe.Len)
 
 	mpi.distSync = distsync.New(
@@ -346,28 +385,20 @@ func (mpi *mempoolImpl) ConsensusRequestsAsync(ctx context.Context, requestRefs
 	return res
 }
 
-func (mpi *mempoolImpl) Iterate(f func(req isc.Request) bool) {
-	// TODO: This makes unprotected concurrent access to the MP state.
-
-	continueIter := true
-
-	mpi.offLedgerPool.Iterate(func(account string, requests []*OrderedPoolEntry) bool {
-		for _, entry := range requests {
-			if !f(entry.req) {
-				continueIter = false
-				return false
-			}
-		}
-		return true
-	})
-
-	if !continueIter {
+func (mpi *mempoolImpl) Iterate(f func(req isc.Request, ts time.Time) bool) {
+	responseCh := make(chan []*iteratedRequest, 1)
+	mpi.reqIteratePipe.In() <- &reqIterate{responseCh: responseCh}
+	var requests []*iteratedRequest
+	select {
+	case requests = <-responseCh:
+	case <-mpi.ctx.Done():
 		return
 	}
-
-	mpi.onLedgerPool.Iterate(func(entry *typedPoolEntry[isc.OnLedgerRequest]) bool {
-		return f(entry.req)
-	})
+	for _, r := range requests {
+		if !f(r.req, r.ts) {
+			return
+		}
+	}
 }
 
 func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc) { //nolint:gocyclo
@@ -379,6 +410,7 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 	reqReceiveOffLedgerRequestPipeOutCh := mpi.reqReceiveOffLedgerRequestPipe.Out()
 	reqTangleTimeUpdatedPipeOutCh := mpi.reqTangleTimeUpdatedPipe.Out()
 	reqTrackNewChainHeadPipeOutCh := mpi.reqTrackNewChainHeadPipe.Out()
+	reqIteratePipeOutCh := mpi.reqIteratePipe.Out()
 	netRecvPipeOutCh := mpi.netRecvPipe.Out()
 	debugTicker := time.NewTicker(distShareDebugTick)
 	timeTicker := time.NewTicker(distShareTimeTick)
@@ -435,6 +467,12 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 				break
 			}
 			mpi.handleTrackNewChainHead(recv)
+		case recv, ok := <-reqIteratePipeOutCh:
+			if !ok {
+				reqIteratePipeOutCh = nil
+				break
+			}
+			mpi.handleIterate(recv)
 		case recv, ok := <-netRecvPipeOutCh:
 			if !ok {
 				netRecvPipeOutCh = nil
@@ -458,6 +496,7 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 			// mpi.reqReceiveOffLedgerRequestPipe.Close()
 			// mpi.reqTangleTimeUpdatedPipe.Close()
 			// mpi.reqTrackNewChainHeadPipe.Close()
+			// mpi.reqIteratePipe.Close()
 			// mpi.netRecvPipe.Close()
 			debugTicker.Stop()
 			timeTicker.Stop()
@@ -536,11 +575,20 @@ func (mpi *mempoolImpl) shouldAddOffledgerRequest(req isc.OffLedgerRequest) erro
 	if req.Nonce() < accountNonce {
 		return fmt.Errorf("bad nonce, expected: %d", accountNonce)
 	}
+	if err := mpi.offLedgerPool.CheckReplacement(req); err != nil {
+		return err
+	}
 
 	// check user has on-chain balance
 	governanceState := governance.NewStateReaderFromChainState(mpi.chainHeadState)
 	minFee := governanceState.GetGasFeePolicy().MinFee(isc.RequestGasPrice(req), parameters.BaseTokenDecimals)
+	// the sender may have a discount on the gas fee
+	minFee = gas.ApplyFeeMultiplier(minFee, governanceState.GetFeeMultiplier(req.SenderAccount()))
 	balance := mpi.accountsState().GetBaseTokensBalanceDiscardExtraDecimals(req.SenderAccount())
+	if sponsor := isc.RequestSponsor(req); sponsor != nil && balance < minFee {
+		// the gas fee is charged to the sponsor if it has approved the sender
+		balance = mpi.accountsState().GetSponsoredGasFeeBalance(sponsor, req.SenderAccount())
+	}
 	if balance < minFee {
 		// make an exception for gov calls (sender is chain admin and target is gov contract)
 		chainAdmin := governanceState.GetChainAdmin()
@@ -610,17 +658,17 @@ func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.Req
 	}
 
 	//
-	// iterate the ordered txs and add the first valid ones (respect nonce) to propose
-	// stop iterating when either: got MaxOffledgerToPropose, or no requests were added during last iteration (there are gaps in nonces)
-	accNonces := make(map[string]uint64)                             // cache of account nonces so we don't propose gaps
-	orderedList := slices.Clone(mpi.offLedgerPool.orderedByGasPrice) // clone the ordered list of references to requests, so we can alter it safely
-	offLedgerReqs := []*isc.RequestRef{}
-	for {
-		addedThisCycle := false
-		for i, e := range orderedList {
-			if e == nil {
-				continue
-			}
+	// collect the off-ledger requests that can be proposed for each account,
+	// i.e. the ones following the current account nonce without gaps, and let
+	// the proposal strategy pick the ones to propose.
+	candidates := [][]*OrderedPoolEntry{}
+	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry) bool {
+		if len(entries) == 0 {
+			return true
+		}
+		accountNonce := mpi.nonce(entries[0].req.SenderAccount())
+		accountCandidates := []*OrderedPoolEntry{}
+		for _, e := range entries {
 			//
 			// drop tx with expired TTL
 			if time.Since(e.ts) > mpi.settings.TTL { // stop proposing after TTL
@@ -640,46 +688,32 @@ func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.Req
 				continue
 			}
 
-			reqAccount := e.req.SenderAccount()
-			reqAccountKey := reqAccount.String()
-			accountNonce, ok := accNonces[reqAccountKey]
-			if !ok {
-				accountNonce = mpi.nonce(reqAccount)
-				accNonces[reqAccountKey] = accountNonce
-			}
-
 			reqNonce := e.req.Nonce()
 			if reqNonce < accountNonce {
 				// nonce too old, delete
-				mpi.log.LogDebugf("refsToPropose, account: %s, removing request (%s) with old nonce (%d) from the pool", reqAccount, e.req.ID(), e.req.Nonce())
+				mpi.log.LogDebugf("refsToPropose, account: %s, removing request (%s) with old nonce (%d) from the pool", account, e.req.ID(), reqNonce)
 				mpi.offLedgerPool.Remove(e.req)
 				continue
 			}
-
-			if reqNonce == accountNonce {
-				// expected nonce, add it to the list to propose
-				mpi.log.LogDebugf("refsToPropose, account: %s, proposing reqID %s with nonce: %d", reqAccount, e.req.ID().String(), e.req.Nonce())
-				offLedgerReqs = append(offLedgerReqs, isc.RequestRefFromRequest(e.req))
-				e.markProposed(consensusID)
-				addedThisCycle = true
-				accountNonce++ // increment the account nonce to match the next valid request
-				accNonces[reqAccountKey] = accountNonce
-				// delete from this list
-				orderedList[i] = nil
-			}
-
-			if len(offLedgerReqs) >= mpi.settings.MaxOffledgerToPropose {
-				break // got enough requests
-			}
-
 			if reqNonce > accountNonce {
-				mpi.log.LogDebugf("refsToPropose, account: %s, req %s has a nonce %d which is too high (expected %d), won't be proposed", reqAccount, e.req.ID().String(), e.req.Nonce(), accountNonce)
-				continue // skip request
+				mpi.log.LogDebugf("refsToPropose, account: %s, req %s has a nonce %d which is too high (expected %d), won't be proposed", account, e.req.ID().String(), reqNonce, accountNonce)
+				break // the requests are ordered by nonce, so all the following ones are too high as well
 			}
+			accountCandidates = append(accountCandidates, e)
+			accountNonce++ // increment the account nonce to match the next valid request
 		}
-		if !addedThisCycle || len(offLedgerReqs) >= mpi.settings.MaxOffledgerToPropose {
-			break
+		if len(accountCandidates) > 0 {
+			candidates = append(candidates, accountCandidates)
 		}
+		return true
+	})
+
+	selected := mpi.proposalStrategy.Select(candidates, mpi.settings.MaxOffledgerToPropose, mpi.offLedgerPool.GasPrice)
+	offLedgerReqs := make([]*isc.RequestRef, len(selected))
+	for i, e := range selected {
+		mpi.log.LogDebugf("refsToPropose, proposing reqID %s with nonce: %d", e.req.ID().String(), e.req.Nonce())
+		offLedgerReqs[i] = isc.RequestRefFromRequest(e.req)
+		e.markProposed(consensusID)
 	}
 
 	return slices.Concat(onLedgerReqs, offLedgerReqs)
@@ -804,6 +838,26 @@ func (mpi *mempoolImpl) handleTangleTimeUpdated(tangleTime time.Time) {
 	}
 }
 
+// Copies the requests in the pools, so that they can be iterated outside of
+// the event loop.
+func (mpi *mempoolImpl) handleIterate(req *reqIterate) {
+	requests := []*iteratedRequest{}
+	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry) bool {
+		for _, entry := range entries {
+			if !entry.old {
+				requests = append(requests, &iteratedRequest{req: entry.req, ts: entry.ts})
+			}
+		}
+		return true
+	})
+	mpi.onLedgerPool.Iterate(func(entry *typedPoolEntry[isc.OnLedgerRequest]) bool {
+		requests = append(requests, &iteratedRequest{req: entry.req, ts: entry.ts})
+		return true
+	})
+	req.responseCh <- requests
+	close(req.responseCh)
+}
+
 // - Re-add all the request from the reverted blocks.
 // - Cleanup requests from the blocks that were added.
 func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
@@ -945,6 +999,8 @@ func (mpi *mempoolImpl) tryReAddRequest(req isc.Request) {
 	case isc.OffLedgerRequest:
 		mpi.log.LogDebugf("re-adding off-ledger request to mempool: %s", req.ID())
 		mpi.offLedgerPool.Add(req)
+	case *isc.ScheduledRequest:
+		// executed by the VM, never in the mempool
 	default:
 		panic(fmt.Errorf("unexpected request type: %T", req))
 	}
@@ -958,6 +1014,8 @@ func (mpi *mempoolImpl) tryRemoveRequest(req isc.Request) {
 	case isc.OffLedgerRequest:
 		mpi.log.LogDebugf("removing off-ledger request from mempool: %s", req.ID())
 		mpi.offLedgerPool.Remove(req)
+	case *isc.ScheduledRequest:
+		// executed by the VM, never in the mempool
 	default:
 		mpi.log.LogWarnf("Trying to remove request of unexpected type %T: %+v", req, req)
 	}
@@ -179,6 +179,17 @@ func testMempoolBasic(t *testing.T, n, f int, reliable bool) {
 		require.Len(t, prop, 1)
 		require.Contains(t, prop, offLedgerRef2)
 	}
+	//
+	// The request is listed by Iterate until it is consumed.
+	for i := range te.mempools {
+		var ids []isc.RequestID
+		te.mempools[i].Iterate(func(req isc.Request, ts time.Time) bool {
+			require.False(t, ts.IsZero())
+			ids = append(ids, req.ID())
+			return true
+		})
+		require.Contains(t, ids, offLedgerReq2.ID())
+	}
 }
 
 func TestMempoolsNonceGaps(t *testing.T) {
@@ -451,6 +462,7 @@ func TestTTL(t *testing.T) {
 		},
 		1*time.Second,
 		func() {},
+		nil,
 	)
 	defer te.close()
 	start := time.Now()
@@ -647,6 +659,7 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			},
 			1*time.Second,
 			func() {},
+			nil,
 		)
 	}
 	return te
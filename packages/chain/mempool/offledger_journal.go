@@ -0,0 +1,83 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"fmt"
+	"time"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kvstore"
+)
+
+// OffLedgerJournal persists the off-ledger requests kept in the mempool, so
+// that they can be restored after a node restart.
+//
+// The journal is not meant to be consistent with the chain state: requests
+// that were processed while the node was down are dropped by the mempool when
+// it receives the first chain head after the restart.
+type OffLedgerJournal struct {
+	store kvstore.KVStore
+}
+
+type offLedgerJournalEntry struct {
+	// Timestamp is the time the request was received, in unix nanoseconds
+	Timestamp int64
+	Request   isc.Request
+}
+
+func NewOffLedgerJournal(store kvstore.KVStore) *OffLedgerJournal {
+	return &OffLedgerJournal{store: store}
+}
+
+func (j *OffLedgerJournal) Add(req isc.OffLedgerRequest, ts time.Time) error {
+	entry := &offLedgerJournalEntry{
+		Timestamp: ts.UnixNano(),
+		Request:   req,
+	}
+	data, err := bcs.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return j.store.Set(req.ID().Bytes(), data)
+}
+
+func (j *OffLedgerJournal) Remove(req isc.OffLedgerRequest) error {
+	return j.store.Delete(req.ID().Bytes())
+}
+
+// Load calls f for each request in the journal.
+// Entries that cannot be decoded are deleted.
+func (j *OffLedgerJournal) Load(f func(req isc.OffLedgerRequest, ts time.Time)) error {
+	var entries []*offLedgerJournalEntry
+	var invalid []kvstore.Key
+	// f might modify the journal, so it is not called while iterating
+	err := j.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		entry, err := bcs.Unmarshal[*offLedgerJournalEntry](value)
+		if err != nil {
+			invalid = append(invalid, key)
+			return true
+		}
+		if _, ok := entry.Request.(isc.OffLedgerRequest); !ok {
+			invalid = append(invalid, key)
+			return true
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range invalid {
+		if err := j.store.Delete(key); err != nil {
+			return fmt.Errorf("cannot delete invalid journal entry: %w", err)
+		}
+	}
+	for _, entry := range entries {
+		f(entry.Request.(isc.OffLedgerRequest), time.Unix(0, entry.Timestamp))
+	}
+	return nil
+}
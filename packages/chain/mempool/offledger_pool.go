@@ -4,6 +4,7 @@
 package mempool
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"slices"
@@ -29,23 +30,40 @@ type OffLedgerPool struct {
 	minGasPrice       *big.Int
 	maxPoolSize       int
 	maxPerAccount     int
-	sizeMetric        func(int)
-	timeMetric        func(time.Duration)
-	log               log.Logger
+	// minReplacementFeeBump is the fee increase, in percent, needed to
+	// replace a pending request with the same nonce
+	minReplacementFeeBump int
+	sizeMetric            func(int)
+	timeMetric            func(time.Duration)
+	// journal persists the requests in the pool, if set
+	journal *OffLedgerJournal
+	log     log.Logger
 }
 
-func NewOffledgerPool(maxPoolSize int, maxPerAccount int, waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log log.Logger) *OffLedgerPool {
+// ErrReplacementUnderpriced is returned when a request with the same nonce of
+// a pending one does not pay enough to replace it.
+var ErrReplacementUnderpriced = errors.New("replacement request underpriced")
+
+// ErrReplacementNotAllowed is returned when a request with the same nonce of
+// a pending one cannot replace it, because either of them is an ISC request.
+var ErrReplacementNotAllowed = errors.New("replacement of ISC requests not allowed")
+
+func NewOffledgerPool(maxPoolSize int, maxPerAccount int, minReplacementFeeBump int, waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log log.Logger) *OffLedgerPool {
+	if minReplacementFeeBump < 0 {
+		panic(fmt.Sprintf("minReplacementFeeBump cannot be negative: %d", minReplacementFeeBump))
+	}
 	return &OffLedgerPool{
-		waitReq:             waitReq,
-		refLUT:              shrinkingmap.New[isc.RequestRefKey, *OrderedPoolEntry](),
-		reqsByAcountOrdered: shrinkingmap.New[string, []*OrderedPoolEntry](),
-		orderedByGasPrice:   []*OrderedPoolEntry{},
-		minGasPrice:         big.NewInt(1),
-		maxPoolSize:         maxPoolSize,
-		maxPerAccount:       maxPerAccount,
-		sizeMetric:          sizeMetric,
-		timeMetric:          timeMetric,
-		log:                 log,
+		waitReq:               waitReq,
+		refLUT:                shrinkingmap.New[isc.RequestRefKey, *OrderedPoolEntry](),
+		reqsByAcountOrdered:   shrinkingmap.New[string, []*OrderedPoolEntry](),
+		orderedByGasPrice:     []*OrderedPoolEntry{},
+		minGasPrice:           big.NewInt(1),
+		maxPoolSize:           maxPoolSize,
+		maxPerAccount:         maxPerAccount,
+		minReplacementFeeBump: minReplacementFeeBump,
+		sizeMetric:            sizeMetric,
+		timeMetric:            timeMetric,
+		log:                   log,
 	}
 }
 
@@ -56,6 +74,15 @@ type OrderedPoolEntry struct {
 	proposedFor []consGR.ConsensusID
 }
 
+func (ope *OrderedPoolEntry) Request() isc.OffLedgerRequest {
+	return ope.req
+}
+
+// Timestamp returns the time the request was added to the pool.
+func (ope *OrderedPoolEntry) Timestamp() time.Time {
+	return ope.ts
+}
+
 func (ope *OrderedPoolEntry) markProposed(consID consGR.ConsensusID) {
 	ope.proposedFor = append(ope.proposedFor, consID)
 }
@@ -88,11 +115,37 @@ func (p *OffLedgerPool) Get(reqRef *isc.RequestRef) isc.OffLedgerRequest {
 	return entry.req
 }
 
+// RestoreFromJournal adds the requests found in the journal to the pool,
+// dropping the ones older than ttl, and keeps the journal up to date with
+// the pool from now on.
+func (p *OffLedgerPool) RestoreFromJournal(journal *OffLedgerJournal, ttl time.Duration) error {
+	p.journal = journal
+	return journal.Load(func(request isc.OffLedgerRequest, ts time.Time) {
+		if ttl > 0 && time.Since(ts) > ttl {
+			p.log.LogDebugf("Not restoring request %v from journal, TTL expired.", request.ID())
+			p.removeFromJournal(request)
+			return
+		}
+		if !p.add(request, ts) {
+			p.removeFromJournal(request)
+		}
+	})
+}
+
 func (p *OffLedgerPool) Add(request isc.OffLedgerRequest) bool {
+	return p.add(request, time.Now())
+}
+
+func (p *OffLedgerPool) add(request isc.OffLedgerRequest, ts time.Time) bool {
 	ref := isc.RequestRefFromRequest(request)
-	entry := &OrderedPoolEntry{req: request, ts: time.Now()}
+	entry := &OrderedPoolEntry{req: request, ts: ts}
 	account := request.SenderAccount().String()
 
+	if err := p.CheckReplacement(request); err != nil {
+		p.log.LogDebugf("OffLedger Request NOT ADDED: %v", err)
+		return false
+	}
+
 	//
 	// add the request to the "request ref" Lookup Table
 	if !p.refLUT.Set(ref.AsKey(), entry) {
@@ -119,6 +172,9 @@ func (p *OffLedgerPool) Add(request isc.OffLedgerRequest) bool {
 				p.orderedByGasPrice = lo.Filter(p.orderedByGasPrice, func(e *OrderedPoolEntry, _ int) bool {
 					return e.req.ID() != oldEntry.req.ID()
 				})
+				p.refLUT.Delete(isc.RequestRefFromRequest(oldEntry.req).AsKey())
+				p.removeFromJournal(oldEntry.req)
+				p.log.LogDebugf("OffLedger Request %v replaced by %v", oldEntry.req.ID(), request.ID())
 			} else {
 				reqsInAccount := len(reqsForAcount)
 				if reqsInAccount >= p.maxPerAccount {
@@ -171,6 +227,12 @@ func (p *OffLedgerPool) Add(request isc.OffLedgerRequest) bool {
 		return false
 	}
 
+	if p.journal != nil {
+		if err := p.journal.Add(request, entry.ts); err != nil {
+			p.log.LogWarnf("cannot write request %v to the journal: %v", request.ID(), err)
+		}
+	}
+
 	//
 	// update metrics and signal that the request is available
 	p.log.LogDebugf("ADD %v as key=%v, senderAccount: %s", request.ID(), ref, account)
@@ -179,6 +241,38 @@ func (p *OffLedgerPool) Add(request isc.OffLedgerRequest) bool {
 	return true
 }
 
+// CheckReplacement returns an error if the request has the same nonce of a
+// pending request of the same account, but does not increase the gas price
+// enough to replace it.
+//
+// Only EVM transactions can be replaced. ISC requests always pay the gas
+// price set by the chain, and their gas budget is only a maximum, so there is
+// nothing a replacement could pay more for.
+func (p *OffLedgerPool) CheckReplacement(request isc.OffLedgerRequest) error {
+	reqsForAccount, exists := p.reqsByAcountOrdered.Get(request.SenderAccount().String())
+	if !exists {
+		return nil
+	}
+	for _, e := range reqsForAccount {
+		if e.old || e.req.Nonce() != request.Nonce() || e.req.ID() == request.ID() {
+			continue
+		}
+		oldPrice := e.req.GasPrice()
+		newPrice := request.GasPrice()
+		if oldPrice == nil || newPrice == nil {
+			return fmt.Errorf("%w: request %v with nonce %d cannot replace %v",
+				ErrReplacementNotAllowed, request.ID(), request.Nonce(), e.req.ID())
+		}
+		// newPrice >= oldPrice * (100 + minReplacementFeeBump) / 100
+		minPrice := new(big.Int).Mul(oldPrice, big.NewInt(int64(100+p.minReplacementFeeBump)))
+		if new(big.Int).Mul(newPrice, big.NewInt(100)).Cmp(minPrice) < 0 {
+			return fmt.Errorf("%w: request %v with nonce %d must increase the gas price of %v by at least %d%%",
+				ErrReplacementUnderpriced, request.ID(), request.Nonce(), e.req.ID(), p.minReplacementFeeBump)
+		}
+	}
+	return nil
+}
+
 // LimitPoolSize drops the txs with the lowest price if the total number of requests is too big
 func (p *OffLedgerPool) LimitPoolSize() []*OrderedPoolEntry {
 	if len(p.orderedByGasPrice) <= p.maxPoolSize {
@@ -260,6 +354,7 @@ func (p *OffLedgerPool) Remove(request isc.OffLedgerRequest) {
 	if p.refLUT.Delete(refKey) {
 		p.log.LogDebugf("DEL %v as key=%v", request.ID(), refKey)
 	}
+	p.removeFromJournal(request)
 
 	//
 	// find the request in the accounts map and delete it
@@ -297,6 +392,15 @@ func (p *OffLedgerPool) Remove(request isc.OffLedgerRequest) {
 	}
 }
 
+func (p *OffLedgerPool) removeFromJournal(request isc.OffLedgerRequest) {
+	if p.journal == nil {
+		return
+	}
+	if err := p.journal.Remove(request); err != nil {
+		p.log.LogWarnf("cannot remove request %v from the journal: %v", request.ID(), err)
+	}
+}
+
 func (p *OffLedgerPool) Iterate(f func(account string, requests []*OrderedPoolEntry) bool) {
 	p.reqsByAcountOrdered.ForEach(func(acc string, entries []*OrderedPoolEntry) bool {
 		return f(acc, slices.Clone(entries))
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/isc/isctest"
+	"github.com/iotaledger/wasp/v2/packages/kvstore"
+	"github.com/iotaledger/wasp/v2/packages/kvstore/mapdb"
 	"github.com/iotaledger/wasp/v2/packages/testutil"
 	"github.com/iotaledger/wasp/v2/packages/testutil/testkey"
 	"github.com/iotaledger/wasp/v2/packages/testutil/testlogger"
@@ -20,7 +23,7 @@ import (
 
 func TestOffledgerMempoolAccountNonce(t *testing.T) {
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
-	pool := NewOffledgerPool(100, 10, waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
+	pool := NewOffledgerPool(100, 10, 0, waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
 
 	// generate a bunch of requests for the same account
 	kp, addr := testkey.GenKeyAddr()
@@ -43,12 +46,13 @@ func TestOffledgerMempoolAccountNonce(t *testing.T) {
 	consLogIndex := cmtlog.NilLogIndex()
 	consID := consGR.NewConsensusID(cryptolib.NewEmptyAddress(), &consLogIndex)
 	lo.ForEach(pool.orderedByGasPrice, func(e *OrderedPoolEntry, _ int) { e.markProposed(consID) })
-	// Add it again. It should not be replaced, but appended instead.
-	pool.Add(req2new)
-	require.EqualValues(t, 4, pool.refLUT.Size())
+	// ISC requests cannot be replaced, neither before nor after being proposed.
+	require.ErrorIs(t, pool.CheckReplacement(req2new), ErrReplacementNotAllowed)
+	require.False(t, pool.Add(req2new))
+	require.EqualValues(t, 3, pool.refLUT.Size())
 	require.EqualValues(t, 1, pool.reqsByAcountOrdered.Size())
 	reqsInPoolForAccount, _ = pool.reqsByAcountOrdered.Get(agentID.String())
-	require.Len(t, reqsInPoolForAccount, 4)
+	require.Len(t, reqsInPoolForAccount, 3)
 
 	// try to remove everything during iteration
 	pool.Iterate(func(account string, entries []*OrderedPoolEntry) bool {
@@ -65,7 +69,7 @@ func TestOffledgerMempoolAccountNonce(t *testing.T) {
 func TestOffledgerMempoolLimit(t *testing.T) {
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
 	poolSizeLimit := 3
-	pool := NewOffledgerPool(poolSizeLimit, poolSizeLimit, waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
+	pool := NewOffledgerPool(poolSizeLimit, poolSizeLimit, 0, waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
 
 	// create requests with different gas prices
 	req0 := testutil.DummyEVMRequest(isctest.RandomChainID(), big.NewInt(1))
@@ -107,3 +111,79 @@ func TestOffledgerMempoolLimit(t *testing.T) {
 
 	contains(req2, req3, req5) // assert req5 was added and req1 was removed
 }
+
+func TestOffledgerMempoolReplacement(t *testing.T) {
+	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	pool := NewOffledgerPool(100, 10, 10, waitReq, func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
+
+	chainID := isctest.RandomChainID()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newRequest := func(gasPrice int64) isc.OffLedgerRequest {
+		return testutil.DummyEVMRequestForKey(chainID, key, 0, big.NewInt(gasPrice))
+	}
+	req := newRequest(1000)
+	require.True(t, pool.Add(req))
+
+	// not enough gas price bump
+	underpriced := newRequest(1099)
+	require.ErrorIs(t, pool.CheckReplacement(underpriced), ErrReplacementUnderpriced)
+	require.False(t, pool.Add(underpriced))
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req)))
+	require.False(t, pool.Has(isc.RequestRefFromRequest(underpriced)))
+
+	// the replaced request is removed from the pool
+	replacement := newRequest(1100)
+	require.NoError(t, pool.CheckReplacement(replacement))
+	require.True(t, pool.Add(replacement))
+	require.False(t, pool.Has(isc.RequestRefFromRequest(req)))
+	require.True(t, pool.Has(isc.RequestRefFromRequest(replacement)))
+	require.EqualValues(t, 1, pool.refLUT.Size())
+	require.Len(t, pool.orderedByGasPrice, 1)
+
+	// a proposed request is kept, and marked as old
+	consLogIndex := cmtlog.NilLogIndex()
+	pool.orderedByGasPrice[0].markProposed(consGR.NewConsensusID(cryptolib.NewEmptyAddress(), &consLogIndex))
+	replacement2 := newRequest(1210)
+	require.True(t, pool.Add(replacement2))
+	require.True(t, pool.Has(isc.RequestRefFromRequest(replacement)))
+	require.True(t, pool.Has(isc.RequestRefFromRequest(replacement2)))
+	require.EqualValues(t, 2, pool.refLUT.Size())
+}
+
+func TestOffledgerMempoolJournal(t *testing.T) {
+	newPool := func() *OffLedgerPool {
+		return NewOffledgerPool(100, 10, 0, NewWaitReq(waitRequestCleanupEvery), func(int) {}, func(time.Duration) {}, testlogger.NewSilentLogger("", true))
+	}
+	store := mapdb.NewMapDB()
+
+	kp, _ := testkey.GenKeyAddr()
+	req0 := testutil.DummyOffledgerRequestForAccount(isctest.RandomChainID(), 0, kp)
+	req1 := testutil.DummyOffledgerRequestForAccount(isctest.RandomChainID(), 1, kp)
+	req2 := testutil.DummyOffledgerRequestForAccount(isctest.RandomChainID(), 2, kp)
+
+	pool := newPool()
+	require.NoError(t, pool.RestoreFromJournal(NewOffLedgerJournal(store), time.Hour))
+	pool.Add(req0)
+	pool.Add(req1)
+	pool.Add(req2)
+	pool.Remove(req1)
+
+	// the requests still in the pool are restored after a "restart"
+	restored := newPool()
+	require.NoError(t, restored.RestoreFromJournal(NewOffLedgerJournal(store), time.Hour))
+	require.EqualValues(t, 2, restored.refLUT.Size())
+	require.True(t, restored.Has(isc.RequestRefFromRequest(req0)))
+	require.False(t, restored.Has(isc.RequestRefFromRequest(req1)))
+	require.True(t, restored.Has(isc.RequestRefFromRequest(req2)))
+
+	// expired requests are dropped, also from the journal
+	time.Sleep(10 * time.Millisecond)
+	expired := newPool()
+	require.NoError(t, expired.RestoreFromJournal(NewOffLedgerJournal(store), time.Millisecond))
+	require.EqualValues(t, 0, expired.refLUT.Size())
+	require.NoError(t, store.Iterate(kvstore.EmptyPrefix, func(kvstore.Key, kvstore.Value) bool {
+		require.Fail(t, "journal should be empty")
+		return false
+	}))
+}
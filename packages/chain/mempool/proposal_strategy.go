@@ -0,0 +1,148 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+const (
+	ProposalStrategyFeePriority = "feePriority"
+	ProposalStrategyArrival     = "arrival"
+)
+
+// ProposalStrategy decides which off-ledger requests are proposed to the
+// consensus, and in which order.
+type ProposalStrategy interface {
+	// Select returns at most max requests out of the candidates.
+	//
+	// Each element of candidates holds the requests of a single account that
+	// can be proposed, ordered by nonce and without gaps. A request can only
+	// be selected after all the ones preceding it in the same account.
+	// gasPrice returns the effective gas price of a request.
+	Select(candidates [][]*OrderedPoolEntry, max int, gasPrice func(*OrderedPoolEntry) *big.Int) []*OrderedPoolEntry
+}
+
+// NewProposalStrategy returns the strategy with the given name.
+// maxPerAccount limits the number of requests proposed for a single account
+// in a single proposal, 0 means no limit.
+func NewProposalStrategy(name string, maxPerAccount int) (ProposalStrategy, error) {
+	switch name {
+	case "", ProposalStrategyFeePriority:
+		return NewFeePriorityStrategy(maxPerAccount), nil
+	case ProposalStrategyArrival:
+		return NewArrivalStrategy(maxPerAccount), nil
+	default:
+		return nil, fmt.Errorf("unknown mempool proposal strategy %q", name)
+	}
+}
+
+// feePriorityStrategy proposes the requests paying the highest gas price
+// first. Only the next request of each account is eligible at any time, so
+// a request with a high gas price can be delayed by a preceding request of
+// the same account with a lower one.
+//
+// On equal gas price, the accounts with less requests already selected come
+// first, so that a single sender cannot fill the proposal on its own.
+type feePriorityStrategy struct {
+	maxPerAccount int
+}
+
+func NewFeePriorityStrategy(maxPerAccount int) ProposalStrategy {
+	return &feePriorityStrategy{maxPerAccount: maxPerAccount}
+}
+
+func (s *feePriorityStrategy) Select(candidates [][]*OrderedPoolEntry, max int, gasPrice func(*OrderedPoolEntry) *big.Int) []*OrderedPoolEntry {
+	return selectByPriority(candidates, max, s.maxPerAccount, func(a, b *accountQueue) bool {
+		if cmp := gasPrice(a.next()).Cmp(gasPrice(b.next())); cmp != 0 {
+			return cmp > 0
+		}
+		if a.selected != b.selected {
+			return a.selected < b.selected
+		}
+		return cmpEntryByID(a.next(), b.next()) < 0
+	})
+}
+
+// arrivalStrategy proposes the requests in the order they were received,
+// ignoring the gas price.
+type arrivalStrategy struct {
+	maxPerAccount int
+}
+
+func NewArrivalStrategy(maxPerAccount int) ProposalStrategy {
+	return &arrivalStrategy{maxPerAccount: maxPerAccount}
+}
+
+func (s *arrivalStrategy) Select(candidates [][]*OrderedPoolEntry, max int, _ func(*OrderedPoolEntry) *big.Int) []*OrderedPoolEntry {
+	return selectByPriority(candidates, max, s.maxPerAccount, func(a, b *accountQueue) bool {
+		if !a.next().ts.Equal(b.next().ts) {
+			return a.next().ts.Before(b.next().ts)
+		}
+		return cmpEntryByID(a.next(), b.next()) < 0
+	})
+}
+
+// selectByPriority repeatedly picks the next request of the account with the
+// highest priority, until max requests are selected or there are no
+// candidates left.
+func selectByPriority(candidates [][]*OrderedPoolEntry, max, maxPerAccount int, less func(a, b *accountQueue) bool) []*OrderedPoolEntry {
+	q := &accountQueues{less: less}
+	for _, entries := range candidates {
+		if len(entries) > 0 {
+			q.queues = append(q.queues, &accountQueue{entries: entries})
+		}
+	}
+	heap.Init(q)
+
+	selected := []*OrderedPoolEntry{}
+	for q.Len() > 0 && len(selected) < max {
+		acc := q.queues[0]
+		selected = append(selected, acc.next())
+		acc.selected++
+		if acc.selected == len(acc.entries) || (maxPerAccount > 0 && acc.selected >= maxPerAccount) {
+			heap.Pop(q)
+			continue
+		}
+		heap.Fix(q, 0)
+	}
+	return selected
+}
+
+func cmpEntryByID(a, b *OrderedPoolEntry) int {
+	aID := a.req.ID()
+	bID := b.req.ID()
+	return bytes.Compare(aID[:], bID[:])
+}
+
+type accountQueue struct {
+	entries  []*OrderedPoolEntry
+	selected int
+}
+
+func (a *accountQueue) next() *OrderedPoolEntry {
+	return a.entries[a.selected]
+}
+
+// accountQueues implements heap.Interface
+type accountQueues struct {
+	queues []*accountQueue
+	less   func(a, b *accountQueue) bool
+}
+
+func (q *accountQueues) Len() int           { return len(q.queues) }
+func (q *accountQueues) Less(i, j int) bool { return q.less(q.queues[i], q.queues[j]) }
+func (q *accountQueues) Swap(i, j int)      { q.queues[i], q.queues[j] = q.queues[j], q.queues[i] }
+func (q *accountQueues) Push(x any)         { q.queues = append(q.queues, x.(*accountQueue)) }
+
+func (q *accountQueues) Pop() any {
+	n := len(q.queues) - 1
+	ret := q.queues[n]
+	q.queues = slices.Delete(q.queues, n, n+1)
+	return ret
+}
@@ -0,0 +1,96 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/isc/isctest"
+	"github.com/iotaledger/wasp/v2/packages/testutil"
+	"github.com/iotaledger/wasp/v2/packages/testutil/testkey"
+)
+
+type proposalStrategyTestEnv struct {
+	chainID isc.ChainID
+	prices  map[*OrderedPoolEntry]*big.Int
+	ts      time.Time
+}
+
+func newProposalStrategyTestEnv() *proposalStrategyTestEnv {
+	return &proposalStrategyTestEnv{
+		chainID: isctest.RandomChainID(),
+		prices:  map[*OrderedPoolEntry]*big.Int{},
+		ts:      time.Now(),
+	}
+}
+
+// account returns the requests of a new account, with the given gas prices,
+// ordered by nonce
+func (env *proposalStrategyTestEnv) account(prices ...int64) []*OrderedPoolEntry {
+	kp, _ := testkey.GenKeyAddr()
+	entries := make([]*OrderedPoolEntry, len(prices))
+	for i, price := range prices {
+		env.ts = env.ts.Add(time.Second)
+		entries[i] = &OrderedPoolEntry{
+			req: testutil.DummyOffledgerRequestForAccount(env.chainID, uint64(i), kp),
+			ts:  env.ts,
+		}
+		env.prices[entries[i]] = big.NewInt(price)
+	}
+	return entries
+}
+
+func (env *proposalStrategyTestEnv) gasPrice(e *OrderedPoolEntry) *big.Int {
+	return env.prices[e]
+}
+
+func TestFeePriorityStrategy(t *testing.T) {
+	env := newProposalStrategyTestEnv()
+	a := env.account(10, 50, 50)
+	b := env.account(20, 20)
+	c := env.account(30)
+
+	selected := NewFeePriorityStrategy(0).Select([][]*OrderedPoolEntry{a, b, c}, 100, env.gasPrice)
+	// a[1] and a[2] pay more, but are behind a[0]
+	require.Equal(t, []*OrderedPoolEntry{c[0], b[0], b[1], a[0], a[1], a[2]}, selected)
+
+	// partial selection
+	selected = NewFeePriorityStrategy(0).Select([][]*OrderedPoolEntry{a, b, c}, 2, env.gasPrice)
+	require.Equal(t, []*OrderedPoolEntry{c[0], b[0]}, selected)
+}
+
+func TestFeePriorityStrategyFairness(t *testing.T) {
+	env := newProposalStrategyTestEnv()
+	a := env.account(10, 10, 10, 10)
+	b := env.account(10, 10)
+
+	// on equal gas price, the accounts take turns
+	selected := NewFeePriorityStrategy(0).Select([][]*OrderedPoolEntry{a, b}, 4, env.gasPrice)
+	require.Len(t, selected, 4)
+	require.ElementsMatch(t, []*OrderedPoolEntry{a[0], a[1], b[0], b[1]}, selected)
+
+	// limit per account
+	selected = NewFeePriorityStrategy(1).Select([][]*OrderedPoolEntry{a, b}, 4, env.gasPrice)
+	require.ElementsMatch(t, []*OrderedPoolEntry{a[0], b[0]}, selected)
+}
+
+func TestArrivalStrategy(t *testing.T) {
+	env := newProposalStrategyTestEnv()
+	a := env.account(10, 10)
+	b := env.account(50)
+
+	selected := NewArrivalStrategy(0).Select([][]*OrderedPoolEntry{b, a}, 100, env.gasPrice)
+	require.Equal(t, []*OrderedPoolEntry{a[0], a[1], b[0]}, selected)
+}
+
+func TestNewProposalStrategy(t *testing.T) {
+	_, err := NewProposalStrategy(ProposalStrategyFeePriority, 0)
+	require.NoError(t, err)
+	_, err = NewProposalStrategy(ProposalStrategyArrival, 0)
+	require.NoError(t, err)
+	_, err = NewProposalStrategy("foo", 0)
+	require.Error(t, err)
+}
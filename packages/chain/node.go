@@ -110,7 +110,7 @@ type Chain interface {
 	GetChainMetrics() *metrics.ChainMetrics
 	GetConsensusPipeMetrics() ConsensusPipeMetrics
 	GetConsensusWorkflowStatus() ConsensusWorkflowStatus
-	IterateMempool(f func(req isc.Request) bool)
+	IterateMempool(f func(req isc.Request, ts time.Time) bool)
 }
 
 type CommitteeInfo struct {
@@ -283,6 +283,7 @@ func New(
 	smParameters smgpa.StateManagerParameters,
 	mempoolSettings mempool.Settings,
 	mempoolBroadcastInterval time.Duration,
+	mempoolJournal *mempool.OffLedgerJournal,
 	originDeposit coin.Value,
 	readOnlyPath string,
 ) (Chain, error) {
@@ -306,7 +307,7 @@ func New(
 			ctx, cni, netPeeringID, chainID, chainStore, nodeConn, nodeIdentity,
 			consensusStateRegistry, dkShareRegistryProvider, recoverFromWAL, blockWAL,
 			net, snapshotManager, chainMetrics, shutdownCoordinator, smParameters,
-			mempoolSettings, mempoolBroadcastInterval, accessNodesFromNode,
+			mempoolSettings, mempoolBroadcastInterval, mempoolJournal, accessNodesFromNode,
 			deriveAliasOutputByQuorum, pipeliningLimit, postponeRecoveryMilestones,
 			onChainConnect, onChainDisconnect, log,
 		)
@@ -1135,7 +1136,7 @@ func (cni *chainNodeImpl) GetConsensusWorkflowStatus() ConsensusWorkflowStatus {
 	return &consensusWorkflowStatusImpl{}
 }
 
-func (cni *chainNodeImpl) IterateMempool(f func(req isc.Request) bool) {
+func (cni *chainNodeImpl) IterateMempool(f func(req isc.Request, ts time.Time) bool) {
 	cni.mempool.Iterate(f)
 }
 
@@ -1216,6 +1217,7 @@ func initializeOperationalChain(
 	smParameters smgpa.StateManagerParameters,
 	mempoolSettings mempool.Settings,
 	mempoolBroadcastInterval time.Duration,
+	mempoolJournal *mempool.OffLedgerJournal,
 	accessNodesFromNode []*cryptolib.PublicKey,
 	deriveAliasOutputByQuorum bool,
 	pipeliningLimit int,
@@ -1254,7 +1256,7 @@ func initializeOperationalChain(
 
 	// Create mempool
 	mempool := createMempool(ctx, chainID, nodeIdentity, net, cni, chainMetrics,
-		mempoolSettings, mempoolBroadcastInterval, nodeConn)
+		mempoolSettings, mempoolBroadcastInterval, mempoolJournal, nodeConn)
 
 	cni.chainMgr = gpa.NewAckHandler(cni.me, chainMgr.AsGPA(), RedeliveryPeriod)
 	cni.stateMgr = stateMgr
@@ -1443,6 +1445,7 @@ func createMempool(
 	chainMetrics *metrics.ChainMetrics,
 	mempoolSettings mempool.Settings,
 	mempoolBroadcastInterval time.Duration,
+	mempoolJournal *mempool.OffLedgerJournal,
 	nodeConn NodeConnection,
 ) mempool.Mempool {
 	return mempool.New(
@@ -1457,6 +1460,7 @@ func createMempool(
 		mempoolSettings,
 		mempoolBroadcastInterval,
 		func() { nodeConn.RefreshOnLedgerRequests(ctx, chainID) },
+		mempoolJournal,
 	)
 }
 
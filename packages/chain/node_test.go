@@ -598,6 +598,7 @@ func newEnv(t *testing.T, n, f int, reliable bool, node l1starter.IotaNodeEndpoi
 				MaxOffledgerPerAccount: 1000,
 			},
 			1*time.Second,
+			nil,
 			originDeposit,
 			"",
 		)
@@ -91,6 +91,10 @@ func (ros *readOnlyStore) TakeSnapshot(trieRoot trie.Hash, w io.Writer) error {
 	return ros.store.TakeSnapshot(trieRoot, w)
 }
 
+func (ros *readOnlyStore) TakeDeltaSnapshot(baseTrieRoot, trieRoot trie.Hash, w io.Writer) error {
+	return ros.store.TakeDeltaSnapshot(baseTrieRoot, trieRoot, w)
+}
+
 func (ros *readOnlyStore) RestoreSnapshot(trie.Hash, io.Reader, bool) error {
 	return fmt.Errorf("cannot write snapshot into read-only store")
 }
@@ -102,3 +106,11 @@ func (ros *readOnlyStore) IsRefcountsEnabled() bool {
 func (ros *readOnlyStore) CheckIntegrity(w io.Writer) {
 	ros.store.CheckIntegrity(w)
 }
+
+func (ros *readOnlyStore) VerifyIntegrity() *state.IntegrityReport {
+	return ros.store.VerifyIntegrity()
+}
+
+func (ros *readOnlyStore) RepairRefcounts(*state.IntegrityReport) error {
+	return fmt.Errorf("cannot repair refcounts in read-only store")
+}
@@ -3,6 +3,7 @@ package snapshots
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -45,33 +46,17 @@ func NewDownloader(
 	filePath string,
 	chunkSize ...uint64,
 ) (Downloader, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodHead, filePath, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make head request to %s: %w", filePath, err)
-	}
-	head, err := http.DefaultClient.Do(request)
+	info, err := getRemoteFileInfo(ctx, filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to receive header for url %s: %w", filePath, err)
-	}
-	defer head.Body.Close()
-
-	if head.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("head request to %s got status code %v", filePath, head.StatusCode)
+		return nil, err
 	}
-
-	acceptRanges := head.Header.Get("Accept-Ranges")
-	fileSizeStr := head.Header.Get("Content-Length")
-	fileSize, err := strconv.ParseUint(fileSizeStr, 10, 64)
 	result := &downloaderImpl{
 		ctx:        ctx,
 		filePath:   filePath,
-		fileSize:   fileSize,
+		fileSize:   info.size,
 		onCloseFun: func() {},
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert file length %v to integer: %w", fileSizeStr, err)
-	}
-	if acceptRanges == "" || strings.ToLower(acceptRanges) == "none" {
+	if !info.acceptRanges {
 		result.chunkSize = 0
 	} else {
 		if len(chunkSize) > 0 {
@@ -165,48 +150,91 @@ func (d *downloaderImpl) GetLength() uint64 {
 	return d.fileSize
 }
 
-// DownloadToFile uses downloader to download data to file. Temporary file is created while
-// download is in progress and only on finishing the download, the file is
-// renamed to provided name. Progress reporter wrapped in `TeeReader` (or any
-// other reader) can be wrapped around downloader (provided by parameter of the
-// function). If it is not needed, `addProgressReporter` function should return
-// the reader in the parameter.
+// remoteFileInfo is the information about the network file, obtained by HEAD request
+type remoteFileInfo struct {
+	url          string
+	size         uint64
+	acceptRanges bool
+	lastModified string
+	etag         string // Strong entity tag of the file; empty, if not provided
+}
+
+// isSameContent returns true, if both files are known to be byte-identical.
+// Only a strong entity tag identifies the content: files of the same size and
+// modification time may still differ.
+func (rfi *remoteFileInfo) isSameContent(other *remoteFileInfo) bool {
+	return rfi.etag != "" && rfi.etag == other.etag && rfi.size == other.size
+}
+
+func getRemoteFileInfo(ctx context.Context, filePath string) (*remoteFileInfo, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, filePath, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make head request to %s: %w", filePath, err)
+	}
+	head, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive header for url %s: %w", filePath, err)
+	}
+	defer head.Body.Close()
+
+	if head.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("head request to %s got status code %v", filePath, head.StatusCode)
+	}
+
+	acceptRanges := head.Header.Get("Accept-Ranges")
+	fileSizeStr := head.Header.Get("Content-Length")
+	fileSize, err := strconv.ParseUint(fileSizeStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert file length %v to integer: %w", fileSizeStr, err)
+	}
+	etag := head.Header.Get("ETag")
+	if strings.HasPrefix(etag, "W/") {
+		etag = "" // Weak entity tags do not guarantee byte-identical content
+	}
+	return &remoteFileInfo{
+		url:          filePath,
+		size:         fileSize,
+		acceptRanges: acceptRanges != "" && strings.ToLower(acceptRanges) != "none",
+		lastModified: head.Header.Get("Last-Modified"),
+		etag:         etag,
+	}, nil
+}
+
+// DownloadToFile downloads the file, available in one or several mirrors, to
+// the local file. Temporary file is created while download is in progress and
+// only on finishing the download, the file is renamed to provided name.
+//
+// If the mirrors support `Range` requests, the file is downloaded in chunks by
+// several parallel workers, each chunk from any of the mirrors, which have the
+// same content as the first available mirror, as identified by the `ETag`
+// header. The progress of such download is persisted next to
+// the temporary file, so if the download is interrupted, calling this function
+// again resumes it. Otherwise the file is downloaded sequentially from the
+// first available mirror.
+//
+// `newProgressReporter` is called once per download; the returned writer
+// receives all the downloaded data. If progress reporting is not needed, it
+// should return `io.Discard`.
 func DownloadToFile(
 	ctx context.Context,
-	filePathNetwork string,
+	filePathsNetwork []string,
 	filePathLocal string,
 	timeout time.Duration,
-	addProgressReporter func(io.Reader, string, uint64) io.Reader,
+	newProgressReporter func(string, uint64) io.Writer,
 ) error {
-	filePathTemp := filePathLocal + tempFileSuffixConst
-	err := func() error { // Function is used to make deferred close occur when it is needed even if write is successful
-		downloader, e := NewDownloaderWithTimeout(ctx, filePathNetwork, timeout)
-		if e != nil {
-			return fmt.Errorf("failed to start downloading %s: %w", filePathNetwork, e)
-		}
-		defer downloader.Close()
-		r := addProgressReporter(downloader, filePathNetwork, downloader.GetLength())
-
-		f, e := os.OpenFile(filePathTemp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
-		if e != nil {
-			return fmt.Errorf("failed to create temporary file %s: %w", filePathTemp, e)
-		}
-		defer f.Close()
+	ctxWithTimeout, ctxWithTimeoutCancel := context.WithTimeout(ctx, timeout)
+	defer ctxWithTimeoutCancel()
 
-		n, e := io.Copy(f, r)
-		if e != nil {
-			return fmt.Errorf("error downloading and saving url %s to file %s: %w", filePathNetwork, filePathTemp, e)
-		}
-		lengthInt64, err := safecast.Convert[int64](downloader.GetLength())
-		if err != nil {
-			return fmt.Errorf("integer overflow in downloader length: %w", err)
-		}
-		if n != lengthInt64 {
-			return fmt.Errorf("downloaded file %s was not written completely: of %v bytes to download only %v byte written",
-				filePathNetwork, downloader.GetLength(), n)
-		}
-		return nil
-	}()
+	filePathTemp := filePathLocal + tempFileSuffixConst
+	mirrors, err := getMirrors(ctxWithTimeout, filePathsNetwork)
+	if err != nil {
+		return err
+	}
+	if mirrors[0].acceptRanges {
+		err = downloadRangesToFile(ctxWithTimeout, mirrors, filePathTemp, defaultChunkSizeConst, parallelDownloadWorkersConst, newProgressReporter)
+	} else {
+		err = downloadSequentiallyToFile(ctxWithTimeout, mirrors[0].url, filePathTemp, newProgressReporter)
+	}
 	if err != nil {
 		return err
 	}
@@ -217,3 +245,64 @@ func DownloadToFile(
 	}
 	return nil
 }
+
+// getMirrors returns the mirrors, from which the file can be downloaded. The
+// first available mirror defines the content of the file; the other mirrors
+// are used only if they support `Range` requests and have the same strong
+// `ETag`. Otherwise the file is downloaded from the first mirror only.
+func getMirrors(ctx context.Context, filePathsNetwork []string) ([]*remoteFileInfo, error) {
+	var errs error
+	result := make([]*remoteFileInfo, 0, len(filePathsNetwork))
+	for _, filePathNetwork := range filePathsNetwork {
+		info, err := getRemoteFileInfo(ctx, filePathNetwork)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if len(result) == 0 {
+			result = append(result, info)
+			continue
+		}
+		if result[0].acceptRanges && info.acceptRanges && info.isSameContent(result[0]) {
+			result = append(result, info)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("failed to start downloading %v: %w", filePathsNetwork, errs)
+	}
+	return result, nil
+}
+
+func downloadSequentiallyToFile(
+	ctx context.Context,
+	filePathNetwork string,
+	filePathTemp string,
+	newProgressReporter func(string, uint64) io.Writer,
+) error {
+	downloader, err := NewDownloader(ctx, filePathNetwork)
+	if err != nil {
+		return fmt.Errorf("failed to start downloading %s: %w", filePathNetwork, err)
+	}
+	defer downloader.Close()
+	r := io.TeeReader(downloader, newProgressReporter(filePathNetwork, downloader.GetLength()))
+
+	f, err := os.OpenFile(filePathTemp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file %s: %w", filePathTemp, err)
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, r)
+	if err != nil {
+		return fmt.Errorf("error downloading and saving url %s to file %s: %w", filePathNetwork, filePathTemp, err)
+	}
+	lengthInt64, err := safecast.Convert[int64](downloader.GetLength())
+	if err != nil {
+		return fmt.Errorf("integer overflow in downloader length: %w", err)
+	}
+	if n != lengthInt64 {
+		return fmt.Errorf("downloaded file %s was not written completely: of %v bytes to download only %v byte written",
+			filePathNetwork, downloader.GetLength(), n)
+	}
+	return nil
+}
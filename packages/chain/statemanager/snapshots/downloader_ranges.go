@@ -0,0 +1,286 @@
+package snapshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fortio.org/safecast"
+
+	"github.com/iotaledger/wasp/v2/packages/util/rwutil"
+)
+
+// rangeDownload downloads a file in chunks using `Range` requests. Chunks
+// are downloaded in parallel, possibly from different mirrors, and written to
+// their places in the temporary file. The set of completed chunks is stored in
+// the progress file, so that the download can be resumed. To avoid syncing the
+// file after every chunk, the chunks are recorded as completed in batches.
+type rangeDownload struct {
+	mirrors          []*remoteFileInfo
+	file             *os.File
+	progressFilePath string
+	chunkSize        uint64
+	chunkCount       uint64
+	done             []byte   // Bit set of completed chunks
+	pending          []uint64 // Chunks written to the file, but not yet recorded as completed
+	lastFlush        time.Time
+	progress         io.Writer
+	mutex            sync.Mutex
+}
+
+const (
+	parallelDownloadWorkersConst = 4
+	chunkAttemptsPerMirrorConst  = 2
+	progressFileSuffixConst      = ".progress"
+	progressFileVersionConst     = byte(2)
+	progressFlushChunksConst     = 64
+	progressFlushPeriodConst     = 5 * time.Second
+)
+
+func downloadRangesToFile(
+	ctx context.Context,
+	mirrors []*remoteFileInfo,
+	filePathTemp string,
+	chunkSize uint64,
+	workers int,
+	newProgressReporter func(string, uint64) io.Writer,
+) error {
+	fileSize := mirrors[0].size
+	chunkCount := (fileSize + chunkSize - 1) / chunkSize
+	rd := &rangeDownload{
+		mirrors:          mirrors,
+		progressFilePath: filePathTemp + progressFileSuffixConst,
+		chunkSize:        chunkSize,
+		chunkCount:       chunkCount,
+		lastFlush:        time.Now(),
+	}
+	rd.done = rd.readProgress()
+	if _, err := os.Stat(filePathTemp); err != nil {
+		rd.done = make([]byte, len(rd.done)) // Progress is worthless without the data
+	}
+
+	var err error
+	rd.file, err = os.OpenFile(filePathTemp, os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return fmt.Errorf("failed to open temporary file %s: %w", filePathTemp, err)
+	}
+	defer rd.file.Close()
+	fileSizeInt64, err := safecast.Convert[int64](fileSize)
+	if err != nil {
+		return fmt.Errorf("integer overflow in file size: %w", err)
+	}
+	err = rd.file.Truncate(fileSizeInt64)
+	if err != nil {
+		return fmt.Errorf("failed to resize temporary file %s: %w", filePathTemp, err)
+	}
+
+	chunks := make(chan uint64, chunkCount)
+	remaining := uint64(0)
+	for i := range chunkCount {
+		if !rd.isDone(i) {
+			chunks <- i
+			start, end := rd.chunkRange(i, fileSize)
+			remaining += end - start
+		}
+	}
+	close(chunks)
+	rd.progress = newProgressReporter(mirrors[0].url, remaining)
+
+	ctxWithCancel, ctxCancel := context.WithCancel(ctx)
+	defer ctxCancel()
+	var wg sync.WaitGroup
+	var errMutex sync.Mutex
+	var errs error
+	for range min(workers, len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				if ctxWithCancel.Err() != nil {
+					return
+				}
+				e := rd.downloadChunk(ctxWithCancel, chunk, fileSize)
+				if e != nil {
+					errMutex.Lock()
+					errs = errors.Join(errs, e)
+					errMutex.Unlock()
+					ctxCancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if errs == nil {
+		errs = ctx.Err()
+	}
+	if errs != nil {
+		// The chunks downloaded so far must not be downloaded again
+		rd.mutex.Lock()
+		err = rd.flushProgress()
+		rd.mutex.Unlock()
+		return fmt.Errorf("download to %s interrupted; it can be resumed: %w", filePathTemp, errors.Join(errs, err))
+	}
+	err = rd.file.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync temporary file %s: %w", filePathTemp, err)
+	}
+	err = os.Remove(rd.progressFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove progress file %s: %w", rd.progressFilePath, err)
+	}
+	return nil
+}
+
+// downloadChunk tries to download the chunk from every mirror in turn, starting
+// with a different mirror for different chunks to spread the load
+func (rd *rangeDownload) downloadChunk(ctx context.Context, chunk uint64, fileSize uint64) error {
+	start, end := rd.chunkRange(chunk, fileSize)
+	var errs error
+	for attempt := range uint64(len(rd.mirrors) * chunkAttemptsPerMirrorConst) {
+		mirror := rd.mirrors[(chunk+attempt)%uint64(len(rd.mirrors))]
+		data, err := getRange(ctx, mirror.url, start, end)
+		if err == nil {
+			return rd.chunkDownloaded(chunk, start, data)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		errs = errors.Join(errs, err)
+	}
+	return fmt.Errorf("failed to download byte %v to %v: %w", start, end, errs)
+}
+
+func (rd *rangeDownload) chunkDownloaded(chunk uint64, start uint64, data []byte) error {
+	startInt64, err := safecast.Convert[int64](start)
+	if err != nil {
+		return fmt.Errorf("integer overflow in chunk start: %w", err)
+	}
+	_, err = rd.file.WriteAt(data, startInt64)
+	if err != nil {
+		return fmt.Errorf("failed to write byte %v to %v to file: %w", start, start+uint64(len(data)), err)
+	}
+
+	rd.mutex.Lock()
+	defer rd.mutex.Unlock()
+	_, _ = rd.progress.Write(data)
+	rd.pending = append(rd.pending, chunk)
+	if len(rd.pending) < progressFlushChunksConst && time.Since(rd.lastFlush) < progressFlushPeriodConst {
+		return nil
+	}
+	return rd.flushProgress()
+}
+
+// flushProgress records the pending chunks as completed in the progress file.
+// The mutex must be held.
+func (rd *rangeDownload) flushProgress() error {
+	if len(rd.pending) == 0 {
+		return nil
+	}
+	// The data must reach the disk before the chunks are recorded as completed
+	err := rd.file.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	for _, chunk := range rd.pending {
+		rd.done[chunk/8] |= 1 << (chunk % 8)
+	}
+	rd.pending = rd.pending[:0]
+	rd.lastFlush = time.Now()
+	return rd.writeProgress()
+}
+
+func (rd *rangeDownload) chunkRange(chunk uint64, fileSize uint64) (uint64, uint64) {
+	start := chunk * rd.chunkSize
+	return start, min(start+rd.chunkSize, fileSize)
+}
+
+func (rd *rangeDownload) isDone(chunk uint64) bool {
+	return rd.done[chunk/8]&(1<<(chunk%8)) != 0
+}
+
+// readProgress returns the set of completed chunks, stored in the progress
+// file. If the file is missing or was made for another version of the
+// downloaded file or with another chunk size, the download starts from scratch.
+// Without an entity tag, the progress is used only if it was made for the same
+// url.
+func (rd *rangeDownload) readProgress() []byte {
+	empty := make([]byte, (rd.chunkCount+7)/8)
+	data, err := os.ReadFile(rd.progressFilePath)
+	if err != nil {
+		return empty
+	}
+	rr := rwutil.NewBytesReader(data)
+	version := rr.ReadByte()
+	fileSize := rr.ReadUint64()
+	url := rr.ReadString()
+	etag := rr.ReadString()
+	lastModified := rr.ReadString()
+	chunkSize := rr.ReadUint64()
+	done := rr.ReadBytes()
+	rr.Close()
+	if rr.Err != nil ||
+		version != progressFileVersionConst ||
+		fileSize != rd.mirrors[0].size ||
+		etag != rd.mirrors[0].etag ||
+		(etag == "" && url != rd.mirrors[0].url) ||
+		lastModified != rd.mirrors[0].lastModified ||
+		chunkSize != rd.chunkSize ||
+		len(done) != len(empty) {
+		return empty
+	}
+	return done
+}
+
+func (rd *rangeDownload) writeProgress() error {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteByte(progressFileVersionConst)
+	ww.WriteUint64(rd.mirrors[0].size)
+	ww.WriteString(rd.mirrors[0].url)
+	ww.WriteString(rd.mirrors[0].etag)
+	ww.WriteString(rd.mirrors[0].lastModified)
+	ww.WriteUint64(rd.chunkSize)
+	ww.WriteBytes(rd.done)
+	// The progress file is replaced atomically, so that it is never corrupted
+	tmpFilePath := rd.progressFilePath + constSnapshotTmpFileSuffix
+	err := os.WriteFile(tmpFilePath, ww.Bytes(), 0o666)
+	if err != nil {
+		return fmt.Errorf("failed to write progress file %s: %w", tmpFilePath, err)
+	}
+	return os.Rename(tmpFilePath, rd.progressFilePath)
+}
+
+// getRange downloads bytes from `start` (inclusive) to `end` (exclusive) of the file
+func getRange(ctx context.Context, filePath string, start uint64, end uint64) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, filePath, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make get request to %s: %w", filePath, err)
+	}
+	request.Header.Add("Range", "bytes="+strconv.FormatUint(start, 10)+"-"+strconv.FormatUint(end-1, 10))
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get byte %v to %v from %s: %w", start, end, filePath, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("get byte %v to %v request to %s got status code %v", start, end, filePath, response.StatusCode)
+	}
+	// Content-Range is "bytes <first>-<last>/<size>"
+	contentRange := response.Header.Get("Content-Range")
+	if !strings.HasPrefix(contentRange, "bytes "+strconv.FormatUint(start, 10)+"-"+strconv.FormatUint(end-1, 10)+"/") {
+		return nil, fmt.Errorf("get byte %v to %v request to %s got content range %q", start, end, filePath, contentRange)
+	}
+	data := make([]byte, end-start)
+	_, err = io.ReadFull(response.Body, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read byte %v to %v from %s: %w", start, end, filePath, err)
+	}
+	return data, nil
+}
@@ -6,10 +6,14 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,7 +22,10 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/util/rwutil"
 )
 
-const downloaderServerPathConst = "testDownloader"
+const (
+	downloaderServerPathConst   = "testDownloader"
+	downloaderTestFileNameConst = "TestFile.bin"
+)
 
 // TODO: test reading without chunks. How to create a file server which pretends to not support `Range` header?
 
@@ -138,6 +145,157 @@ func TestDownloaderReadTooMuch(t *testing.T) {
 	)
 }
 
+func TestDownloadToFileMirrors(t *testing.T) {
+	data := writeDownloaderTestFile(t, 3*int(defaultChunkSizeConst)+5)
+	fileServer := withETag(`"v1"`, http.FileServer(http.Dir(downloaderServerPathConst)))
+	good := httptest.NewServer(fileServer)
+	defer good.Close()
+	// Second mirror fails to serve any chunk
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	}))
+	defer broken.Close()
+
+	filePathLocal := filepath.Join(downloaderServerPathConst, "Downloaded.bin")
+	err := DownloadToFile(
+		context.Background(),
+		[]string{broken.URL + "/" + downloaderTestFileNameConst, good.URL + "/" + downloaderTestFileNameConst},
+		filePathLocal,
+		time.Minute,
+		func(string, uint64) io.Writer { return io.Discard },
+	)
+	require.NoError(t, err)
+	downloaded, err := os.ReadFile(filePathLocal)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+}
+
+func TestDownloadToFileDifferentMirrors(t *testing.T) {
+	data := writeDownloaderTestFile(t, 3*int(defaultChunkSizeConst)+5)
+	fileServer := http.FileServer(http.Dir(downloaderServerPathConst))
+	first := httptest.NewServer(withETag(`"v1"`, fileServer))
+	defer first.Close()
+	// The file of the second mirror has the same size, but another content
+	var otherRangeRequests atomic.Int32
+	other := httptest.NewServer(withETag(`"v2"`, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			otherRangeRequests.Add(1)
+		}
+		fileServer.ServeHTTP(w, r)
+	})))
+	defer other.Close()
+	// Without an entity tag, the content of the mirrors is unknown
+	noETag := httptest.NewServer(fileServer)
+	defer noETag.Close()
+
+	mirrors, err := getMirrors(context.Background(), []string{
+		first.URL + "/" + downloaderTestFileNameConst,
+		other.URL + "/" + downloaderTestFileNameConst,
+	})
+	require.NoError(t, err)
+	require.Len(t, mirrors, 1)
+	mirrors, err = getMirrors(context.Background(), []string{
+		noETag.URL + "/" + downloaderTestFileNameConst,
+		noETag.URL + "/" + downloaderTestFileNameConst,
+	})
+	require.NoError(t, err)
+	require.Len(t, mirrors, 1)
+
+	filePathLocal := filepath.Join(downloaderServerPathConst, "Downloaded.bin")
+	err = DownloadToFile(
+		context.Background(),
+		[]string{first.URL + "/" + downloaderTestFileNameConst, other.URL + "/" + downloaderTestFileNameConst},
+		filePathLocal,
+		time.Minute,
+		func(string, uint64) io.Writer { return io.Discard },
+	)
+	require.NoError(t, err)
+	require.Zero(t, otherRangeRequests.Load())
+	downloaded, err := os.ReadFile(filePathLocal)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+}
+
+func TestDownloadToFileResume(t *testing.T) {
+	const chunkSize = 1024
+	data := writeDownloaderTestFile(t, 10*chunkSize+5)
+	fileServer := http.FileServer(http.Dir(downloaderServerPathConst))
+	var rangeRequests atomic.Int32
+	var failAfter atomic.Int32
+	failAfter.Store(3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" && rangeRequests.Add(1) > failAfter.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	filePathTemp := filepath.Join(downloaderServerPathConst, "Downloaded.bin"+tempFileSuffixConst)
+	download := func() error {
+		mirrors, err := getMirrors(context.Background(), []string{server.URL + "/" + downloaderTestFileNameConst})
+		require.NoError(t, err)
+		return downloadRangesToFile(context.Background(), mirrors, filePathTemp, chunkSize, 1, func(string, uint64) io.Writer { return io.Discard })
+	}
+	require.Error(t, download())
+	require.FileExists(t, filePathTemp+progressFileSuffixConst)
+
+	// Only the chunks, which were not downloaded, are requested again
+	rangeRequests.Store(0)
+	failAfter.Store(100)
+	require.NoError(t, download())
+	require.EqualValues(t, 8, rangeRequests.Load())
+	require.NoFileExists(t, filePathTemp+progressFileSuffixConst)
+	downloaded, err := os.ReadFile(filePathTemp)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+}
+
+func TestDownloadToFileWrongContentRange(t *testing.T) {
+	const chunkSize = 1024
+	writeDownloaderTestFile(t, 3*chunkSize)
+	fileServer := http.FileServer(http.Dir(downloaderServerPathConst))
+	// The server ignores the requested range and always serves the first chunk
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			r.Header.Set("Range", "bytes=0-"+strconv.Itoa(chunkSize-1))
+		}
+		fileServer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	mirrors, err := getMirrors(context.Background(), []string{server.URL + "/" + downloaderTestFileNameConst})
+	require.NoError(t, err)
+	filePathTemp := filepath.Join(downloaderServerPathConst, "Downloaded.bin"+tempFileSuffixConst)
+	err = downloadRangesToFile(context.Background(), mirrors, filePathTemp, chunkSize, 1, func(string, uint64) io.Writer { return io.Discard })
+	require.ErrorContains(t, err, "content range")
+}
+
+func withETag(etag string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func writeDownloaderTestFile(t *testing.T, size int) []byte {
+	t.Cleanup(func() { cleanupAfterDownloaderTest(t) })
+	err := ioutils.CreateDirectory(downloaderServerPathConst, 0o777)
+	require.NoError(t, err)
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	err = os.WriteFile(filepath.Join(downloaderServerPathConst, downloaderTestFileNameConst), data, 0o666)
+	require.NoError(t, err)
+	return data
+}
+
 func startServer(t *testing.T, port string, handler http.Handler) {
 	listener, err := net.Listen("tcp", port)
 	require.NoError(t, err)
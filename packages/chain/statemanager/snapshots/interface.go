@@ -20,6 +20,23 @@ type SnapshotManager interface {
 	BlockCommittedAsync(SnapshotInfo)
 }
 
+// SnapshotAdmin allows node operators to manage the local snapshots on demand,
+// in addition to the ones made periodically by SnapshotManager.
+type SnapshotAdmin interface {
+	CreateSnapshot(SnapshotInfo) (string, error)
+	ListSnapshots() ([]*LocalSnapshot, error)
+	DeleteSnapshot(fileName string) error
+	ExportSnapshot(SnapshotInfo, io.Writer) error
+}
+
+// LocalSnapshot is a snapshot file in the local snapshot folder
+type LocalSnapshot struct {
+	FileName string
+	Info     SnapshotInfo
+	Base     SnapshotInfo // nil, if it is a full snapshot
+	Size     int64
+}
+
 type SnapshotInfo interface {
 	StateIndex() uint32
 	Commitment() *state.L1Commitment
@@ -37,9 +54,13 @@ type snapshotManagerCore interface {
 // snapshotter is responsible for moving the snapshot between store and external
 // sources/destinations. It can:
 // * take required snapshot from the store and write it to some `Writer` (`storeSnapshot` method)
+// * take the difference between base (first parameter) and required snapshot from the store and write it to some `Writer` (`storeDeltaSnapshot` method)
+// * check the integrity and the signature of the snapshot in some `Reader` (`verifySnapshot` method)
 // * read the snapshot from some `Reader` and put it to the store (`loadSnapshot` method).
 type snapshotter interface {
 	storeSnapshot(SnapshotInfo, io.Writer) error
+	storeDeltaSnapshot(SnapshotInfo, SnapshotInfo, io.Writer) error
+	verifySnapshot(SnapshotInfo, io.Reader) error
 	loadSnapshot(SnapshotInfo, io.Reader) error
 }
 
@@ -0,0 +1,422 @@
+package snapshots
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/samber/lo"
+	"golang.org/x/crypto/blake2b"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
+	"github.com/iotaledger/wasp/v2/packages/hashing"
+	"github.com/iotaledger/wasp/v2/packages/state"
+	"github.com/iotaledger/wasp/v2/packages/trie"
+	"github.com/iotaledger/wasp/v2/packages/util/rwutil"
+)
+
+// Snapshot container is the format, in which snapshots are stored to files and
+// served over the network. It consists of:
+//   - magic bytes and version of the container format,
+//   - header with state index, L1 commitment and trie root of the snapshot;
+//     since version 2, also state index and L1 commitment of the base
+//     snapshot, if it is a delta snapshot,
+//   - the snapshot itself (as produced by `state.Store.TakeSnapshot` or
+//     `state.Store.TakeDeltaSnapshot`), split
+//     into chunks; each chunk is zstd compressed and followed by the hash of
+//     the compressed bytes,
+//   - an empty chunk marking the end of the snapshot,
+//   - an optional signature of the container digest.
+//
+// The container digest is the hash of the version, the header and the hashes
+// of all the chunks, so the signature covers the whole container.
+//
+// A delta snapshot contains only the trie nodes and values, which are not
+// present in the state of its base snapshot, so it can be loaded only after
+// the base snapshot (full or delta) is loaded.
+//
+// Snapshots written before the container was introduced consist of the state
+// index and L1 commitment followed by the raw snapshot. They can still be read,
+// but they cannot be verified.
+
+const (
+	constSnapshotContainerVersion = byte(2)
+	// Version 1 does not support delta snapshots
+	constSnapshotContainerVersionNoDelta = byte(1)
+	constSnapshotChunkSize               = 4 << 20 // 4 MiB of uncompressed snapshot data
+	constSnapshotMaxChunkSize            = 2 * constSnapshotChunkSize
+)
+
+var (
+	snapshotContainerMagic = [4]byte{'W', 'S', 'N', 'P'}
+	// Legacy snapshots start with the length of the state index array
+	legacySnapshotMagic = [4]byte{4, 0, 0, 0}
+)
+
+type snapshotHeader struct {
+	version    byte
+	stateIndex uint32
+	commitment *state.L1Commitment
+	trieRoot   trie.Hash
+	base       SnapshotInfo // nil, if it is a full snapshot
+}
+
+// newSnapshotHeader creates a header of a full snapshot, if `base` is nil, or
+// of a delta snapshot otherwise
+func newSnapshotHeader(snapshotInfo SnapshotInfo, base SnapshotInfo) *snapshotHeader {
+	return &snapshotHeader{
+		version:    constSnapshotContainerVersion,
+		stateIndex: snapshotInfo.StateIndex(),
+		commitment: snapshotInfo.Commitment(),
+		trieRoot:   snapshotInfo.TrieRoot(),
+		base:       base,
+	}
+}
+
+func (h *snapshotHeader) isLegacy() bool {
+	return h.version == 0
+}
+
+func (h *snapshotHeader) isDelta() bool {
+	return h.base != nil
+}
+
+func (h *snapshotHeader) snapshotInfo() SnapshotInfo {
+	return NewSnapshotInfo(h.stateIndex, h.commitment)
+}
+
+func (h *snapshotHeader) Bytes() []byte {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint32(h.stateIndex)
+	ww.WriteBytes(h.commitment.Bytes())
+	ww.WriteN(h.trieRoot[:])
+	if h.version > constSnapshotContainerVersionNoDelta {
+		ww.WriteBool(h.isDelta())
+		if h.isDelta() {
+			ww.WriteUint32(h.base.StateIndex())
+			ww.WriteBytes(h.base.Commitment().Bytes())
+		}
+	}
+	return ww.Bytes()
+}
+
+// readSnapshotHeader reads the header of either the container or the legacy
+// snapshot. After it returns, the reader is positioned at the first chunk of
+// the container or at the raw legacy snapshot.
+func readSnapshotHeader(r io.Reader) (*snapshotHeader, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("failed to read magic bytes: %w", err)
+	}
+	switch magic {
+	case legacySnapshotMagic:
+		return readLegacySnapshotHeader(r)
+	case snapshotContainerMagic:
+	default:
+		return nil, fmt.Errorf("unknown snapshot format %x", magic)
+	}
+
+	rr := rwutil.NewReader(r)
+	version := rr.ReadByte()
+	if rr.Err != nil {
+		return nil, fmt.Errorf("failed to read container version: %w", rr.Err)
+	}
+	if version != constSnapshotContainerVersion && version != constSnapshotContainerVersionNoDelta {
+		return nil, fmt.Errorf("unsupported container version %v", version)
+	}
+	headerBytes := rr.ReadBytes()
+	if rr.Err != nil {
+		return nil, fmt.Errorf("failed to read container header: %w", rr.Err)
+	}
+	hr := rwutil.NewBytesReader(headerBytes)
+	header := &snapshotHeader{version: version}
+	header.stateIndex = hr.ReadUint32()
+	commitmentBytes := hr.ReadBytes()
+	hr.ReadN(header.trieRoot[:])
+	var baseIndex uint32
+	var baseCommitmentBytes []byte
+	isDelta := version > constSnapshotContainerVersionNoDelta && hr.ReadBool()
+	if isDelta {
+		baseIndex = hr.ReadUint32()
+		baseCommitmentBytes = hr.ReadBytes()
+	}
+	hr.Close()
+	if hr.Err != nil {
+		return nil, fmt.Errorf("failed to parse container header: %w", hr.Err)
+	}
+	commitment, err := state.NewL1CommitmentFromBytes(commitmentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse L1 commitment: %w", err)
+	}
+	header.commitment = commitment
+	if !header.trieRoot.Equals(commitment.TrieRoot()) {
+		return nil, fmt.Errorf("trie root %s does not match L1 commitment %s", header.trieRoot, commitment)
+	}
+	if isDelta {
+		baseCommitment, err := state.NewL1CommitmentFromBytes(baseCommitmentBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse base L1 commitment: %w", err)
+		}
+		header.base = NewSnapshotInfo(baseIndex, baseCommitment)
+	}
+	return header, nil
+}
+
+// readLegacySnapshotHeader reads the rest of the legacy snapshot header after
+// the length of the state index array is read as magic bytes
+func readLegacySnapshotHeader(r io.Reader) (*snapshotHeader, error) {
+	var indexArray [4]byte
+	if _, err := io.ReadFull(r, indexArray[:]); err != nil {
+		return nil, fmt.Errorf("failed to read block index: %w", err)
+	}
+	index := binary.LittleEndian.Uint32(indexArray[:])
+
+	trieRootArray, err := readBytes(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trie root: %w", err)
+	}
+	commitment, err := state.NewL1CommitmentFromBytes(trieRootArray)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse L1 commitment: %w", err)
+	}
+	return &snapshotHeader{
+		stateIndex: index,
+		commitment: commitment,
+		trieRoot:   commitment.TrieRoot(),
+	}, nil
+}
+
+// snapshotContainerWriter writes the container; the raw snapshot must be
+// written to it and the container finalised by calling `Close`.
+type snapshotContainerWriter struct {
+	ww      *rwutil.Writer
+	encoder *zstd.Encoder
+	digest  hash.Hash
+	signer  cryptolib.Signer
+	chunk   []byte
+}
+
+var _ io.WriteCloser = &snapshotContainerWriter{}
+
+// newSnapshotContainerWriter writes the header of the container. If `signer`
+// is not nil, the container is signed by it.
+func newSnapshotContainerWriter(w io.Writer, header *snapshotHeader, signer cryptolib.Signer) (*snapshotContainerWriter, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	result := &snapshotContainerWriter{
+		ww:      rwutil.NewWriter(w),
+		encoder: encoder,
+		digest:  lo.Must(blake2b.New256(nil)),
+		signer:  signer,
+		chunk:   make([]byte, 0, constSnapshotChunkSize),
+	}
+	headerBytes := header.Bytes()
+	result.digest.Write([]byte{header.version})
+	result.digest.Write(headerBytes)
+	result.ww.WriteN(snapshotContainerMagic[:])
+	result.ww.WriteByte(header.version)
+	result.ww.WriteBytes(headerBytes)
+	if result.ww.Err != nil {
+		return nil, fmt.Errorf("failed to write container header: %w", result.ww.Err)
+	}
+	return result, nil
+}
+
+func (cw *snapshotContainerWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), constSnapshotChunkSize-len(cw.chunk))
+		cw.chunk = append(cw.chunk, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(cw.chunk) == constSnapshotChunkSize {
+			if err := cw.flushChunk(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (cw *snapshotContainerWriter) flushChunk() error {
+	if len(cw.chunk) == 0 {
+		return nil
+	}
+	compressed := cw.encoder.EncodeAll(cw.chunk, nil)
+	chunkHash := hashing.HashData(compressed)
+	cw.digest.Write(chunkHash[:])
+	cw.ww.WriteBytes(compressed)
+	cw.ww.WriteN(chunkHash[:])
+	cw.chunk = cw.chunk[:0]
+	if cw.ww.Err != nil {
+		return fmt.Errorf("failed to write chunk: %w", cw.ww.Err)
+	}
+	return nil
+}
+
+// Close writes the last chunk, the end marker and the signature. It does not
+// close the underlying writer.
+func (cw *snapshotContainerWriter) Close() error {
+	defer cw.encoder.Close()
+	if err := cw.flushChunk(); err != nil {
+		return err
+	}
+	cw.ww.WriteBytes(nil)
+	cw.ww.WriteBool(cw.signer != nil)
+	if cw.signer != nil {
+		signature, err := cw.signer.Sign(cw.digest.Sum(nil))
+		if err != nil {
+			return fmt.Errorf("failed to sign container: %w", err)
+		}
+		cw.ww.WriteBytes(signature.Bytes())
+	}
+	if cw.ww.Err != nil {
+		return fmt.Errorf("failed to finalise container: %w", cw.ww.Err)
+	}
+	return nil
+}
+
+// snapshotContainerReader reads the raw snapshot from the container, checking
+// the hash of each chunk. Its `Read` returns `io.EOF` only after the signature
+// of the container is verified.
+type snapshotContainerReader struct {
+	rr             *rwutil.Reader
+	decoder        *zstd.Decoder
+	digest         hash.Hash
+	trustedSigners []*cryptolib.PublicKey
+	chunk          *bytes.Reader
+	signer         *cryptolib.PublicKey
+	done           bool
+}
+
+var _ io.Reader = &snapshotContainerReader{}
+
+// newSnapshotContainerReader continues reading the container after its header
+// is read by `readSnapshotHeader`. If `trustedSigners` is not empty, the
+// container must be signed by one of them.
+func newSnapshotContainerReader(r io.Reader, header *snapshotHeader, trustedSigners []*cryptolib.PublicKey) (*snapshotContainerReader, error) {
+	if header.isLegacy() {
+		return nil, errors.New("legacy snapshots are not stored in a container")
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(constSnapshotChunkSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+	result := &snapshotContainerReader{
+		rr:             rwutil.NewReader(r),
+		decoder:        decoder,
+		digest:         lo.Must(blake2b.New256(nil)),
+		trustedSigners: trustedSigners,
+		chunk:          bytes.NewReader(nil),
+	}
+	result.digest.Write([]byte{header.version})
+	result.digest.Write(header.Bytes())
+	return result, nil
+}
+
+func (cr *snapshotContainerReader) Read(p []byte) (int, error) {
+	for cr.chunk.Len() == 0 {
+		if cr.done {
+			return 0, io.EOF
+		}
+		compressed, err := cr.nextChunk()
+		if err != nil {
+			return 0, err
+		}
+		if compressed == nil {
+			continue
+		}
+		decompressed, err := cr.decoder.DecodeAll(compressed, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decompress chunk: %w", err)
+		}
+		cr.chunk.Reset(decompressed)
+	}
+	return cr.chunk.Read(p)
+}
+
+// verify reads the rest of the container, checking the hashes of the chunks
+// and the signature, without decompressing the snapshot
+func (cr *snapshotContainerReader) verify() error {
+	for !cr.done {
+		if _, err := cr.nextChunk(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signer returns the public key of the node, which signed the container, or
+// nil if the container is not signed. It is known only after the whole
+// container is read.
+func (cr *snapshotContainerReader) Signer() *cryptolib.PublicKey {
+	return cr.signer
+}
+
+// Close releases the resources of the decoder. It does not close the
+// underlying reader.
+func (cr *snapshotContainerReader) Close() {
+	cr.decoder.Close()
+}
+
+// nextChunk returns the compressed bytes of the next chunk after checking its
+// hash. When the end marker is reached, it verifies the signature and returns
+// nil.
+func (cr *snapshotContainerReader) nextChunk() ([]byte, error) {
+	size := cr.rr.ReadSizeWithLimit(constSnapshotMaxChunkSize)
+	if cr.rr.Err != nil {
+		return nil, fmt.Errorf("failed to read chunk size: %w", cr.rr.Err)
+	}
+	if size == 0 {
+		cr.done = true
+		return nil, cr.verifySignature()
+	}
+	compressed := make([]byte, size)
+	cr.rr.ReadN(compressed)
+	var chunkHash hashing.HashValue
+	cr.rr.ReadN(chunkHash[:])
+	if cr.rr.Err != nil {
+		return nil, fmt.Errorf("failed to read chunk: %w", cr.rr.Err)
+	}
+	if chunkHash != hashing.HashData(compressed) {
+		return nil, fmt.Errorf("chunk hash mismatch: expected %s", chunkHash)
+	}
+	cr.digest.Write(chunkHash[:])
+	return compressed, nil
+}
+
+func (cr *snapshotContainerReader) verifySignature() error {
+	signed := cr.rr.ReadBool()
+	var signatureBytes []byte
+	if signed {
+		signatureBytes = cr.rr.ReadBytes()
+	}
+	if cr.rr.Err != nil {
+		return fmt.Errorf("failed to read signature: %w", cr.rr.Err)
+	}
+	if !signed {
+		if len(cr.trustedSigners) > 0 {
+			return errors.New("snapshot is not signed")
+		}
+		return nil
+	}
+	signature, err := bcs.Unmarshal[*cryptolib.Signature](signatureBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse signature: %w", err)
+	}
+	if signature.GetPublicKey() == nil || !signature.Validate(cr.digest.Sum(nil)) {
+		return errors.New("invalid snapshot signature")
+	}
+	cr.signer = signature.GetPublicKey()
+	if len(cr.trustedSigners) > 0 && !lo.ContainsBy(cr.trustedSigners, cr.signer.Equals) {
+		return fmt.Errorf("snapshot is signed by untrusted node %s", cr.signer)
+	}
+	return nil
+}
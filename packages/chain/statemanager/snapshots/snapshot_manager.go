@@ -2,6 +2,7 @@ package snapshots
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -9,11 +10,17 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/samber/lo"
+
 	"github.com/iotaledger/hive.go/log"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/metrics"
 	"github.com/iotaledger/wasp/v2/packages/shutdown"
@@ -29,22 +36,49 @@ type snapshotManagerImpl struct {
 	metrics *metrics.ChainSnapshotsMetrics
 
 	snapshotter      snapshotter
+	store            state.Store
 	localPath        string
 	baseNetworkPaths []string
 	snapshotToLoad   *state.BlockHash
+
+	// Delta snapshots are made on top of the last created snapshot, until
+	// `deltaCount` of them are made in a row; then a full snapshot is made.
+	// Only the snapshots, which were written successfully, are used as bases,
+	// so that a delta snapshot is never left without its base.
+	deltaCount        uint32
+	lastSnapshot      SnapshotInfo
+	deltasSinceFull   uint32
+	lastSnapshotMutex sync.Mutex
+	indexFileMutex    sync.Mutex
+}
+
+// snapshotFile is a snapshot found locally or in the network
+type snapshotFile struct {
+	info    SnapshotInfo
+	base    SnapshotInfo // nil, if it is a full snapshot
+	path    string
+	mirrors []string // Other network paths of the same snapshot; the files may differ
 }
 
 var (
 	_ snapshotManagerCore = &snapshotManagerImpl{}
 	_ SnapshotManager     = &snapshotManagerImpl{}
+	_ SnapshotAdmin       = &snapshotManagerImpl{}
 )
 
+var errSnapshotInProgress = errors.New("snapshot is already being produced")
+
+// ErrStateNotAvailable is returned, if a snapshot of a state, which is not in
+// the store, is requested
+var ErrStateNotAvailable = errors.New("state is not available in the store")
+
 const (
 	constDownloadTimeout                     = 10 * time.Minute
 	constSnapshotIndexHashFileNameSepparator = "-"
 	constSnapshotFileSuffix                  = ".snap"
 	constSnapshotTmpFileSuffix               = ".tmp"
 	constSnapshotDownloaded                  = "net"
+	constSnapshotDelta                       = "delta"
 	constIndexFileName                       = "INDEX" // Index file contains a new-line separated list of snapshot files
 	constLocalAddress                        = "file://"
 	constSchemeHTTP                          = "http(s)"
@@ -58,8 +92,11 @@ func NewSnapshotManager(
 	snapshotToLoad *state.BlockHash,
 	createPeriod uint32,
 	delayPeriod uint32,
+	deltaCount uint32,
 	baseLocalPath string,
 	baseNetworkPaths []string,
+	signer cryptolib.Signer,
+	trustedSigners []*cryptolib.PublicKey,
 	store state.Store,
 	metrics *metrics.ChainSnapshotsMetrics,
 	log log.Logger,
@@ -71,15 +108,17 @@ func NewSnapshotManager(
 		ctx:              ctx,
 		chainID:          chainID,
 		metrics:          metrics,
-		snapshotter:      newSnapshotter(store),
+		snapshotter:      newSnapshotter(store, signer, trustedSigners),
+		store:            store,
 		localPath:        localPath,
 		baseNetworkPaths: baseNetworkPaths,
 		snapshotToLoad:   snapshotToLoad,
+		deltaCount:       deltaCount,
 	}
 	if err := ioutils.CreateDirectory(localPath, 0o777); err != nil {
 		return nil, fmt.Errorf("cannot create folder %s: %v", localPath, err)
 	}
-	result.cleanTempFiles() // To be able to make snapshots, which were not finished. See comment in `createSnapshot` function
+	result.cleanTempFiles() // To be able to make snapshots, which were not finished. See comment in `writeSnapshotFile` function
 	snapMLog.LogDebugf("Snapshot manager created; folder %v is used for snapshots", localPath)
 	result.snapshotManagerRunner = newSnapshotManagerRunner(ctx, store, shutdownCoordinator, createPeriod, delayPeriod, result, snapMLog)
 	return result, nil
@@ -95,142 +134,345 @@ func NewSnapshotManager(
 // Implementations of snapshotManagerCore interface
 // -------------------------------------
 
+// If delta snapshots are enabled, the snapshot may contain only the difference
+// from the previously created snapshot. See `writeSnapshotFile` for details on
+// how the snapshot file is created.
+func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
+	base, deltasSinceFull := smiT.deltaSnapshotBase(snapshotInfo)
+	_, _ = smiT.writeSnapshotFile(snapshotInfo, base, func(err error) {
+		if err == nil {
+			smiT.deltaSnapshotBaseCreated(snapshotInfo, deltasSinceFull)
+			smiT.snapshotCreated(snapshotInfo)
+		}
+	})
+}
+
+// Snapshot manager first finds all the snapshots, available locally or in the
+// network, which satisfy the search condition (largest state index or the
+// requested block hash). For each of them it builds a chain of snapshots: a
+// full snapshot followed by the delta snapshots leading to it. Shorter chains
+// are tried first. If no chain can be loaded completely, the state of the
+// longest loaded prefix of any chain is used, unless a specific block hash was
+// requested.
+func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
+	snapshotFiles := make([]*snapshotFile, 0)
+	addSnapshotFun := func(sf *snapshotFile) {
+		smiT.log.LogDebugf("Snapshot %s found in %s", sf, sf.path)
+		// The same snapshot in several network locations may be downloaded from
+		// all of them at once, if their files are identical (see getMirrors)
+		if isHTTPURL(sf.path) {
+			same, ok := lo.Find(snapshotFiles, func(other *snapshotFile) bool { return isHTTPURL(other.path) && other.isSameSnapshot(sf) })
+			if ok {
+				same.mirrors = append(same.mirrors, sf.path)
+				return
+			}
+		}
+		snapshotFiles = append(snapshotFiles, sf)
+	}
+	smiT.searchLocalSnapshots(addSnapshotFun)
+	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, addSnapshotFun)
+
+	// Only the snapshots with all their base snapshots available can be loaded
+	snapshotChains := make(map[*snapshotFile][]*snapshotFile)
+	for _, sf := range snapshotFiles {
+		chain := snapshotChain(sf, snapshotFiles)
+		if chain == nil {
+			smiT.log.LogDebugf("Snapshot %s in %s is ignored, because some of its base snapshots are not available", sf, sf.path)
+			continue
+		}
+		snapshotChains[sf] = chain
+	}
+
+	var isTargetFun func(SnapshotInfo) bool
+	var searchCondition string
+	if smiT.snapshotToLoad == nil {
+		largestIndex := uint32(0)
+		for sf := range snapshotChains {
+			largestIndex = max(largestIndex, sf.info.StateIndex())
+		}
+		isTargetFun = func(snapshotInfo SnapshotInfo) bool { return snapshotInfo.StateIndex() == largestIndex }
+		searchCondition = fmt.Sprintf("state index %v", largestIndex)
+	} else {
+		isTargetFun = func(snapshotInfo SnapshotInfo) bool { return snapshotInfo.BlockHash().Equals(*smiT.snapshotToLoad) }
+		searchCondition = fmt.Sprintf("block hash %s", *smiT.snapshotToLoad)
+	}
+
+	chains := make([][]*snapshotFile, 0)
+	for _, sf := range snapshotFiles {
+		chain, ok := snapshotChains[sf]
+		if ok && isTargetFun(sf.info) {
+			chains = append(chains, chain)
+		}
+	}
+	slices.SortStableFunc(chains, func(c1, c2 []*snapshotFile) int { return len(c1) - len(c2) })
+	smiT.log.LogDebugf("%v snapshots with %s will be considered for loading in this order: %v", len(chains), searchCondition, chains)
+
+	var partiallyLoaded SnapshotInfo
+	for _, chain := range chains {
+		loaded := smiT.loadSnapshotChain(chain)
+		target := chain[len(chain)-1]
+		if loaded != nil && loaded.Equals(target.info) {
+			smiT.log.LogInfof("Snapshot %s successfully loaded from %s", target, target.path)
+			return target.info
+		}
+		if loaded != nil && (partiallyLoaded == nil || loaded.StateIndex() > partiallyLoaded.StateIndex()) {
+			partiallyLoaded = loaded
+		}
+	}
+	if smiT.snapshotToLoad != nil {
+		// Another state than the requested one must not be used
+		if partiallyLoaded != nil {
+			smiT.log.LogWarnf("Failed to load any snapshot with %s; the loaded base snapshot %s is not used", searchCondition, partiallyLoaded)
+		} else {
+			smiT.log.LogWarnf("Failed to load any snapshot with %s", searchCondition)
+		}
+		return nil
+	}
+	if partiallyLoaded != nil {
+		smiT.log.LogWarnf("Failed to load any snapshot with %s; will continue with the loaded base snapshot %s", searchCondition, partiallyLoaded)
+		return partiallyLoaded
+	}
+	smiT.log.LogWarnf("Failed to load any snapshot; will continue with empty store")
+	return nil
+}
+
+// -------------------------------------
+// Implementations of SnapshotAdmin interface
+// -------------------------------------
+
+// CreateSnapshot starts making a full snapshot of the given state in the local
+// snapshot folder. The snapshot is written in the background; the returned file
+// name appears in the list of local snapshots once the snapshot is complete.
+func (smiT *snapshotManagerImpl) CreateSnapshot(snapshotInfo SnapshotInfo) (string, error) {
+	if !smiT.store.HasTrieRoot(snapshotInfo.TrieRoot()) {
+		return "", fmt.Errorf("state %s: %w", snapshotInfo, ErrStateNotAvailable)
+	}
+	fileName := snapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+	exists, _, _ := ioutils.PathExists(filepath.Join(smiT.localPath, fileName))
+	if exists {
+		return "", fmt.Errorf("snapshot %s already exists in %s", snapshotInfo, fileName)
+	}
+	return smiT.writeSnapshotFile(snapshotInfo, nil, func(error) {})
+}
+
+func (smiT *snapshotManagerImpl) ListSnapshots() ([]*LocalSnapshot, error) {
+	files, err := filepath.Glob(filepath.Join(smiT.localPath, snapshotFileNameString("*", "*")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain snapshot file list: %w", err)
+	}
+	result := make([]*LocalSnapshot, 0, len(files))
+	for _, file := range files {
+		localSnapshot, err := readLocalSnapshot(file)
+		if err != nil {
+			smiT.log.LogWarnf("Listing snapshots: %v", err)
+			continue
+		}
+		result = append(result, localSnapshot)
+	}
+	slices.SortFunc(result, func(ls1, ls2 *LocalSnapshot) int {
+		return cmp.Compare(ls1.Info.StateIndex(), ls2.Info.StateIndex())
+	})
+	return result, nil
+}
+
+// DeleteSnapshot deletes the snapshot file from the local snapshot folder. The
+// snapshot cannot be deleted, if it is the only base of some delta snapshot.
+func (smiT *snapshotManagerImpl) DeleteSnapshot(fileName string) error {
+	localSnapshots, err := smiT.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	toDelete, ok := lo.Find(localSnapshots, func(ls *LocalSnapshot) bool { return ls.FileName == fileName })
+	if !ok {
+		return fmt.Errorf("snapshot file %s not found", fileName)
+	}
+	hasOtherFileFun := func(ls *LocalSnapshot) bool { return ls != toDelete && ls.Info.Equals(toDelete.Info) }
+	isDependentFun := func(ls *LocalSnapshot) bool { return ls.Base != nil && ls.Base.Equals(toDelete.Info) }
+	if !lo.ContainsBy(localSnapshots, hasOtherFileFun) {
+		dependent, ok := lo.Find(localSnapshots, isDependentFun)
+		if ok {
+			return fmt.Errorf("snapshot file %s is the base of delta snapshot file %s", fileName, dependent.FileName)
+		}
+	}
+	err = os.Remove(filepath.Join(smiT.localPath, fileName))
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot file %s: %w", fileName, err)
+	}
+	smiT.log.LogInfof("Snapshot %s deleted from %s", toDelete.Info, fileName)
+	smiT.updateIndexFile()
+	return nil
+}
+
+// ExportSnapshot writes a full snapshot of the given state to the writer. The
+// format is the same as of the snapshot files, so the exported snapshot can be
+// loaded by any node.
+func (smiT *snapshotManagerImpl) ExportSnapshot(snapshotInfo SnapshotInfo, w io.Writer) error {
+	if !smiT.store.HasTrieRoot(snapshotInfo.TrieRoot()) {
+		return fmt.Errorf("state %s: %w", snapshotInfo, ErrStateNotAvailable)
+	}
+	return smiT.snapshotter.storeSnapshot(snapshotInfo, w)
+}
+
+// -------------------------------------
+// Internal functions
+// -------------------------------------
+
+// This happens strictly before snapshot manager starts to produce new snapshots.
+// So there is no way that this function will delete temp file, which is needed.
+func (smiT *snapshotManagerImpl) cleanTempFiles() {
+	tempFileRegExp := tempSnapshotFileNameString("*", "*")
+	tempFileRegExpWithPath := filepath.Join(smiT.localPath, tempFileRegExp)
+	tempFiles, err := filepath.Glob(tempFileRegExpWithPath)
+	if err != nil {
+		smiT.log.LogErrorf("Failed to obtain temporary snapshot file list: %v", err)
+		return
+	}
+
+	removed := 0
+	for _, tempFile := range tempFiles {
+		err = os.Remove(tempFile)
+		if err != nil {
+			smiT.log.LogWarnf("Failed to remove temporary snapshot file %s: %v", tempFile, err)
+		} else {
+			removed++
+		}
+	}
+	smiT.log.LogDebugf("Removed %v out of %v temporary snapshot files", removed, len(tempFiles))
+}
+
 // Snapshot file name includes state index and state hash. Snapshot manager first
 // writes the state to temporary file and only then moves it to permanent location.
 // Writing is done in separate thread to not interfere with normal snapshot manager
-// routine, as it may be lengthy. If snapshot manager detects that the temporary
-// file, needed to create a snapshot, already exists, it assumes that another go
-// routine is already making a snapshot and returns. For this reason it is important
-// to delete all temporary files on snapshot manager start.
-func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
+// routine, as it may be lengthy; `done` is called once it finishes. If snapshot
+// manager detects that the temporary file, needed to create a snapshot, already
+// exists, it assumes that another go routine is already making a snapshot and
+// returns. For this reason it is important to delete all temporary files on
+// snapshot manager start. If `base` is not nil, a delta snapshot is made and its
+// file name also includes the state index and hash of the base snapshot.
+func (smiT *snapshotManagerImpl) writeSnapshotFile(snapshotInfo SnapshotInfo, base SnapshotInfo, done func(error)) (string, error) {
 	start := time.Now()
 	stateIndex := snapshotInfo.StateIndex()
 	commitment := snapshotInfo.Commitment()
-	smiT.log.LogDebugf("Creating snapshot %v %s...", stateIndex, commitment)
-	tmpFileName := tempSnapshotFileName(stateIndex, commitment.BlockHash())
+	if base == nil {
+		smiT.log.LogDebugf("Creating snapshot %v %s...", stateIndex, commitment)
+	} else {
+		smiT.log.LogDebugf("Creating snapshot %v %s as a delta on top of snapshot %s...", stateIndex, commitment, base)
+	}
+	hashString := snapshotHashString(commitment.BlockHash(), base)
+	tmpFileName := tempSnapshotFileNameString(fmt.Sprint(stateIndex), hashString)
 	tmpFilePath := filepath.Join(smiT.localPath, tmpFileName)
 	exists, _, _ := ioutils.PathExists(tmpFilePath)
 	if exists {
 		smiT.log.LogDebugf("Creating snapshot %v %s: skipped making snapshot as it is already being produced", stateIndex, commitment)
-		return
+		return "", errSnapshotInProgress
 	}
 	f, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
 	if err != nil {
 		smiT.log.LogErrorf("Creating snapshot %v %s: failed to create temporary snapshot file %s: %v", stateIndex, commitment, tmpFilePath, err)
-		f.Close()
-		return
+		return "", fmt.Errorf("failed to create temporary snapshot file %s: %w", tmpFilePath, err)
 	}
+	finalFileName := snapshotFileNameString(fmt.Sprint(stateIndex), hashString)
 	go func() {
 		defer f.Close()
 
 		smiT.log.LogDebugf("Creating snapshot %v %s: storing it to file", stateIndex, commitment)
-		err := smiT.snapshotter.storeSnapshot(snapshotInfo, f)
+		var err error
+		if base == nil {
+			err = smiT.snapshotter.storeSnapshot(snapshotInfo, f)
+		} else {
+			err = smiT.snapshotter.storeDeltaSnapshot(base, snapshotInfo, f)
+		}
 		if err != nil {
 			smiT.log.LogErrorf("Creating snapshot %v %s: failed to write snapshot to temporary file %s: %v", stateIndex, commitment, tmpFilePath, err)
+			done(err)
 			return
 		}
 
-		finalFileName := snapshotFileName(stateIndex, commitment.BlockHash())
 		finalFilePath := filepath.Join(smiT.localPath, finalFileName)
 		err = os.Rename(tmpFilePath, finalFilePath)
 		if err != nil {
 			smiT.log.LogErrorf("Creating snapshot %v %s: failed to move temporary snapshot file %s to permanent location %s: %v",
 				stateIndex, commitment, tmpFilePath, finalFilePath, err)
+			done(err)
 			return
 		}
-		smiT.snapshotCreated(snapshotInfo)
+		done(nil)
 		smiT.log.LogInfof("Creating snapshot %v %s: snapshot created in %s", stateIndex, commitment, finalFilePath)
+		smiT.updateIndexFile()
 		smiT.metrics.SnapshotCreated(time.Since(start), stateIndex)
 	}()
+	return finalFileName, nil
 }
 
-func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
-	snapshotPaths := make([]string, 0)
-	snapshotInfos := make([]SnapshotInfo, 0)
-
-	var considerSnapshotFun func(snapshotInfo SnapshotInfo, path string)
-	var searchCondition string
-	if smiT.snapshotToLoad == nil {
-		largestIndex := uint32(0)
-		considerSnapshotFun = func(snapshotInfo SnapshotInfo, path string) {
-			if snapshotInfo.StateIndex() < largestIndex {
-				smiT.log.LogDebugf("Snapshot %s found in %s; it is ignored, because its index is lower than current largest index %v",
-					path, snapshotInfo, largestIndex)
-				return
-			}
-			if snapshotInfo.StateIndex() == largestIndex {
-				snapshotPaths = append(snapshotPaths, path)
-				snapshotInfos = append(snapshotInfos, snapshotInfo)
-				smiT.log.LogDebugf("Snapshot %s found in %s; it is added to the list of considered snapshots, because its index mathec current largest index",
-					path, snapshotInfo)
-				return
-			}
-			// NOTE: snapshotInfo.StateIndex() > largestIndex
-			snapshotPaths = []string{path}
-			snapshotInfos = []SnapshotInfo{snapshotInfo}
-			smiT.log.LogDebugf("Snapshot %s found in %s; it is now the only considered snapshot, because its index is larger than former largest index %v",
-				path, snapshotInfo, largestIndex)
-			largestIndex = snapshotInfo.StateIndex()
-		}
-		searchCondition = fmt.Sprintf("state index %v", largestIndex)
-	} else {
-		considerSnapshotFun = func(snapshotInfo SnapshotInfo, path string) {
-			if snapshotInfo.BlockHash().Equals(*smiT.snapshotToLoad) {
-				snapshotPaths = append(snapshotPaths, path)
-				snapshotInfos = append(snapshotInfos, snapshotInfo)
-				smiT.log.LogDebugf("Snapshot %s found in %s; it is added to the list of considered snapshots, because its hash matches what was requested",
-					path, snapshotInfo)
-				return
-			}
-			smiT.log.LogDebugf("Snapshot %s found in %s; it is ignored, because its hash does not match what was requested", path, snapshotInfo)
-		}
-		searchCondition = fmt.Sprintf("block hash %s", *smiT.snapshotToLoad)
+// deltaSnapshotBase returns the snapshot, on top of which a delta snapshot
+// should be made, or nil if a full snapshot should be made. It also returns
+// the number of delta snapshots since the last full one, including the one
+// being made.
+func (smiT *snapshotManagerImpl) deltaSnapshotBase(snapshotInfo SnapshotInfo) (SnapshotInfo, uint32) {
+	smiT.lastSnapshotMutex.Lock()
+	defer smiT.lastSnapshotMutex.Unlock()
+
+	base := smiT.lastSnapshot
+	if smiT.deltaCount == 0 ||
+		base == nil ||
+		smiT.deltasSinceFull >= smiT.deltaCount ||
+		base.StateIndex() >= snapshotInfo.StateIndex() ||
+		!smiT.store.HasTrieRoot(base.TrieRoot()) {
+		return nil, 0
 	}
+	return base, smiT.deltasSinceFull + 1
+}
 
-	smiT.searchLocalSnapshots(considerSnapshotFun)
-	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, considerSnapshotFun)
-	smiT.log.LogDebugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotPaths), searchCondition, snapshotPaths)
+// deltaSnapshotBaseCreated remembers the created snapshot as a base for the
+// next delta snapshot. A snapshot, which failed to be created, is never
+// remembered, so the deltas are not made on top of it.
+func (smiT *snapshotManagerImpl) deltaSnapshotBaseCreated(snapshotInfo SnapshotInfo, deltasSinceFull uint32) {
+	smiT.lastSnapshotMutex.Lock()
+	defer smiT.lastSnapshotMutex.Unlock()
 
-	for i := range snapshotPaths {
-		err := smiT.loadSnapshotFromPath(snapshotInfos[i], snapshotPaths[i])
-		if err == nil {
-			smiT.log.LogInfof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotPaths[i])
-			return snapshotInfos[i]
-		}
-		smiT.log.LogErrorf("Failed to load snapshot %s from %s: %v", snapshotInfos[i], snapshotPaths[i], err)
+	if smiT.lastSnapshot != nil && smiT.lastSnapshot.StateIndex() >= snapshotInfo.StateIndex() {
+		return
 	}
-	smiT.log.LogWarnf("Failed to load any snapshot; will continue with empty store")
-	return nil
+	smiT.lastSnapshot = snapshotInfo
+	smiT.deltasSinceFull = deltasSinceFull
 }
 
-// -------------------------------------
-// Internal functions
-// -------------------------------------
+// updateIndexFile rewrites the index file of the local snapshot folder, so that
+// the folder could be used as a network path by other nodes. The index file
+// lists both full and delta snapshots; the chains of delta snapshots can be
+// reconstructed from their headers.
+func (smiT *snapshotManagerImpl) updateIndexFile() {
+	smiT.indexFileMutex.Lock()
+	defer smiT.indexFileMutex.Unlock()
 
-// This happens strictly before snapshot manager starts to produce new snapshots.
-// So there is no way that this function will delete temp file, which is needed.
-func (smiT *snapshotManagerImpl) cleanTempFiles() {
-	tempFileRegExp := tempSnapshotFileNameString("*", "*")
-	tempFileRegExpWithPath := filepath.Join(smiT.localPath, tempFileRegExp)
-	tempFiles, err := filepath.Glob(tempFileRegExpWithPath)
+	files, err := filepath.Glob(filepath.Join(smiT.localPath, snapshotFileNameString("*", "*")))
 	if err != nil {
-		smiT.log.LogErrorf("Failed to obtain temporary snapshot file list: %v", err)
+		smiT.log.LogErrorf("Updating index file: failed to obtain snapshot file list: %v", err)
 		return
 	}
-
-	removed := 0
-	for _, tempFile := range tempFiles {
-		err = os.Remove(tempFile)
-		if err != nil {
-			smiT.log.LogWarnf("Failed to remove temporary snapshot file %s: %v", tempFile, err)
-		} else {
-			removed++
-		}
+	fileNames := lo.Map(files, func(file string, _ int) string { return filepath.Base(file) })
+	slices.Sort(fileNames)
+
+	indexFilePath := filepath.Join(smiT.localPath, constIndexFileName)
+	tmpIndexFilePath := indexFilePath + constSnapshotTmpFileSuffix
+	var content strings.Builder
+	for _, fileName := range fileNames {
+		content.WriteString(fileName + "\n")
 	}
-	smiT.log.LogDebugf("Removed %v out of %v temporary snapshot files", removed, len(tempFiles))
+	err = os.WriteFile(tmpIndexFilePath, []byte(content.String()), 0o666)
+	if err != nil {
+		smiT.log.LogErrorf("Updating index file: failed to write temporary index file %s: %v", tmpIndexFilePath, err)
+		return
+	}
+	err = os.Rename(tmpIndexFilePath, indexFilePath)
+	if err != nil {
+		smiT.log.LogErrorf("Updating index file: failed to move temporary index file %s to %s: %v", tmpIndexFilePath, indexFilePath, err)
+		return
+	}
+	smiT.log.LogDebugf("Updating index file: %v snapshot files listed in %s", len(fileNames), indexFilePath)
 }
 
-func (smiT *snapshotManagerImpl) searchLocalSnapshots(considerSnapshotFun func(SnapshotInfo, string)) {
+func (smiT *snapshotManagerImpl) searchLocalSnapshots(addSnapshotFun func(*snapshotFile)) {
 	fileRegExp := snapshotFileNameString("*", "*")
 	fileRegExpWithPath := filepath.Join(smiT.localPath, fileRegExp)
 	files, err := filepath.Glob(fileRegExpWithPath)
@@ -247,19 +489,19 @@ func (smiT *snapshotManagerImpl) searchLocalSnapshots(considerSnapshotFun func(S
 				return
 			}
 			defer f.Close()
-			snapshotInfo, err := readSnapshotInfo(f)
+			header, err := readSnapshotHeader(f)
 			if err != nil {
 				smiT.log.LogErrorf("Search local snapshots: failed to read snapshot info from file %s: %v", file, err)
 				return
 			}
-			considerSnapshotFun(snapshotInfo, constLocalAddress+file)
+			addSnapshotFun(newSnapshotFile(header, constLocalAddress+file))
 			snapshotCount++
 		}()
 	}
 	smiT.log.LogDebugf("Search local snapshots: %v snapshot files found", snapshotCount)
 }
 
-func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []string, considerSnapshotFun func(SnapshotInfo, string)) {
+func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []string, addSnapshotFun func(*snapshotFile)) {
 	chainIDString := smiT.chainID.String()
 	for _, baseNetworkPath := range baseNetworkPaths {
 		func() { // Function to make the defers sooner
@@ -290,7 +532,7 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 						return
 					}
 					defer sReader.Close()
-					snapshotInfo, er := readSnapshotInfo(sReader)
+					header, er := readSnapshotHeader(sReader)
 					if er != nil {
 						smiT.log.LogErrorf("Search network snapshots: failed to read snapshot info from %s in %s: %v", snapshotFileName, basePath, er)
 						return
@@ -300,7 +542,7 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 						smiT.log.LogErrorf("Search network snapshots: unable to join paths %s and %s: %v", baseNetworkPathWithChainID, snapshotFileName, er)
 						return
 					}
-					considerSnapshotFun(snapshotInfo, baseNetworkPathSnapshot)
+					addSnapshotFun(newSnapshotFile(header, baseNetworkPathSnapshot))
 					snapshotCount++
 				}()
 			}
@@ -313,7 +555,25 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 	}
 }
 
-func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo, url string) error {
+// loadSnapshotChain loads the snapshots of the chain in order and returns the
+// last successfully loaded one
+func (smiT *snapshotManagerImpl) loadSnapshotChain(chain []*snapshotFile) SnapshotInfo {
+	var loaded SnapshotInfo
+	for _, sf := range chain {
+		err := smiT.loadSnapshotFromPath(sf)
+		if err != nil {
+			smiT.log.LogErrorf("Failed to load snapshot %s from %s: %v", sf, sf.path, err)
+			return loaded
+		}
+		smiT.log.LogDebugf("Snapshot %s loaded from %s", sf, sf.path)
+		loaded = sf.info
+	}
+	return loaded
+}
+
+func (smiT *snapshotManagerImpl) loadSnapshotFromPath(sf *snapshotFile) error {
+	snapshotInfo := sf.info
+	url := sf.path
 	loadSnapshotFun := func(r io.Reader) error {
 		err := smiT.snapshotter.loadSnapshot(snapshotInfo, r)
 		if err != nil {
@@ -327,20 +587,47 @@ func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo,
 			return fmt.Errorf("failed to open snapshot file %s", path)
 		}
 		defer f.Close()
-		return loadSnapshotFun(f)
+		// The whole snapshot is verified before anything is written to the store
+		err = smiT.snapshotter.verifySnapshot(snapshotInfo, bufio.NewReader(f))
+		if err != nil {
+			return fmt.Errorf("verifying snapshot failed: %v", err)
+		}
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("failed to rewind snapshot file %s: %v", path, err)
+		}
+		return loadSnapshotFun(bufio.NewReader(f))
 	}
 	loadNetworkFun := func(url string) error {
-		fileNameLocal := downloadedSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+		fileNameLocal := downloadedSnapshotFileNameString(fmt.Sprint(snapshotInfo.StateIndex()), snapshotHashString(snapshotInfo.BlockHash(), sf.base))
 		filePathLocal := filepath.Join(smiT.localPath, fileNameLocal)
-		addProgressReporterFun := func(r io.Reader, f string, s uint64) io.Reader {
-			return smiT.addProgressReporter(r, fmt.Sprintf("snapshot %s", snapshotInfo), f, s)
-		}
-		err := DownloadToFile(smiT.ctx, url, filePathLocal, constDownloadTimeout, addProgressReporterFun)
-		if err != nil {
-			return err
+		urls := append([]string{url}, sf.mirrors...)
+		var errs error
+		// Files of the same snapshot from different nodes are not identical, so
+		// each location is tried as the source in turn; the other locations
+		// are used as its mirrors only if they serve the same file.
+		for i := range urls {
+			sources := append(slices.Clone(urls[i:]), urls[:i]...)
+			newProgressReporterFun := func(_ string, length uint64) io.Writer {
+				return NewProgressReporter(smiT.log, fmt.Sprintf("Downloading snapshot %s from urls %v", snapshotInfo, sources), length)
+			}
+			err := DownloadToFile(smiT.ctx, sources, filePathLocal, constDownloadTimeout, newProgressReporterFun)
+			if err == nil {
+				smiT.log.LogDebugf("Loading snapshot %s from url %s: snapshot successfully downloaded to %s", snapshotInfo, sources[0], filePathLocal)
+				err = loadLocalFun(filePathLocal)
+				if err == nil {
+					return nil
+				}
+				if e := os.Remove(filePathLocal); e != nil {
+					smiT.log.LogWarnf("Failed to remove downloaded snapshot file %s: %v", filePathLocal, e)
+				}
+			}
+			errs = errors.Join(errs, err)
+			if smiT.ctx.Err() != nil {
+				break
+			}
 		}
-		smiT.log.LogDebugf("Loading snapshot %s from url %s: snapshot successfully downloaded to %s", snapshotInfo, url, filePathLocal)
-		return loadLocalFun(filePathLocal)
+		return errs
 	}
 
 	scheme, path, err := smiT.splitURL(url)
@@ -404,10 +691,6 @@ func (smiT *snapshotManagerImpl) addProgressReporter(r io.Reader, fileType strin
 	return io.TeeReader(r, progressReporter)
 }
 
-func tempSnapshotFileName(index uint32, blockHash state.BlockHash) string {
-	return tempSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
-}
-
 func tempSnapshotFileNameString(index, blockHash string) string {
 	return snapshotFileNameString(index, blockHash) + constSnapshotTmpFileSuffix
 }
@@ -420,11 +703,93 @@ func snapshotFileNameString(index, blockHash string) string {
 	return index + constSnapshotIndexHashFileNameSepparator + blockHash + constSnapshotFileSuffix
 }
 
-func downloadedSnapshotFileName(index uint32, blockHash state.BlockHash) string {
-	return downloadedSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
-}
-
 func downloadedSnapshotFileNameString(index, blockHash string) string {
 	return index + constSnapshotIndexHashFileNameSepparator + blockHash +
 		constSnapshotIndexHashFileNameSepparator + constSnapshotDownloaded + constSnapshotFileSuffix
 }
+
+func readLocalSnapshot(filePath string) (*LocalSnapshot, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	header, err := readSnapshotHeader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot info from file %s: %w", filePath, err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain size of snapshot file %s: %w", filePath, err)
+	}
+	return &LocalSnapshot{
+		FileName: filepath.Base(filePath),
+		Info:     header.snapshotInfo(),
+		Base:     header.base,
+		Size:     stat.Size(),
+	}, nil
+}
+
+// snapshotHashString returns the part of the snapshot file name after the state
+// index: block hash of the snapshot for full snapshots, or block hash followed by
+// state index and block hash of the base snapshot for delta snapshots
+func snapshotHashString(blockHash state.BlockHash, base SnapshotInfo) string {
+	if base == nil {
+		return blockHash.String()
+	}
+	return blockHash.String() + constSnapshotIndexHashFileNameSepparator + constSnapshotDelta +
+		constSnapshotIndexHashFileNameSepparator + fmt.Sprint(base.StateIndex()) +
+		constSnapshotIndexHashFileNameSepparator + base.BlockHash().String()
+}
+
+func newSnapshotFile(header *snapshotHeader, path string) *snapshotFile {
+	return &snapshotFile{
+		info: header.snapshotInfo(),
+		base: header.base,
+		path: path,
+	}
+}
+
+func (sf *snapshotFile) isSameSnapshot(other *snapshotFile) bool {
+	if !sf.info.Equals(other.info) {
+		return false
+	}
+	if sf.base == nil || other.base == nil {
+		return sf.base == nil && other.base == nil
+	}
+	return sf.base.Equals(other.base)
+}
+
+func isHTTPURL(uString string) bool {
+	uObj, err := url.Parse(uString)
+	return err == nil && (uObj.Scheme == "http" || uObj.Scheme == "https")
+}
+
+func (sf *snapshotFile) String() string {
+	if sf.base == nil {
+		return sf.info.String()
+	}
+	return fmt.Sprintf("%s (delta on top of %s)", sf.info, sf.base)
+}
+
+// snapshotChain returns the snapshots, which must be loaded in order to load
+// the target snapshot: a full snapshot followed by the delta snapshots leading
+// to the target. Nil is returned, if some base snapshot is not available.
+func snapshotChain(target *snapshotFile, snapshotFiles []*snapshotFile) []*snapshotFile {
+	chain := []*snapshotFile{target}
+	for current := target; current.base != nil; current = chain[len(chain)-1] {
+		if current.base.StateIndex() >= current.info.StateIndex() {
+			return nil // Base must precede the delta; this also prevents cycles
+		}
+		isBaseFun := func(sf *snapshotFile) bool { return sf.info.Equals(current.base) }
+		base, ok := lo.Find(snapshotFiles, func(sf *snapshotFile) bool { return sf.base == nil && isBaseFun(sf) })
+		if !ok {
+			base, ok = lo.Find(snapshotFiles, isBaseFun)
+			if !ok {
+				return nil
+			}
+		}
+		chain = append(chain, base)
+	}
+	return lo.Reverse(chain)
+}
@@ -2,6 +2,7 @@ package snapshots
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotaledger/hive.go/log"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/gpa/utils"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/kvstore/mapdb"
 	"github.com/iotaledger/wasp/v2/packages/metrics"
@@ -78,8 +80,11 @@ func getLocalFuns(t *testing.T) (createNewNodeFun, snapshotsAvailableFun) {
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsCreatePathConst,
 				[]string{},
+				nil,
+				nil,
 				store,
 				mockSnapshotsMetrics(),
 				log,
@@ -113,8 +118,11 @@ func getNetworkFuns(t *testing.T, networkPaths []string) (createNewNodeFun, snap
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsDownloadPathConst,
 				networkPaths,
+				nil,
+				nil,
 				store,
 				mockSnapshotsMetrics(),
 				log,
@@ -177,8 +185,11 @@ func testSnapshotManagerAny(
 		nil,
 		uint32(snapshotCreatePeriod),
 		uint32(snapshotDelayPeriod),
+		0,
 		localSnapshotsCreatePathConst,
 		[]string{},
+		cryptolib.NewKeyPair(),
+		nil,
 		storeOrig,
 		mockSnapshotsMetrics(),
 		log,
@@ -229,6 +240,117 @@ func testSnapshotManagerAny(
 	}
 }
 
+func TestSnapshotChain(t *testing.T) {
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(8, 1)
+	info := func(i int) SnapshotInfo { return NewSnapshotInfo(blocks[i].StateIndex(), blocks[i].L1Commitment()) }
+	file := func(i int, base SnapshotInfo) *snapshotFile {
+		return &snapshotFile{info: info(i), base: base, path: fmt.Sprint(i)}
+	}
+
+	full1 := file(1, nil)
+	delta3 := file(3, info(1))
+	delta5 := file(5, info(3))
+	full5 := file(5, nil)
+	delta7 := file(7, info(6))
+	files := []*snapshotFile{delta5, delta3, full1, full5, delta7}
+
+	require.Equal(t, []*snapshotFile{full1}, snapshotChain(full1, files))
+	require.Equal(t, []*snapshotFile{full1, delta3, delta5}, snapshotChain(delta5, files))
+	require.Nil(t, snapshotChain(delta7, files))
+
+	// base is preferred to be a full snapshot
+	delta6 := file(6, info(5))
+	require.Equal(t, []*snapshotFile{full5, delta6}, snapshotChain(delta6, append(files, delta6)))
+}
+
+func TestDeltaSnapshotBase(t *testing.T) {
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(6, 1)
+	info := func(i int) SnapshotInfo { return NewSnapshotInfo(blocks[i].StateIndex(), blocks[i].L1Commitment()) }
+	smi := &snapshotManagerImpl{store: factory.GetStore(), deltaCount: 2}
+
+	base, deltas := smi.deltaSnapshotBase(info(1))
+	require.Nil(t, base)
+	smi.deltaSnapshotBaseCreated(info(1), deltas)
+
+	// the snapshot of block 2 fails, so the next delta is made on top of block 1
+	base, _ = smi.deltaSnapshotBase(info(2))
+	require.True(t, base.Equals(info(1)))
+	base, deltas = smi.deltaSnapshotBase(info(3))
+	require.True(t, base.Equals(info(1)))
+	smi.deltaSnapshotBaseCreated(info(3), deltas)
+
+	base, deltas = smi.deltaSnapshotBase(info(4))
+	require.True(t, base.Equals(info(3)))
+	smi.deltaSnapshotBaseCreated(info(4), deltas)
+
+	// an older snapshot does not replace the base
+	smi.deltaSnapshotBaseCreated(info(2), 0)
+
+	// deltaCount deltas are made in a row
+	base, _ = smi.deltaSnapshotBase(info(5))
+	require.Nil(t, base)
+}
+
+func TestSnapshotAdmin(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Shutdown()
+	defer cleanupAfterSnapshotManagerTest(t)
+
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	snapshotManager, err := NewSnapshotManager(
+		context.Background(),
+		nil,
+		factory.GetChainID(),
+		nil,
+		0,
+		0,
+		0,
+		localSnapshotsCreatePathConst,
+		[]string{},
+		cryptolib.NewKeyPair(),
+		nil,
+		factory.GetStore(),
+		mockSnapshotsMetrics(),
+		log,
+	)
+	require.NoError(t, err)
+	snapshotAdmin, ok := snapshotManager.(SnapshotAdmin)
+	require.True(t, ok)
+
+	for _, i := range []int{3, 1} {
+		fileName, err := snapshotAdmin.CreateSnapshot(NewSnapshotInfo(blocks[i].StateIndex(), blocks[i].L1Commitment()))
+		require.NoError(t, err)
+		require.Equal(t, snapshotFileName(blocks[i].StateIndex(), blocks[i].L1Commitment().BlockHash()), fileName)
+		require.True(t, waitForBlock(t, factory.GetChainID(), blocks[i], 10, 50*time.Millisecond))
+	}
+	_, err = snapshotAdmin.CreateSnapshot(NewSnapshotInfo(blocks[1].StateIndex(), blocks[1].L1Commitment()))
+	require.Error(t, err)
+
+	localSnapshots, err := snapshotAdmin.ListSnapshots()
+	require.NoError(t, err)
+	require.Len(t, localSnapshots, 2)
+	require.Equal(t, blocks[1].StateIndex(), localSnapshots[0].Info.StateIndex())
+	require.Equal(t, blocks[3].StateIndex(), localSnapshots[1].Info.StateIndex())
+	require.Nil(t, localSnapshots[1].Base)
+	require.Positive(t, localSnapshots[1].Size)
+
+	// exporting does not create a local snapshot
+	exportInfo := NewSnapshotInfo(blocks[4].StateIndex(), blocks[4].L1Commitment())
+	exported := new(bytes.Buffer)
+	require.NoError(t, snapshotAdmin.ExportSnapshot(exportInfo, exported))
+	require.False(t, snapshotExists(t, factory.GetChainID(), blocks[4].StateIndex(), blocks[4].L1Commitment()))
+	storeNew := statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	require.NoError(t, newSnapshotter(storeNew, nil, nil).loadSnapshot(exportInfo, exported))
+	utils.CheckStateInStores(t, factory.GetStore(), storeNew, blocks[4].L1Commitment())
+
+	require.NoError(t, snapshotAdmin.DeleteSnapshot(localSnapshots[0].FileName))
+	require.False(t, snapshotExists(t, factory.GetChainID(), blocks[1].StateIndex(), blocks[1].L1Commitment()))
+	require.Error(t, snapshotAdmin.DeleteSnapshot(localSnapshots[0].FileName))
+}
+
 func snapshotExists(t *testing.T, chainID isc.ChainID, stateIndex uint32, commitment *state.L1Commitment) bool {
 	path := filepath.Join(localSnapshotsCreatePathConst, chainID.String(), snapshotFileName(stateIndex, commitment.BlockHash()))
 	exists, isDir, err := ioutils.PathExists(path)
@@ -1,110 +1,166 @@
 package snapshots
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"os"
 
-	"fortio.org/safecast"
-
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/state"
 )
 
 type snapshotterImpl struct {
-	store state.Store
+	store          state.Store
+	signer         cryptolib.Signer
+	trustedSigners []*cryptolib.PublicKey
 }
 
 var _ snapshotter = &snapshotterImpl{}
 
 const constLengthArrayLength = 4 // bytes
 
-func newSnapshotter(store state.Store) snapshotter {
-	return &snapshotterImpl{store: store}
+// newSnapshotter creates a snapshotter, which signs the created snapshots with
+// `signer`, if it is not nil. If `trustedSigners` is not empty, only snapshots
+// signed by one of them are loaded.
+func newSnapshotter(store state.Store, signer cryptolib.Signer, trustedSigners []*cryptolib.PublicKey) snapshotter {
+	return &snapshotterImpl{
+		store:          store,
+		signer:         signer,
+		trustedSigners: trustedSigners,
+	}
 }
 
 func (sn *snapshotterImpl) storeSnapshot(snapshotInfo SnapshotInfo, w io.Writer) error {
-	indexArray := make([]byte, 4) // Size of block index, which is of type uint32: 4 bytes
-	binary.LittleEndian.PutUint32(indexArray, snapshotInfo.StateIndex())
-	err := writeBytes(indexArray, w)
-	if err != nil {
-		return fmt.Errorf("failed writing block index %v: %w", snapshotInfo.StateIndex(), err)
-	}
+	return sn.storeContainer(newSnapshotHeader(snapshotInfo, nil), w, func(cw io.Writer) error {
+		return sn.store.TakeSnapshot(snapshotInfo.TrieRoot(), cw)
+	})
+}
 
-	trieRootBytes := snapshotInfo.Commitment().Bytes()
-	err = writeBytes(trieRootBytes, w)
+func (sn *snapshotterImpl) storeDeltaSnapshot(base SnapshotInfo, snapshotInfo SnapshotInfo, w io.Writer) error {
+	return sn.storeContainer(newSnapshotHeader(snapshotInfo, base), w, func(cw io.Writer) error {
+		return sn.store.TakeDeltaSnapshot(base.TrieRoot(), snapshotInfo.TrieRoot(), cw)
+	})
+}
+
+func (sn *snapshotterImpl) storeContainer(header *snapshotHeader, w io.Writer, takeSnapshotFun func(io.Writer) error) error {
+	cw, err := newSnapshotContainerWriter(w, header, sn.signer)
 	if err != nil {
-		return fmt.Errorf("failed writing L1 commitment %s: %w", snapshotInfo.Commitment(), err)
+		return fmt.Errorf("failed writing snapshot %s header: %w", header.snapshotInfo(), err)
 	}
-
-	err = sn.store.TakeSnapshot(snapshotInfo.TrieRoot(), w)
+	err = takeSnapshotFun(cw)
 	if err != nil {
 		return fmt.Errorf("failed to store snapshot: %w", err)
 	}
+	err = cw.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finalise snapshot: %w", err)
+	}
 	return nil
 }
 
-func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader) error {
-	readSnapshotInfo, err := readSnapshotInfo(r)
+func (sn *snapshotterImpl) verifySnapshot(snapshotInfo SnapshotInfo, r io.Reader) error {
+	header, err := sn.readExpectedHeader(snapshotInfo, r)
 	if err != nil {
-		return fmt.Errorf("failed reading snapshot info: %w", err)
+		return err
 	}
-	if !readSnapshotInfo.Equals(snapshotInfo) {
-		return fmt.Errorf("snapshot read %s is different than expected %v", readSnapshotInfo, snapshotInfo)
+	if header.isLegacy() {
+		// Legacy snapshots contain nothing to verify
+		return nil
 	}
-	err = sn.store.RestoreSnapshot(readSnapshotInfo.TrieRoot(), r, true)
+	cr, err := newSnapshotContainerReader(r, header, sn.trustedSigners)
 	if err != nil {
-		return fmt.Errorf("failed restoring snapshot: %w", err)
+		return err
+	}
+	defer cr.Close()
+	err = cr.verify()
+	if err != nil {
+		return fmt.Errorf("snapshot %s verification failed: %w", snapshotInfo, err)
 	}
 	return nil
 }
 
-func readSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
-	indexArray, err := readBytes(r)
+func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader) error {
+	header, err := sn.readExpectedHeader(snapshotInfo, r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read block index: %w", err)
+		return err
 	}
-	if len(indexArray) != 4 { // Size of block index, which is of type uint32: 4 bytes
-		return nil, fmt.Errorf("block index is %v instead of 4 bytes", len(indexArray))
+	if header.isDelta() && !sn.store.HasTrieRoot(header.base.TrieRoot()) {
+		return fmt.Errorf("base snapshot %s of delta snapshot %s is not loaded", header.base, snapshotInfo)
 	}
-	index := binary.LittleEndian.Uint32(indexArray)
-
-	trieRootArray, err := readBytes(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read trie root: %w", err)
+	if !header.isLegacy() {
+		cr, err := newSnapshotContainerReader(r, header, sn.trustedSigners)
+		if err != nil {
+			return err
+		}
+		defer cr.Close()
+		r = cr
 	}
-	commitment, err := state.NewL1CommitmentFromBytes(trieRootArray)
+	err = sn.store.RestoreSnapshot(header.trieRoot, r, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse L1 commitment: %w", err)
+		return fmt.Errorf("failed restoring snapshot: %w", err)
 	}
-
-	return NewSnapshotInfo(index, commitment), nil
+	return nil
 }
 
-func writeBytes(bytes []byte, w io.Writer) error {
-	lengthArray := make([]byte, constLengthArrayLength)
-	bytesLen, err := safecast.Convert[uint32](len(bytes))
+func (sn *snapshotterImpl) readExpectedHeader(snapshotInfo SnapshotInfo, r io.Reader) (*snapshotHeader, error) {
+	header, err := readSnapshotHeader(r)
 	if err != nil {
-		return fmt.Errorf("integer overflow in bytes length: %w", err)
+		return nil, fmt.Errorf("failed reading snapshot info: %w", err)
 	}
-	binary.LittleEndian.PutUint32(lengthArray, bytesLen)
-	n, err := w.Write(lengthArray)
-	if n != constLengthArrayLength {
-		return fmt.Errorf("only %v of total %v bytes of length written", n, constLengthArrayLength)
+	if readSnapshotInfo := header.snapshotInfo(); !readSnapshotInfo.Equals(snapshotInfo) {
+		return nil, fmt.Errorf("snapshot read %s is different than expected %v", readSnapshotInfo, snapshotInfo)
 	}
-	if err != nil {
-		return fmt.Errorf("failed writing length: %w", err)
+	if header.isLegacy() && len(sn.trustedSigners) > 0 {
+		return nil, fmt.Errorf("snapshot %s is in legacy format, which cannot be signed", snapshotInfo)
 	}
+	return header, nil
+}
 
-	n, err = w.Write(bytes)
-	if n != len(bytes) {
-		return fmt.Errorf("only %v of total %v bytes of array written", n, len(bytes))
+// LoadSnapshotFile verifies the snapshot file and restores it to the store. If
+// `trustedSigners` is not empty, the snapshot must be signed by one of them.
+// It is meant for offline tools; nodes load snapshots using SnapshotManager.
+func LoadSnapshotFile(store state.Store, filePath string, trustedSigners []*cryptolib.PublicKey) (SnapshotInfo, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file %s: %w", filePath, err)
 	}
+	defer f.Close()
+	snapshotInfo, err := readSnapshotInfo(f)
 	if err != nil {
-		return fmt.Errorf("failed writing array: %w", err)
+		return nil, fmt.Errorf("failed to read snapshot info from file %s: %w", filePath, err)
+	}
+	sn := newSnapshotter(store, nil, trustedSigners)
+	rewindFun := func() error {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind snapshot file %s: %w", filePath, err)
+		}
+		return nil
+	}
+	if err = rewindFun(); err != nil {
+		return nil, err
 	}
+	// The whole snapshot is verified before anything is written to the store
+	if err = sn.verifySnapshot(snapshotInfo, bufio.NewReader(f)); err != nil {
+		return nil, err
+	}
+	if err = rewindFun(); err != nil {
+		return nil, err
+	}
+	if err = sn.loadSnapshot(snapshotInfo, bufio.NewReader(f)); err != nil {
+		return nil, err
+	}
+	return snapshotInfo, nil
+}
 
-	return nil
+func readSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
+	header, err := readSnapshotHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return header.snapshotInfo(), nil
 }
 
 func readBytes(r io.Reader) ([]byte, error) {
@@ -1,13 +1,17 @@
 package snapshots
 
 import (
+	"bytes"
+	"encoding/binary"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/gpa/utils"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/kvstore/mapdb"
+	"github.com/iotaledger/wasp/v2/packages/state"
 	"github.com/iotaledger/wasp/v2/packages/state/statetest"
 	"github.com/iotaledger/wasp/v2/packages/testutil/testlogger"
 )
@@ -23,7 +27,7 @@ func TestWriteReadDifferentStores(t *testing.T) {
 	lastBlock := blocks[numberOfBlocks-1]
 	lastCommitment := lastBlock.L1Commitment()
 	snapshotInfo := NewSnapshotInfo(blocks[numberOfBlocks-1].StateIndex(), lastCommitment)
-	snapshotterOrig := newSnapshotter(factory.GetStore())
+	snapshotterOrig := newSnapshotter(factory.GetStore(), nil, nil)
 	fileName := "TestWriteReadDifferentStores.snap"
 	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
 	require.NoError(t, err)
@@ -33,7 +37,7 @@ func TestWriteReadDifferentStores(t *testing.T) {
 	require.NoError(t, err)
 
 	store := statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
-	snapshotterNew := newSnapshotter(store)
+	snapshotterNew := newSnapshotter(store, nil, nil)
 	f, err = os.Open(fileName)
 	require.NoError(t, err)
 	err = snapshotterNew.loadSnapshot(snapshotInfo, f)
@@ -46,3 +50,100 @@ func TestWriteReadDifferentStores(t *testing.T) {
 	utils.CheckBlockInStore(t, store, lastBlock)
 	utils.CheckStateInStores(t, factory.GetStore(), store, lastCommitment)
 }
+
+func TestSnapshotSignature(t *testing.T) {
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	lastBlock := blocks[len(blocks)-1]
+	snapshotInfo := NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())
+	signer := cryptolib.NewKeyPair()
+	other := cryptolib.NewKeyPair()
+
+	signed := new(bytes.Buffer)
+	require.NoError(t, newSnapshotter(factory.GetStore(), signer, nil).storeSnapshot(snapshotInfo, signed))
+	unsigned := new(bytes.Buffer)
+	require.NoError(t, newSnapshotter(factory.GetStore(), nil, nil).storeSnapshot(snapshotInfo, unsigned))
+
+	verify := func(snapshot []byte, trustedSigners ...*cryptolib.PublicKey) error {
+		store := statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+		return newSnapshotter(store, nil, trustedSigners).verifySnapshot(snapshotInfo, bytes.NewReader(snapshot))
+	}
+	require.NoError(t, verify(signed.Bytes()))
+	require.NoError(t, verify(unsigned.Bytes()))
+	require.NoError(t, verify(signed.Bytes(), other.GetPublicKey(), signer.GetPublicKey()))
+	require.ErrorContains(t, verify(signed.Bytes(), other.GetPublicKey()), "untrusted")
+	require.ErrorContains(t, verify(unsigned.Bytes(), signer.GetPublicKey()), "not signed")
+
+	// corrupting any byte of the snapshot data is detected
+	corrupted := bytes.Clone(signed.Bytes())
+	corrupted[len(corrupted)/2] ^= 0xff
+	require.Error(t, verify(corrupted))
+
+	// the snapshot is restored only from a trusted container
+	store := statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	require.NoError(t, newSnapshotter(store, nil, []*cryptolib.PublicKey{signer.GetPublicKey()}).loadSnapshot(snapshotInfo, bytes.NewReader(signed.Bytes())))
+	utils.CheckStateInStores(t, factory.GetStore(), store, lastBlock.L1Commitment())
+}
+
+func TestLoadLegacySnapshot(t *testing.T) {
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	lastBlock := blocks[len(blocks)-1]
+	snapshotInfo := NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())
+
+	legacy := new(bytes.Buffer)
+	writeLegacyBytes := func(b []byte) {
+		require.NoError(t, binary.Write(legacy, binary.LittleEndian, uint32(len(b))))
+		legacy.Write(b)
+	}
+	writeLegacyBytes(binary.LittleEndian.AppendUint32(nil, snapshotInfo.StateIndex()))
+	writeLegacyBytes(snapshotInfo.Commitment().Bytes())
+	require.NoError(t, factory.GetStore().TakeSnapshot(snapshotInfo.TrieRoot(), legacy))
+
+	readInfo, err := readSnapshotInfo(bytes.NewReader(legacy.Bytes()))
+	require.NoError(t, err)
+	require.True(t, readInfo.Equals(snapshotInfo))
+
+	newStore := func() state.Store { return statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB()) }
+	err = newSnapshotter(newStore(), nil, []*cryptolib.PublicKey{cryptolib.NewKeyPair().GetPublicKey()}).
+		loadSnapshot(snapshotInfo, bytes.NewReader(legacy.Bytes()))
+	require.ErrorContains(t, err, "legacy")
+
+	store := newStore()
+	snapshotter := newSnapshotter(store, nil, nil)
+	require.NoError(t, snapshotter.verifySnapshot(snapshotInfo, bytes.NewReader(legacy.Bytes())))
+	require.NoError(t, snapshotter.loadSnapshot(snapshotInfo, bytes.NewReader(legacy.Bytes())))
+	utils.CheckStateInStores(t, factory.GetStore(), store, lastBlock.L1Commitment())
+}
+
+func TestDeltaSnapshot(t *testing.T) {
+	factory := utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(10, 1)
+	baseBlock := blocks[4]
+	lastBlock := blocks[len(blocks)-1]
+	baseInfo := NewSnapshotInfo(baseBlock.StateIndex(), baseBlock.L1Commitment())
+	snapshotInfo := NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())
+	snapshotterOrig := newSnapshotter(factory.GetStore(), nil, nil)
+
+	full := new(bytes.Buffer)
+	require.NoError(t, snapshotterOrig.storeSnapshot(baseInfo, full))
+	delta := new(bytes.Buffer)
+	require.NoError(t, snapshotterOrig.storeDeltaSnapshot(baseInfo, snapshotInfo, delta))
+
+	header, err := readSnapshotHeader(bytes.NewReader(delta.Bytes()))
+	require.NoError(t, err)
+	require.True(t, header.isDelta())
+	require.True(t, header.base.Equals(baseInfo))
+	require.True(t, header.snapshotInfo().Equals(snapshotInfo))
+
+	// delta cannot be loaded without its base
+	store := statetest.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	snapshotter := newSnapshotter(store, nil, nil)
+	require.ErrorContains(t, snapshotter.loadSnapshot(snapshotInfo, bytes.NewReader(delta.Bytes())), "not loaded")
+
+	require.NoError(t, snapshotter.loadSnapshot(baseInfo, bytes.NewReader(full.Bytes())))
+	require.NoError(t, snapshotter.verifySnapshot(snapshotInfo, bytes.NewReader(delta.Bytes())))
+	require.NoError(t, snapshotter.loadSnapshot(snapshotInfo, bytes.NewReader(delta.Bytes())))
+	utils.CheckBlockInStore(t, store, lastBlock)
+	utils.CheckStateInStores(t, factory.GetStore(), store, lastBlock.L1Commitment())
+}
@@ -6,5 +6,6 @@ const (
 	PrefixTrie                    = 1
 	PrefixLatestTrieRoot          = 2
 	PrefixLargestPrunedBlockIndex = 3
+	PrefixMempoolJournal          = 4
 	PrefixHealthTracker           = 255
 )
@@ -24,6 +24,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/gpa"
 	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/gpa/utils"
 	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
+	"github.com/iotaledger/wasp/v2/packages/chaindb"
 	"github.com/iotaledger/wasp/v2/packages/chains/accessmanager"
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/database"
@@ -115,8 +116,11 @@ type Chains struct {
 	snapshotsToLoad                     map[isc.ChainIDKey]state.BlockHash
 	snapshotPeriod                      uint32
 	snapshotDelay                       uint32
+	snapshotDeltas                      uint32
 	snapshotFolderPath                  string
 	snapshotNetworkPaths                []string
+	snapshotSign                        bool
+	snapshotTrustedSigners              []*cryptolib.PublicKey
 
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider
 	dkShareRegistryProvider     registry.DKShareRegistryProvider
@@ -140,8 +144,9 @@ type Chains struct {
 }
 
 type activeChain struct {
-	chain      chain.Chain
-	cancelFunc context.CancelFunc
+	chain           chain.Chain
+	snapshotManager snapshots.SnapshotManager
+	cancelFunc      context.CancelFunc
 }
 
 func New(
@@ -173,8 +178,11 @@ func New(
 	snapshotsToLoad []string,
 	snapshotPeriod uint32,
 	snapshotDelay uint32,
+	snapshotDeltas uint32,
 	snapshotFolderPath string,
 	snapshotNetworkPaths []string,
+	snapshotSign bool,
+	snapshotTrustedSigners []string,
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider,
 	dkShareRegistryProvider registry.DKShareRegistryProvider,
 	nodeIdentityProvider registry.NodeIdentityProvider,
@@ -193,6 +201,14 @@ func New(
 		}
 		validatorFeeAddr = addr
 	}
+	trustedSigners := make([]*cryptolib.PublicKey, len(snapshotTrustedSigners))
+	for i, publicKeyStr := range snapshotTrustedSigners {
+		publicKey, err := cryptolib.PublicKeyFromString(publicKeyStr)
+		if err != nil {
+			panic(fmt.Errorf("error parsing snapshots.trustedSigners: %s", err.Error()))
+		}
+		trustedSigners[i] = publicKey
+	}
 	ret := &Chains{
 		log:                                 log,
 		mutex:                               &sync.RWMutex{},
@@ -221,8 +237,11 @@ func New(
 		smPruningMaxStatesToDelete:          smPruningMaxStatesToDelete,
 		snapshotPeriod:                      snapshotPeriod,
 		snapshotDelay:                       snapshotDelay,
+		snapshotDeltas:                      snapshotDeltas,
 		snapshotFolderPath:                  snapshotFolderPath,
 		snapshotNetworkPaths:                snapshotNetworkPaths,
+		snapshotSign:                        snapshotSign,
+		snapshotTrustedSigners:              trustedSigners,
 		chainRecordRegistryProvider:         chainRecordRegistryProvider,
 		dkShareRegistryProvider:             dkShareRegistryProvider,
 		nodeIdentityProvider:                nodeIdentityProvider,
@@ -419,6 +438,16 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID, mode ChainMode) err
 		return fmt.Errorf("failed to initialize chain components: %w", err)
 	}
 
+	var mempoolJournal *mempool.OffLedgerJournal
+	if c.mempoolSettings.JournalEnabled && !mode.IsReadOnly() {
+		journalStore, err2 := chainKVStore.WithExtendedRealm([]byte{chaindb.PrefixMempoolJournal})
+		if err2 != nil {
+			chainCancel()
+			return fmt.Errorf("error when creating mempool journal KV store: %w", err2)
+		}
+		mempoolJournal = mempool.NewOffLedgerJournal(journalStore)
+	}
+
 	newChain, err := chain.New(
 		chainCtx,
 		chainLog,
@@ -448,6 +477,7 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID, mode ChainMode) err
 		components.StateManager,
 		c.mempoolSettings,
 		c.mempoolBroadcastInterval,
+		mempoolJournal,
 		0,
 		mode.ReadOnlyPath,
 	)
@@ -456,8 +486,9 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID, mode ChainMode) err
 		return fmt.Errorf("Chains.Activate: failed to create chain object: %w", err)
 	}
 	c.allChains.Set(chainID, &activeChain{
-		chain:      newChain,
-		cancelFunc: chainCancel,
+		chain:           newChain,
+		snapshotManager: components.SnapshotManager,
+		cancelFunc:      chainCancel,
 	})
 
 	c.log.LogInfof("activated chain: %v = %s", chainID.ShortString(), chainID.String())
@@ -573,6 +604,10 @@ func (c *Chains) setSnapshotManager(
 	} else {
 		snapshotToLoad = c.defaultSnapshotToLoad
 	}
+	var snapshotSigner cryptolib.Signer
+	if c.snapshotSign {
+		snapshotSigner = c.nodeIdentityProvider.NodeIdentity()
+	}
 	chainSnapshotManager, err := snapshots.NewSnapshotManager(
 		chainCtx,
 		chainShutdownCoordinator.Nested("SnapMgr"),
@@ -580,8 +615,11 @@ func (c *Chains) setSnapshotManager(
 		snapshotToLoad,
 		c.snapshotPeriod,
 		c.snapshotDelay,
+		c.snapshotDeltas,
 		c.snapshotFolderPath,
 		c.snapshotNetworkPaths,
+		snapshotSigner,
+		c.snapshotTrustedSigners,
 		chainStore,
 		chainMetrics.Snapshots,
 		chainLog,
@@ -645,6 +683,22 @@ func (c *Chains) GetFirst() (chain.Chain, error) {
 	return ret.chain, nil
 }
 
+// SnapshotAdmin returns the interface to manage the snapshots of the active chain
+func (c *Chains) SnapshotAdmin(chainID isc.ChainID) (snapshots.SnapshotAdmin, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	ret, exists := c.allChains.Get(chainID)
+	if !exists {
+		return nil, interfaces.ErrChainNotFound
+	}
+	snapshotAdmin, ok := ret.snapshotManager.(snapshots.SnapshotAdmin)
+	if !ok {
+		return nil, errors.New("snapshots cannot be managed in read-only mode")
+	}
+	return snapshotAdmin, nil
+}
+
 func (c *Chains) ValidatorAddress() *cryptolib.Address {
 	return c.validatorFeeAddr
 }
@@ -0,0 +1,84 @@
+package chainutil
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/v2/packages/transaction"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/emulator"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/iscmagic"
+	"github.com/iotaledger/wasp/v2/packages/vm/processors"
+)
+
+// EVMCreateAccessList executes an EVM contract call, discarding any state
+// changes, and returns the access list of the call, along with the gas used
+// when executing it with that access list.
+// If the call fails, the access list is returned along with the VM error.
+func EVMCreateAccessList(
+	anchor *isc.StateAnchor,
+	l1Params *parameters.L1Params,
+	store indexedstore.IndexedStore,
+	processors *processors.Config,
+	log log.Logger,
+	call ethereum.CallMsg,
+) (accessList types.AccessList, gasUsed uint64, vmError *isc.VMError, err error) {
+	excluded, err := accessListExcludedAddresses(anchor, store, call)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Adding an address or slot to the access list changes the gas used by
+	// the call, which may change its execution path, so we repeat the call
+	// until the access list stabilizes (same as go-ethereum).
+	prevTracer := logger.NewAccessListTracer(call.AccessList, excluded)
+	for {
+		accessList = prevTracer.AccessList()
+		call.AccessList = accessList
+		tracer := logger.NewAccessListTracer(accessList, excluded)
+		res, err := evmCall(anchor, l1Params, store, processors, log, call, &tracers.Tracer{Hooks: tracer.Hooks()})
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, res.gasUsed, res.vmError, nil
+		}
+		prevTracer = tracer
+	}
+}
+
+// accessListExcludedAddresses returns the addresses that are always warm
+// and thus don't need to be included in the access list.
+func accessListExcludedAddresses(anchor *isc.StateAnchor, store indexedstore.IndexedStore, call ethereum.CallMsg) (map[common.Address]struct{}, error) {
+	excluded := map[common.Address]struct{}{
+		call.From:        {},
+		iscmagic.Address: {},
+	}
+	for _, addr := range emulator.ActivePrecompiles() {
+		excluded[addr] = struct{}{}
+	}
+	if call.To != nil {
+		excluded[*call.To] = struct{}{}
+		return excluded, nil
+	}
+	// contract creation: the created address is warm as well
+	l1Commitment, err := transaction.L1CommitmentFromAnchor(anchor)
+	if err != nil {
+		return nil, err
+	}
+	chainState, err := store.StateByTrieRoot(l1Commitment.TrieRoot())
+	if err != nil {
+		return nil, err
+	}
+	stateDB := emulator.StateDBSubrealmR(evm.EmulatorStateSubrealmR(evm.ContractPartitionR(chainState)))
+	excluded[crypto.CreateAddress(call.From, emulator.GetNonce(stateDB, call.From))] = struct{}{}
+	return excluded, nil
+}
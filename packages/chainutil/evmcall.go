@@ -5,9 +5,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	bcs "github.com/iotaledger/bcs-go"
 	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/hashing"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
@@ -16,7 +18,8 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/processors"
 )
 
-// EVMCall executes an EVM contract call and returns its output, discarding any state changes
+// EVMCall executes an EVM contract call and returns its output, discarding any state changes.
+// The given state overrides, if any, are applied before executing the call.
 func EVMCall(
 	anchor *isc.StateAnchor,
 	l1Params *parameters.L1Params,
@@ -24,7 +27,38 @@ func EVMCall(
 	processors *processors.Config,
 	log log.Logger,
 	call ethereum.CallMsg,
+	stateOverride evmutil.StateOverride,
 ) ([]byte, error) {
+	anchor, store, err := withStateOverride(anchor, l1Params, store, processors, log, stateOverride)
+	if err != nil {
+		return nil, err
+	}
+	res, err := evmCall(anchor, l1Params, store, processors, log, call, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.vmError != nil {
+		return nil, res.vmError
+	}
+	return res.ret, nil
+}
+
+type evmCallResult struct {
+	ret []byte
+	// gasUsed is the EVM gas burned by the call
+	gasUsed uint64
+	vmError *isc.VMError
+}
+
+func evmCall(
+	anchor *isc.StateAnchor,
+	l1Params *parameters.L1Params,
+	store indexedstore.IndexedStore,
+	processors *processors.Config,
+	log log.Logger,
+	call ethereum.CallMsg,
+	evmTracer *tracers.Tracer,
+) (*evmCallResult, error) {
 	latestState, err := store.LatestState()
 	if err != nil {
 		return nil, err
@@ -52,16 +86,25 @@ func EVMCall(
 		hashing.PseudoRandomHash(nil),
 		iscReq,
 		true,
+		evmTracer,
 	)
 	if err != nil {
 		return nil, err
 	}
+	ret := &evmCallResult{
+		gasUsed: gas.ISCGasBurnedToEVM(res.Receipt.GasBurned, &info.GasFeePolicy.EVMGasRatio),
+	}
 	if res.Receipt.Error != nil {
 		vmerr, resolvingErr := ResolveError(latestState, res.Receipt.Error)
 		if resolvingErr != nil {
 			panic(fmt.Errorf("error resolving vmerror %w", resolvingErr))
 		}
-		return nil, vmerr
+		ret.vmError = vmerr
+		return ret, nil
+	}
+	ret.ret, err = bcs.Unmarshal[[]byte](res.Return[0])
+	if err != nil {
+		return nil, err
 	}
-	return bcs.Unmarshal[[]byte](res.Return[0])
+	return ret, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/hashing"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
@@ -24,7 +25,7 @@ import (
 var evmErrOutOfGasRegex = regexp.MustCompile("out of gas|intrinsic gas too low")
 
 // EVMEstimateGas executes the given request and discards the resulting chain state. It is useful
-// for estimating gas.
+// for estimating gas. The given state overrides, if any, are applied before executing the request.
 //
 //nolint:gocyclo,funlen
 func EVMEstimateGas(
@@ -34,7 +35,13 @@ func EVMEstimateGas(
 	processors *processors.Config,
 	log log.Logger,
 	call ethereum.CallMsg,
+	stateOverride evmutil.StateOverride,
 ) (uint64, error) {
+	anchor, store, err := withStateOverride(anchor, l1Params, store, processors, log, stateOverride)
+	if err != nil {
+		return 0, err
+	}
+
 	// Determine the lowest and highest possible gas limits to binary search in between
 	intrinsicGas, err := core.IntrinsicGas(call.Data, nil, nil, call.To == nil, true, true, true)
 	if err != nil {
@@ -80,6 +87,7 @@ func EVMEstimateGas(
 			hashing.PseudoRandomHash(nil),
 			iscReq,
 			true,
+			nil,
 		)
 		if err != nil {
 			return true, nil, err
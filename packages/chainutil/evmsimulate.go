@@ -0,0 +1,302 @@
+package chainutil
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/wasp/v2/clients/iscmove"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/hashing"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/state"
+	"github.com/iotaledger/wasp/v2/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/v2/packages/transaction"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/emulator"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/evmimpl"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
+	"github.com/iotaledger/wasp/v2/packages/vm/processors"
+)
+
+// EVMSimulate executes the given sequence of blocks on top of the chain
+// state, each block consisting of a sequence of EVM calls. Each call observes
+// the state changes produced by the previous ones.
+// All blocks are committed to an in-memory store, so the chain state is left
+// untouched.
+func EVMSimulate(
+	anchor *isc.StateAnchor,
+	l1Params *parameters.L1Params,
+	store indexedstore.IndexedStore,
+	processors *processors.Config,
+	log log.Logger,
+	blocks []*evmutil.SimulateBlock,
+) ([]*evmutil.SimulatedBlock, error) {
+	s, err := newEVMSimulator(anchor, l1Params, store, processors, log)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*evmutil.SimulatedBlock, len(blocks))
+	for i, block := range blocks {
+		ret[i], err = s.simulateBlock(block)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+	}
+	return ret, nil
+}
+
+type evmSimulator struct {
+	anchor     *isc.StateAnchor
+	l1Params   *parameters.L1Params
+	store      indexedstore.IndexedStore
+	processors *processors.Config
+	log        log.Logger
+	state      state.State
+}
+
+func newEVMSimulator(
+	anchor *isc.StateAnchor,
+	l1Params *parameters.L1Params,
+	store indexedstore.IndexedStore,
+	processors *processors.Config,
+	log log.Logger,
+) (*evmSimulator, error) {
+	l1Commitment, err := transaction.L1CommitmentFromAnchor(anchor)
+	if err != nil {
+		return nil, err
+	}
+	bufferedStore, err := indexedstore.NewBuffered(store)
+	if err != nil {
+		return nil, err
+	}
+	if err = bufferedStore.SetLatest(l1Commitment.TrieRoot()); err != nil {
+		return nil, err
+	}
+	chainState, err := bufferedStore.LatestState()
+	if err != nil {
+		return nil, err
+	}
+	return &evmSimulator{
+		anchor:     anchor,
+		l1Params:   l1Params,
+		store:      bufferedStore,
+		processors: processors,
+		log:        log,
+		state:      chainState,
+	}, nil
+}
+
+func (s *evmSimulator) simulateBlock(block *evmutil.SimulateBlock) (*evmutil.SimulatedBlock, error) {
+	blockTime := block.Time
+	prevTime := s.state.Timestamp()
+	if blockTime.IsZero() {
+		blockTime = prevTime.Add(time.Second)
+	} else if !blockTime.After(prevTime) {
+		return nil, fmt.Errorf("block timestamp %d is not after the previous block timestamp %d", blockTime.Unix(), prevTime.Unix())
+	}
+
+	if len(block.StateOverride) > 0 {
+		if err := s.applyStateOverride(blockTime, block.StateOverride); err != nil {
+			return nil, err
+		}
+	}
+
+	calls := make([]*evmutil.SimulatedCall, len(block.Calls))
+	for i := range block.Calls {
+		var err error
+		calls[i], err = s.simulateCall(blockTime, block.Calls[i])
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+	}
+
+	header, err := s.mintBlock(blockTime)
+	if err != nil {
+		return nil, err
+	}
+	var logIndex uint
+	for i, call := range calls {
+		for _, l := range call.Logs {
+			l.BlockNumber = header.Number.Uint64()
+			l.BlockHash = header.Hash()
+			l.TxIndex = uint(i)
+			l.Index = logIndex
+			logIndex++
+		}
+	}
+	return &evmutil.SimulatedBlock{Header: header, Calls: calls}, nil
+}
+
+func (s *evmSimulator) applyStateOverride(blockTime time.Time, override evmutil.StateOverride) error {
+	return s.update(blockTime, func(draft state.StateDraft) error {
+		accountsState := accounts.NewStateWriter(draft.SchemaVersion(), accounts.Contract.StateSubrealm(draft))
+		accountsState.SetTimeSource(func() time.Time { return blockTime })
+		return emulator.ApplyStateOverride(
+			emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(evm.ContractPartition(draft))),
+			override,
+			func(addr common.Address, balance *big.Int) {
+				agentID := isc.NewEthereumAddressAgentID(addr)
+				diff := new(big.Int).Sub(balance, accountsState.GetBaseTokensBalanceFullDecimals(agentID))
+				if diff.Sign() > 0 {
+					accountsState.CreditToAccountFullDecimals(agentID, diff)
+				} else {
+					accountsState.DebitFromAccountFullDecimals(agentID, diff.Neg(diff))
+				}
+			},
+		)
+	})
+}
+
+func (s *evmSimulator) mintBlock(blockTime time.Time) (header *types.Header, err error) {
+	chainInfo := getChainInfo(s.anchor.ChainID(), s.state)
+	err = s.update(blockTime, func(draft state.StateDraft) error {
+		header = evmimpl.MintBlock(evm.Contract.StateSubrealm(draft), chainInfo, draft.SchemaVersion(), blockTime)
+		return nil
+	})
+	return header, err
+}
+
+// update creates a new block by applying f to a new state draft
+func (s *evmSimulator) update(blockTime time.Time, f func(draft state.StateDraft) error) error {
+	l1Commitment, err := transaction.L1CommitmentFromAnchor(s.anchor)
+	if err != nil {
+		return err
+	}
+	draft, err := s.store.NewStateDraft(blockTime, l1Commitment)
+	if err != nil {
+		return err
+	}
+	if err = f(draft); err != nil {
+		return err
+	}
+	return s.commit(draft)
+}
+
+func (s *evmSimulator) simulateCall(blockTime time.Time, call ethereum.CallMsg) (*evmutil.SimulatedCall, error) {
+	info := getChainInfo(s.anchor.ChainID(), s.state)
+
+	gasLimit := gas.EVMCallGasLimit(info.GasLimits, &info.GasFeePolicy.EVMGasRatio)
+	if call.Gas != 0 && call.Gas > gasLimit {
+		call.Gas = gasLimit
+	}
+	if call.GasPrice == nil {
+		call.GasPrice = info.GasFeePolicy.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
+	}
+
+	logs := &logCollector{}
+	res, err := runISCTask(
+		s.anchor,
+		s.l1Params,
+		s.store,
+		s.processors,
+		s.log,
+		blockTime,
+		hashing.PseudoRandomHash(nil),
+		[]isc.Request{isc.NewEVMOffLedgerCallRequest(info.ChainID, call)},
+		nil,
+		true,
+		&tracers.Tracer{Hooks: logs.hooks()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.RequestResults) == 0 {
+		return nil, errors.New("request was skipped")
+	}
+	reqResult := res.RequestResults[0]
+
+	ret := &evmutil.SimulatedCall{
+		GasUsed: gas.ISCGasBurnedToEVM(reqResult.Receipt.GasBurned, &info.GasFeePolicy.EVMGasRatio),
+	}
+	if reqResult.Receipt.Error != nil {
+		vmerr, resolvingErr := ResolveError(s.state, reqResult.Receipt.Error)
+		if resolvingErr != nil {
+			return nil, fmt.Errorf("error resolving vmerror: %w", resolvingErr)
+		}
+		ret.Error = vmerr
+	} else {
+		ret.ReturnData, err = bcs.Unmarshal[[]byte](reqResult.Return[0])
+		if err != nil {
+			return nil, err
+		}
+		ret.Logs = logs.logs
+	}
+	// the VM discards the state changes of a failed request, so it is
+	// safe to commit the draft in any case
+	return ret, s.commit(res.StateDraft)
+}
+
+// commit saves the draft in the in-memory store, and advances the anchor so
+// that the next block is executed on top of it.
+func (s *evmSimulator) commit(draft state.StateDraft) error {
+	block, _, _, err := s.store.Commit(draft)
+	if err != nil {
+		return err
+	}
+	if err = s.store.SetLatest(block.TrieRoot()); err != nil {
+		return err
+	}
+	s.state, err = s.store.StateByTrieRoot(block.TrieRoot())
+	if err != nil {
+		return err
+	}
+	s.anchor, err = nextAnchor(s.anchor, block.L1Commitment(), s.state.BlockIndex())
+	return err
+}
+
+func nextAnchor(anchor *isc.StateAnchor, l1Commitment *state.L1Commitment, stateIndex uint32) (*isc.StateAnchor, error) {
+	metadata, err := transaction.StateMetadataFromBytes(anchor.GetStateMetadata())
+	if err != nil {
+		return nil, err
+	}
+	metadata.L1Commitment = l1Commitment
+	next := isc.NewStateAnchor(&iscmove.AnchorWithRef{
+		Owner:     anchor.Anchor().Owner,
+		ObjectRef: anchor.Anchor().ObjectRef,
+		Object: &iscmove.Anchor{
+			ID:            anchor.Anchor().Object.ID,
+			Assets:        anchor.Anchor().Object.Assets,
+			StateMetadata: metadata.Bytes(),
+			StateIndex:    stateIndex,
+		},
+	}, anchor.ISCPackage())
+	return &next, nil
+}
+
+// logCollector collects the EVM logs emitted during a call, discarding the
+// ones emitted by reverted frames.
+type logCollector struct {
+	logs []*types.Log
+	// frames contains the amount of logs at the start of each call frame
+	frames []int
+}
+
+func (c *logCollector) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter: func(int, byte, common.Address, common.Address, []byte, uint64, *big.Int) {
+			c.frames = append(c.frames, len(c.logs))
+		},
+		OnExit: func(_ int, _ []byte, _ uint64, _ error, reverted bool) {
+			n := len(c.frames) - 1
+			if reverted {
+				c.logs = c.logs[:c.frames[n]]
+			}
+			c.frames = c.frames[:n]
+		},
+		OnLog: func(l *types.Log) {
+			c.logs = append(c.logs, l)
+		},
+	}
+}
@@ -34,7 +34,7 @@ func runISCTask(
 	enforceGasBurned []vm.EnforceGasBurned,
 	estimateGasMode bool,
 	evmTracer *tracers.Tracer,
-) ([]*vm.RequestResult, error) {
+) (*vm.VMTaskResult, error) {
 	migs, err := getMigrationsForBlock(store, anchor)
 	if err != nil {
 		return nil, err
@@ -56,11 +56,7 @@ func runISCTask(
 		Log:                  log,
 		Migrations:           migs,
 	}
-	res, err := vmimpl.Run(task)
-	if err != nil {
-		return nil, err
-	}
-	return res.RequestResults, nil
+	return vmimpl.Run(task)
 }
 
 func getMigrationsForBlock(store indexedstore.IndexedStore, anchor *isc.StateAnchor) (*migrations.MigrationScheme, error) {
@@ -93,8 +89,9 @@ func runISCRequest(
 	entropy hashing.HashValue,
 	req isc.Request,
 	estimateGasMode bool,
+	evmTracer *tracers.Tracer,
 ) (*vm.RequestResult, error) {
-	results, err := runISCTask(
+	res, err := runISCTask(
 		anchor,
 		l1Params,
 		store,
@@ -105,13 +102,13 @@ func runISCRequest(
 		[]isc.Request{req},
 		nil,
 		estimateGasMode,
-		nil,
+		evmTracer,
 	)
 	if err != nil {
 		return nil, err
 	}
-	if len(results) == 0 {
+	if len(res.RequestResults) == 0 {
 		return nil, errors.New("request was skipped")
 	}
-	return results[0], nil
+	return res.RequestResults[0], nil
 }
@@ -31,6 +31,7 @@ func SimulateRequest(
 		hashing.PseudoRandomHash(nil),
 		req,
 		estimateGasMode,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,34 @@
+package chainutil
+
+import (
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/v2/packages/vm/processors"
+)
+
+// withStateOverride returns an in-memory store and an anchor pointing to a
+// state where the given overrides are applied on top of the anchor state.
+// If there are no overrides, the given anchor and store are returned.
+func withStateOverride(
+	anchor *isc.StateAnchor,
+	l1Params *parameters.L1Params,
+	store indexedstore.IndexedStore,
+	processors *processors.Config,
+	log log.Logger,
+	stateOverride evmutil.StateOverride,
+) (*isc.StateAnchor, indexedstore.IndexedStore, error) {
+	if len(stateOverride) == 0 {
+		return anchor, store, nil
+	}
+	s, err := newEVMSimulator(anchor, l1Params, store, processors, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = s.applyStateOverride(s.state.Timestamp(), stateOverride); err != nil {
+		return nil, nil, err
+	}
+	return s.anchor, s.store, nil
+}
@@ -103,7 +103,7 @@ func TestEVMCall(t *testing.T) {
 
 	logger := testlogger.NewLogger(t)
 
-	result, err := chainutil.EVMCall(anchor, parameterstest.L1Mock, store, coreprocessors.NewConfig(), logger, msg)
+	result, err := chainutil.EVMCall(anchor, parameterstest.L1Mock, store, coreprocessors.NewConfig(), logger, msg, nil)
 	if err != nil {
 		t.Fatalf("failed to call EVM: %v", err)
 	}
@@ -130,7 +130,7 @@
 //     the evm core contract.
 //
 //   - The entry point handler function, `callContract` calls
-//     [emulator.EVMEmulator.CallContract], which in turn calls
+//     [emulator.EVMEmulator.ApplyCall], which in turn calls
 //     [emulator.EVMEmulator.applyMessage], just like when processing a regular
 //     transaction.
 //
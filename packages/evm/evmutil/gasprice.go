@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/core/types"
+
 	"github.com/iotaledger/wasp/v2/packages/parameters"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 func CheckGasPrice(gasPrice *big.Int, gasFeePolicy *gas.FeePolicy) error {
-	minimumGasPrice := gasFeePolicy.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
+	minimumGasPrice := BaseFee(gasFeePolicy)
 	if gasPrice.Cmp(minimumGasPrice) < 0 {
 		return fmt.Errorf(
 			"insufficient gas price: got %s, minimum is %s",
@@ -19,3 +21,42 @@ func CheckGasPrice(gasPrice *big.Int, gasFeePolicy *gas.FeePolicy) error {
 	}
 	return nil
 }
+
+// BaseFee returns the minimum gas price (in wei) accepted by the chain with
+// the given fee policy. It is reported as the block base fee in
+// eth_feeHistory. When the dynamic gas price is enabled, the fee policy of
+// each block already includes the gas price multiplier of the block.
+func BaseFee(gasFeePolicy *gas.FeePolicy) *big.Int {
+	return gasFeePolicy.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
+}
+
+// EffectiveGasPrice returns the gas price paid by the transaction.
+// Transactions sent before the gas price was mandatory have a zero gas
+// price, and were charged the default gas price instead.
+func EffectiveGasPrice(tx *types.Transaction, gasFeePolicy *gas.FeePolicy) *big.Int {
+	gasPrice := tx.GasPrice()
+	if gasPrice.Sign() == 0 && !gasFeePolicy.GasPerToken.IsEmpty() {
+		return BaseFee(gasFeePolicy)
+	}
+	return gasPrice
+}
+
+// ApplyFeeMultiplier returns the gas price multiplied by the fee multiplier
+// of the sender, in basis points (see gas.GasPriceMultiplierBase)
+func ApplyFeeMultiplier(gasPrice *big.Int, multiplier uint32) *big.Int {
+	if multiplier == gas.GasPriceMultiplierBase {
+		return gasPrice
+	}
+	ret := new(big.Int).Mul(gasPrice, big.NewInt(int64(multiplier)))
+	return ret.Quo(ret, big.NewInt(gas.GasPriceMultiplierBase))
+}
+
+// EffectiveGasTip returns the amount paid by the transaction on top of the
+// base fee, per gas unit.
+func EffectiveGasTip(tx *types.Transaction, gasFeePolicy *gas.FeePolicy) *big.Int {
+	tip := new(big.Int).Sub(EffectiveGasPrice(tx, gasFeePolicy), BaseFee(gasFeePolicy))
+	if tip.Sign() < 0 {
+		return new(big.Int)
+	}
+	return tip
+}
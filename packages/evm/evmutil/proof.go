@@ -0,0 +1,170 @@
+package evmutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/trie"
+	"github.com/iotaledger/wasp/v2/packages/util"
+)
+
+// AccountProof is the result of eth_getProof on an ISC chain.
+//
+// The ISC state is not a Merkle-Patricia trie, so the EIP-1186 fields are
+// kept for compatibility, but their meaning is different:
+//   - AccountProof contains one BCS-encoded [trie.MerkleProof] for each of
+//     the account keys, in the order defined by [AccountProofKeys.Account]:
+//     nonce, code, base tokens balance and wei remainder.
+//   - StorageHash is always empty, since there is no per-account storage trie.
+//   - Each StorageProof contains a single BCS-encoded [trie.MerkleProof]
+//     for the storage slot.
+//
+// The proofs, together with the raw state values in AccountValues and
+// StorageProof[i].RawValue, can be checked against a trusted
+// [state.L1Commitment] with [AccountProof.Verify].
+type AccountProof struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageProof  `json:"storageProof"`
+
+	// AccountValues are the raw state values committed by AccountProof
+	AccountValues []hexutil.Bytes `json:"accountValues"`
+	// Code is the account code, which is needed to verify the code proof
+	Code hexutil.Bytes `json:"code"`
+	// L1Commitment is the commitment of the state the proofs were taken from
+	L1Commitment hexutil.Bytes `json:"l1Commitment"`
+	// TrieRoot is the root of the ISC state trie the proofs are anchored to
+	TrieRoot hexutil.Bytes `json:"trieRoot"`
+}
+
+// StorageProof is the proof of a single storage slot. See [AccountProof].
+type StorageProof struct {
+	Key      string          `json:"key"`
+	Value    *hexutil.Big    `json:"value"`
+	Proof    []hexutil.Bytes `json:"proof"`
+	RawValue hexutil.Bytes   `json:"rawValue"`
+}
+
+// AccountProofKeys are the ISC state keys holding the data of an EVM account.
+type AccountProofKeys struct {
+	Nonce        []byte
+	Code         []byte
+	Balance      []byte
+	WeiRemainder []byte
+	Storage      [][]byte
+}
+
+// Account returns the keys covered by [AccountProof.AccountProof], in order.
+func (k *AccountProofKeys) Account() [][]byte {
+	return [][]byte{k.Nonce, k.Code, k.Balance, k.WeiRemainder}
+}
+
+// TrieRootCommitment is implemented by [state.L1Commitment].
+type TrieRootCommitment interface {
+	TrieRoot() trie.Hash
+	Bytes() []byte
+}
+
+// EncodeMerkleProof encodes the proof in the format used by [AccountProof].
+func EncodeMerkleProof(p *trie.MerkleProof) hexutil.Bytes {
+	return bcs.MustMarshal(p)
+}
+
+// DecodeMerkleProof decodes a proof encoded with [EncodeMerkleProof].
+func DecodeMerkleProof(b []byte) (*trie.MerkleProof, error) {
+	return bcs.Unmarshal[*trie.MerkleProof](b)
+}
+
+// Verify checks all proofs contained in p against the trusted L1 commitment.
+// keys must be computed by the verifier for the expected address and
+// storage slots (see jsonrpc.AccountProofKeys), and not taken from the
+// response.
+func (p *AccountProof) Verify(l1Commitment TrieRootCommitment, keys *AccountProofKeys) error {
+	if !bytes.Equal(p.L1Commitment, l1Commitment.Bytes()) {
+		return errors.New("proof is not anchored to the given L1 commitment")
+	}
+	root := l1Commitment.TrieRoot()
+
+	accountKeys := keys.Account()
+	if len(p.AccountProof) != len(accountKeys) || len(p.AccountValues) != len(accountKeys) {
+		return fmt.Errorf("expected %d account proofs and values", len(accountKeys))
+	}
+	for i, key := range accountKeys {
+		if err := verifyProofItem(root, key, p.AccountValues[i], p.AccountProof[i]); err != nil {
+			return fmt.Errorf("account proof #%d: %w", i, err)
+		}
+	}
+	if err := p.verifyAccountValues(); err != nil {
+		return err
+	}
+
+	if len(p.StorageProof) != len(keys.Storage) {
+		return fmt.Errorf("expected %d storage proofs", len(keys.Storage))
+	}
+	for i, sp := range p.StorageProof {
+		if len(sp.Proof) != 1 {
+			return fmt.Errorf("storage proof #%d: expected exactly one proof", i)
+		}
+		if err := verifyProofItem(root, keys.Storage[i], sp.RawValue, sp.Proof[0]); err != nil {
+			return fmt.Errorf("storage proof #%d: %w", i, err)
+		}
+		if sp.Value == nil || (*big.Int)(sp.Value).Cmp(new(big.Int).SetBytes(sp.RawValue)) != 0 {
+			return fmt.Errorf("storage proof #%d: value mismatch", i)
+		}
+	}
+	return nil
+}
+
+func (p *AccountProof) verifyAccountValues() error {
+	nonce, err := codec.Decode[uint64](p.AccountValues[0], 0)
+	if err != nil {
+		return fmt.Errorf("cannot decode nonce: %w", err)
+	}
+	if nonce != uint64(p.Nonce) {
+		return errors.New("nonce mismatch")
+	}
+
+	if !bytes.Equal(p.Code, p.AccountValues[1]) {
+		return errors.New("code mismatch")
+	}
+	if p.CodeHash != crypto.Keccak256Hash(p.Code) {
+		return errors.New("code hash mismatch")
+	}
+
+	baseTokens, err := codec.Decode[coin.Value](p.AccountValues[2], 0)
+	if err != nil {
+		return fmt.Errorf("cannot decode balance: %w", err)
+	}
+	remainder, err := codec.Decode[*big.Int](p.AccountValues[3], new(big.Int))
+	if err != nil {
+		return fmt.Errorf("cannot decode wei remainder: %w", err)
+	}
+	balance := util.BaseTokensDecimalsToEthereumDecimals(baseTokens, parameters.BaseTokenDecimals)
+	balance.Add(balance, remainder)
+	if p.Balance == nil || (*big.Int)(p.Balance).Cmp(balance) != 0 {
+		return errors.New("balance mismatch")
+	}
+	return nil
+}
+
+func verifyProofItem(root trie.Hash, key, value, encodedProof []byte) error {
+	proof, err := DecodeMerkleProof(encodedProof)
+	if err != nil {
+		return fmt.Errorf("cannot decode proof: %w", err)
+	}
+	return proof.ValidateKeyValue(root, key, value)
+}
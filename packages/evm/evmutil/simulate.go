@@ -0,0 +1,38 @@
+package evmutil
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SimulateBlock is a virtual block executed on top of the chain state by
+// eth_simulateV1.
+type SimulateBlock struct {
+	// Time is the block timestamp. If zero, it is set one second after the
+	// previous block.
+	Time time.Time
+	// StateOverride is applied before executing the calls of the block
+	StateOverride StateOverride
+	Calls         []ethereum.CallMsg
+}
+
+// SimulatedBlock is the result of executing a [SimulateBlock].
+type SimulatedBlock struct {
+	// Header is the header of the Ethereum block minted after executing the
+	// calls. Note that the calls are not included in the block, so Header
+	// does not account for their gas usage.
+	Header *types.Header
+	Calls  []*SimulatedCall
+}
+
+// SimulatedCall is the result of a call executed in a [SimulateBlock].
+type SimulatedCall struct {
+	ReturnData []byte
+	Logs       []*types.Log
+	// GasUsed is the EVM gas burned by the call
+	GasUsed uint64
+	// Error is the error returned by the call, if it failed
+	Error error
+}
@@ -0,0 +1,40 @@
+package evmutil
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountOverride contains the fields of an EVM account that are replaced
+// before executing a call. nil fields are left untouched.
+type AccountOverride struct {
+	Nonce *uint64
+	// Code replaces the account code. An empty non-nil slice removes it.
+	Code    []byte
+	Balance *big.Int
+	// State replaces the whole account storage
+	State map[common.Hash]common.Hash
+	// StateDiff replaces only the given storage slots
+	StateDiff map[common.Hash]common.Hash
+}
+
+// StateOverride is a set of account overrides, by address.
+type StateOverride map[common.Address]*AccountOverride
+
+// Validate checks that the overrides are consistent.
+func (o StateOverride) Validate() error {
+	for addr, account := range o {
+		if account == nil {
+			continue
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		if account.Balance != nil && account.Balance.Sign() < 0 {
+			return fmt.Errorf("account %s: negative balance", addr.Hex())
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/hashing"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
@@ -22,8 +23,10 @@ import (
 // ChainBackend provides access to the underlying ISC chain.
 type ChainBackend interface {
 	EVMSendTransaction(tx *types.Transaction) error
-	EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) ([]byte, error)
-	EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (uint64, error)
+	EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) ([]byte, error)
+	EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) (uint64, error)
+	EVMCreateAccessList(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (types.AccessList, uint64, *isc.VMError, error)
+	EVMSimulate(anchor *isc.StateAnchor, blocks []*evmutil.SimulateBlock, l1Params *parameters.L1Params) ([]*evmutil.SimulatedBlock, error)
 	EVMTrace(
 		anchor *isc.StateAnchor,
 		blockTime time.Time,
@@ -33,6 +36,8 @@ type ChainBackend interface {
 		tracer *tracers.Tracer,
 		l1Params *parameters.L1Params,
 	) error
+	// EVMPendingTransactions returns the EVM transactions waiting in the mempool
+	EVMPendingTransactions() []*types.Transaction
 	FeePolicy(blockIndex uint32) (*gas.FeePolicy, error)
 	ISCChainID() *isc.ChainID
 	ISCCallView(chainState state.State, msg isc.Message) (isc.CallArguments, error)
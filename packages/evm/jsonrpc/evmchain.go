@@ -10,12 +10,14 @@ import (
 	"math"
 	"math/big"
 	"path"
+	"time"
 
 	"fortio.org/safecast"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -56,6 +58,7 @@ type EVMChain struct {
 	backend  ChainBackend
 	chainID  uint16 // cache
 	newBlock *event.Event1[*NewBlockEvent]
+	newTx    *event.Event1[common.Hash]
 	log      log.Logger
 	index    *Index // only indexes blocks that will be pruned from the active state
 }
@@ -81,6 +84,7 @@ func NewEVMChain(
 	e := &EVMChain{
 		backend:  backend,
 		newBlock: event.New1[*NewBlockEvent](),
+		newTx:    event.New1[common.Hash](),
 		log:      log,
 		index: NewIndex(
 			backend.ISCStateByTrieRoot,
@@ -166,10 +170,16 @@ func (e *EVMChain) BlockNumber() *big.Int {
 	return big.NewInt(0).SetUint64(db.GetNumber())
 }
 
+// GasFeePolicy returns the fee policy of the next block, which takes into
+// account the dynamic gas price, if enabled
 func (e *EVMChain) GasFeePolicy() *gas.FeePolicy {
 	_, state := lo.Must2(e.backend.ISCLatestState())
 	govState := governance.NewStateReaderFromChainState(state)
-	return govState.GetGasFeePolicy()
+	blockInfo, ok := blocklog.NewStateReaderFromChainState(state).GetBlockInfo(state.BlockIndex())
+	if !ok {
+		return govState.GetGasFeePolicy()
+	}
+	return govState.GetNextGasFeePolicy(blockInfo.GasBurned, time.Since(blockInfo.Timestamp))
 }
 
 func (e *EVMChain) gasLimits() *gas.Limits {
@@ -208,7 +218,11 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	if err := e.checkEnoughL2FundsForGasBudget(sender, tx, gasFeePolicy); err != nil {
 		return err
 	}
-	return e.backend.EVMSendTransaction(tx)
+	if err := e.backend.EVMSendTransaction(tx); err != nil {
+		return err
+	}
+	e.newTx.Trigger(tx.Hash())
+	return nil
 }
 
 func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, tx *types.Transaction, gasFeePolicy *gas.FeePolicy) error {
@@ -466,7 +480,10 @@ func (e *EVMChain) TransactionCount(address common.Address, blockNumberOrHash *r
 	return emulator.GetNonce(stateDBSubrealmR(chainState), address), nil
 }
 
-func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) ([]byte, error) {
+// CallContract executes the call on top of the given block, discarding any
+// state changes. The given state overrides, if any, are applied before
+// executing the call.
+func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, stateOverride evmutil.StateOverride) ([]byte, error) {
 	anchor, err := e.iscAnchorFromEVMBlockNumberOrHash(blockNumberOrHash)
 	if err != nil {
 		return nil, err
@@ -475,10 +492,13 @@ func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc
 	if err != nil {
 		return nil, err
 	}
-	return e.backend.EVMCall(anchor, callMsg, blockinfo.L1Params)
+	return e.backend.EVMCall(anchor, callMsg, stateOverride, blockinfo.L1Params)
 }
 
-func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) (uint64, error) {
+// EstimateGas estimates the gas needed to execute the call on top of the
+// given block. The given state overrides, if any, are applied before
+// executing the call.
+func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, stateOverride evmutil.StateOverride) (uint64, error) {
 	anchor, err := e.iscAnchorFromEVMBlockNumberOrHash(blockNumberOrHash)
 	if err != nil {
 		return 0, err
@@ -487,7 +507,57 @@ func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.
 	if err != nil {
 		return 0, err
 	}
-	return e.backend.EVMEstimateGas(anchor, callMsg, blockinfo.L1Params)
+	return e.backend.EVMEstimateGas(anchor, callMsg, stateOverride, blockinfo.L1Params)
+}
+
+// CreateAccessList executes the call and returns the access list it would
+// need, along with the gas used and the error returned by the call, if any.
+func (e *EVMChain) CreateAccessList(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) (*RPCAccessListResult, error) {
+	e.log.LogDebugf("CreateAccessList(from=%v, to=%v, blockNumberOrHash=%v)", callMsg.From, callMsg.To, blockNumberOrHash)
+	anchor, err := e.iscAnchorFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	blockinfo, err := e.getBlockInfoByAnchor(anchor)
+	if err != nil {
+		return nil, err
+	}
+	accessList, gasUsed, vmError, err := e.backend.EVMCreateAccessList(anchor, callMsg, blockinfo.L1Params)
+	if err != nil {
+		return nil, err
+	}
+	ret := &RPCAccessListResult{
+		AccessList: &accessList,
+		GasUsed:    hexutil.Uint64(gasUsed),
+	}
+	if vmError != nil {
+		ret.Error = vmError.Error()
+	}
+	return ret, nil
+}
+
+// maxSimulateBlocks is the maximum amount of blocks accepted by SimulateV1
+const maxSimulateBlocks = 256
+
+// SimulateV1 executes the given sequence of blocks on top of the given block,
+// discarding any state changes.
+func (e *EVMChain) SimulateV1(blocks []*evmutil.SimulateBlock, blockNumberOrHash *rpc.BlockNumberOrHash) ([]*evmutil.SimulatedBlock, error) {
+	e.log.LogDebugf("SimulateV1(blocks=%d, blockNumberOrHash=%v)", len(blocks), blockNumberOrHash)
+	if len(blocks) == 0 {
+		return nil, errors.New("empty input")
+	}
+	if len(blocks) > maxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks: %d > %d", len(blocks), maxSimulateBlocks)
+	}
+	anchor, err := e.iscAnchorFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	blockinfo, err := e.getBlockInfoByAnchor(anchor)
+	if err != nil {
+		return nil, err
+	}
+	return e.backend.EVMSimulate(anchor, blocks, blockinfo.L1Params)
 }
 
 func (e *EVMChain) GasPrice() *big.Int {
@@ -504,6 +574,75 @@ func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumbe
 	return emulator.GetState(stateDBSubrealmR(chainState), address, key), nil
 }
 
+// AccountProofKeys returns the ISC state keys holding the data of the given
+// EVM account and storage slots.
+func AccountProofKeys(address common.Address, storageKeys []common.Hash) *evmutil.AccountProofKeys {
+	stateDBKey := func(key kv.Key) []byte {
+		return []byte(evm.EmulatorStateKey(emulator.StateDBKey(key)))
+	}
+	balanceKey, weiRemainderKey := accounts.BaseTokensBalanceKeys(isc.NewEthereumAddressAgentID(address))
+	keys := &evmutil.AccountProofKeys{
+		Nonce:        stateDBKey(emulator.AccountNonceKey(address)),
+		Code:         stateDBKey(emulator.AccountCodeKey(address)),
+		Balance:      []byte(balanceKey),
+		WeiRemainder: []byte(weiRemainderKey),
+		Storage:      make([][]byte, len(storageKeys)),
+	}
+	for i, k := range storageKeys {
+		keys.Storage[i] = stateDBKey(emulator.AccountStateKey(address, k))
+	}
+	return keys
+}
+
+// Proof returns the proofs of the account data and the given storage slots,
+// anchored to the L1 commitment of the given block.
+func (e *EVMChain) Proof(address common.Address, storageKeys []common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (*evmutil.AccountProof, error) {
+	e.log.LogDebugf("Proof(address=%v, storageKeys=%v, blockNumberOrHash=%v)", address, storageKeys, blockNumberOrHash)
+	anchor, err := e.iscAnchorFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	stateMetadata, err := transaction.StateMetadataFromBytes(anchor.GetStateMetadata())
+	if err != nil {
+		return nil, err
+	}
+	l1Commitment := stateMetadata.L1Commitment
+	chainState, err := e.backend.ISCStateByTrieRoot(l1Commitment.TrieRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := AccountProofKeys(address, storageKeys)
+	accountKeys := keys.Account()
+	code := emulator.GetCode(stateDBSubrealmR(chainState), address)
+	ret := &evmutil.AccountProof{
+		Address:       address,
+		AccountProof:  make([]hexutil.Bytes, len(accountKeys)),
+		AccountValues: make([]hexutil.Bytes, len(accountKeys)),
+		Balance:       (*hexutil.Big)(e.accountsState(chainState).GetBaseTokensBalanceFullDecimals(isc.NewEthereumAddressAgentID(address))),
+		Code:          code,
+		CodeHash:      crypto.Keccak256Hash(code),
+		Nonce:         hexutil.Uint64(emulator.GetNonce(stateDBSubrealmR(chainState), address)),
+		StorageProof:  make([]evmutil.StorageProof, len(storageKeys)),
+		L1Commitment:  l1Commitment.Bytes(),
+		TrieRoot:      l1Commitment.TrieRoot().Bytes(),
+	}
+	for i, key := range accountKeys {
+		ret.AccountProof[i] = evmutil.EncodeMerkleProof(chainState.GetMerkleProof(key))
+		ret.AccountValues[i] = chainState.Get(kv.Key(key))
+	}
+	for i, key := range keys.Storage {
+		value := chainState.Get(kv.Key(key))
+		ret.StorageProof[i] = evmutil.StorageProof{
+			Key:      storageKeys[i].Hex(),
+			Value:    (*hexutil.Big)(new(big.Int).SetBytes(value)),
+			Proof:    []hexutil.Bytes{evmutil.EncodeMerkleProof(chainState.GetMerkleProof(key))},
+			RawValue: value,
+		}
+	}
+	return ret, nil
+}
+
 func (e *EVMChain) BlockTransactionCountByHash(blockHash common.Hash) uint64 {
 	e.log.LogDebugf("BlockTransactionCountByHash(blockHash=%v)", blockHash)
 	block := e.BlockByHash(blockHash)
@@ -624,13 +763,38 @@ func filterAndAppendToLogs(query *ethereum.FilterQuery, receipts []*types.Receip
 
 func (e *EVMChain) SubscribeNewHeads(ch chan<- *types.Header) (unsubscribe func()) {
 	e.log.LogDebugf("SubscribeNewHeads(ch=?)")
-	return e.newBlock.Hook(func(ev *NewBlockEvent) {
-		ch <- ev.block.Header()
-	}).Unhook
+	return e.hookNewHeads(func(h *types.Header) { ch <- h })
+}
+
+// SubscribePendingTransactions notifies the hashes of the transactions sent
+// to the chain through [EVMChain.SendTransaction].
+func (e *EVMChain) SubscribePendingTransactions(ch chan<- common.Hash) (unsubscribe func()) {
+	e.log.LogDebugf("SubscribePendingTransactions(ch=?)")
+	return e.hookPendingTransactions(func(txHash common.Hash) { ch <- txHash })
 }
 
 func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log) (unsubscribe func()) {
 	e.log.LogDebugf("SubscribeLogs(q=%v, ch=?)", q)
+	return e.hookLogs(q, func(logs []*types.Log) { ch <- logs })
+}
+
+// hookNewHeads calls fn for each new block. fn is called synchronously by the
+// publisher, so it must not block.
+func (e *EVMChain) hookNewHeads(fn func(*types.Header)) (unhook func()) {
+	return e.newBlock.Hook(func(ev *NewBlockEvent) {
+		fn(ev.block.Header())
+	}).Unhook
+}
+
+// hookPendingTransactions calls fn for each transaction sent through
+// [EVMChain.SendTransaction]. fn must not block.
+func (e *EVMChain) hookPendingTransactions(fn func(common.Hash)) (unhook func()) {
+	return e.newTx.Hook(fn).Unhook
+}
+
+// hookLogs calls fn with the logs of each new block matching the query. fn is
+// called synchronously by the publisher, so it must not block.
+func (e *EVMChain) hookLogs(q *ethereum.FilterQuery, fn func([]*types.Log)) (unhook func()) {
 	return e.newBlock.Hook(func(ev *NewBlockEvent) {
 		if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
 			return
@@ -649,7 +813,7 @@ func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log
 			}
 		}
 		if len(matchedLogs) > 0 {
-			ch <- matchedLogs
+			fn(matchedLogs)
 		}
 	}).Unhook
 }
@@ -878,6 +1042,41 @@ func (e *EVMChain) GetBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash) ([]*typ
 	return db.GetReceiptsByBlockNumber(block.NumberU64()), db.GetTransactionsByBlockNumber(block.NumberU64()), nil
 }
 
+// FeeMultipliers returns the fee multipliers applied to the senders of the
+// requests included in the given EVM block, by request ID. The requests
+// without a fee multiplier are omitted.
+func (e *EVMChain) FeeMultipliers(blockNumber uint64) (map[isc.RequestID]*uint32, error) {
+	chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	_, receipts, err := blocklog.NewStateReaderFromChainState(chainState).GetRequestReceiptsInBlock(chainState.BlockIndex())
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[isc.RequestID]*uint32)
+	for _, rec := range receipts {
+		if rec.FeeMultiplier != nil {
+			ret[rec.Request.ID()] = rec.FeeMultiplier
+		}
+	}
+	return ret, nil
+}
+
+// FeeMultiplier returns the fee multiplier applied to the sender of the
+// transaction included in the given EVM block, or nil if there is none.
+func (e *EVMChain) FeeMultiplier(blockNumber uint64, txHash common.Hash) (*uint32, error) {
+	chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	rec, err := blocklog.NewStateReaderFromChainState(chainState).GetRequestRecordDataByRequestID(isc.RequestIDFromEVMTxHash(txHash))
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	return rec.FeeMultiplier, nil
+}
+
 func (e *EVMChain) TraceBlock(bn rpc.BlockNumber) (any, error) {
 	e.log.LogDebugf("TraceBlock(blockNumber=%v)", bn)
 
@@ -0,0 +1,191 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+)
+
+const (
+	// maxRewardPercentiles is copied from go-ethereum
+	maxRewardPercentiles = 100
+
+	// maxPriorityFeeBlocks is the amount of recent blocks taken into account
+	// by eth_maxPriorityFeePerGas
+	maxPriorityFeeBlocks = 20
+	// maxPriorityFeePercentile is the percentile of the tips of each block
+	// taken into account by eth_maxPriorityFeePerGas
+	maxPriorityFeePercentile = 60
+)
+
+// RPCFeeHistory is the result of eth_feeHistory
+type RPCFeeHistory struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee data of blockCount blocks ending at newestBlock.
+//
+// The base fee of each block is the minimum gas price set by the chain's fee
+// policy at that block, which changes from block to block only if the dynamic
+// gas price is enabled. The rewards are the amounts paid by the transactions
+// on top of it.
+func (e *EVMChain) FeeHistory(blockCount uint64, newestBlock rpc.BlockNumber, rewardPercentiles []float64, maxBlockCount uint64) (*RPCFeeHistory, error) {
+	e.log.LogDebugf("FeeHistory(blockCount=%v, newestBlock=%v, rewardPercentiles=%v)", blockCount, newestBlock, rewardPercentiles)
+	if len(rewardPercentiles) > maxRewardPercentiles {
+		return nil, fmt.Errorf("too many reward percentiles: %d, max %d", len(rewardPercentiles), maxRewardPercentiles)
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p <= rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("reward percentiles must be in ascending order: #%d:%f >= #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	if maxBlockCount > 0 && blockCount > maxBlockCount {
+		blockCount = maxBlockCount
+	}
+
+	latestBlock := e.BlockNumber().Uint64()
+	last := latestBlock
+	if n := parseBlockNumber(newestBlock); n != nil {
+		if !n.IsUint64() || n.Uint64() > latestBlock {
+			return nil, fmt.Errorf("block %s not found", n)
+		}
+		last = n.Uint64()
+	}
+	if blockCount > last+1 {
+		blockCount = last + 1
+	}
+	ret := &RPCFeeHistory{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(last + 1 - blockCount)),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+	if blockCount == 0 {
+		return ret, nil
+	}
+	ret.BaseFee = make([]*hexutil.Big, 0, blockCount+1)
+	if len(rewardPercentiles) > 0 {
+		ret.Reward = make([][]*hexutil.Big, 0, blockCount)
+	}
+
+	for n := last + 1 - blockCount; n <= last; n++ {
+		chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		feePolicy := governance.NewStateReaderFromChainState(chainState).GetGasFeePolicy()
+		db := blockchainDB(chainState)
+		header := db.GetHeaderByBlockNumber(n)
+		if header == nil {
+			// the block has been pruned, return only the blocks that are
+			// still available
+			ret.OldestBlock = (*hexutil.Big)(new(big.Int).SetUint64(n + 1))
+			ret.BaseFee = ret.BaseFee[:0]
+			ret.GasUsedRatio = ret.GasUsedRatio[:0]
+			if ret.Reward != nil {
+				ret.Reward = ret.Reward[:0]
+			}
+			continue
+		}
+
+		ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(evmutil.BaseFee(feePolicy)))
+		gasUsedRatio := 0.0
+		if header.GasLimit > 0 {
+			gasUsedRatio = float64(header.GasUsed) / float64(header.GasLimit)
+		}
+		ret.GasUsedRatio = append(ret.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) > 0 {
+			txs := db.GetTransactionsByBlockNumber(n)
+			receipts := db.GetReceiptsByBlockNumber(n)
+			if len(txs) != len(receipts) {
+				return nil, errors.New("receipts length mismatch")
+			}
+			tips := make([]*big.Int, len(txs))
+			for i, tx := range txs {
+				tips[i] = evmutil.EffectiveGasTip(tx, feePolicy)
+			}
+			ret.Reward = append(ret.Reward, rewardPercentilesOf(tips, receipts, rewardPercentiles))
+		}
+	}
+
+	// the base fee of the next block is given by the next fee policy
+	ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(evmutil.BaseFee(e.GasFeePolicy())))
+	return ret, nil
+}
+
+// rewardPercentilesOf returns the tips at the given percentiles, weighted by
+// the gas used by each transaction (same as go-ethereum).
+func rewardPercentilesOf(tips []*big.Int, receipts []*types.Receipt, percentiles []float64) []*hexutil.Big {
+	ret := make([]*hexutil.Big, len(percentiles))
+	if len(tips) == 0 {
+		for i := range ret {
+			ret[i] = (*hexutil.Big)(new(big.Int))
+		}
+		return ret
+	}
+
+	type txGasAndTip struct {
+		gasUsed uint64
+		tip     *big.Int
+	}
+	sorted := make([]txGasAndTip, len(tips))
+	totalGasUsed := uint64(0)
+	for i, tip := range tips {
+		sorted[i] = txGasAndTip{gasUsed: receipts[i].GasUsed, tip: tip}
+		totalGasUsed += receipts[i].GasUsed
+	}
+	slices.SortStableFunc(sorted, func(a, b txGasAndTip) int {
+		return a.tip.Cmp(b.tip)
+	})
+
+	txIndex := 0
+	sumGasUsed := sorted[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].gasUsed
+		}
+		ret[i] = (*hexutil.Big)(sorted[txIndex].tip)
+	}
+	return ret
+}
+
+// MaxPriorityFeePerGas suggests a tip for new transactions, based on the
+// tips paid in the most recent blocks.
+func (e *EVMChain) MaxPriorityFeePerGas() (*big.Int, error) {
+	e.log.LogDebugf("MaxPriorityFeePerGas()")
+	history, err := e.FeeHistory(maxPriorityFeeBlocks, rpc.LatestBlockNumber, []float64{maxPriorityFeePercentile}, 0)
+	if err != nil {
+		return nil, err
+	}
+	var tips []*big.Int
+	for i, reward := range history.Reward {
+		if history.GasUsedRatio[i] == 0 {
+			// empty block
+			continue
+		}
+		tips = append(tips, (*big.Int)(reward[0]))
+	}
+	if len(tips) == 0 {
+		return new(big.Int), nil
+	}
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+	return tips[len(tips)/2], nil
+}
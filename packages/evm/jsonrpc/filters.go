@@ -0,0 +1,277 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FiltersLimits configures the stateful filters installed via
+// eth_newFilter, eth_newBlockFilter and eth_newPendingTransactionFilter.
+type FiltersLimits struct {
+	// MaxFiltersPerClient is the maximum amount of filters a single client
+	// (see [ContextWithClientID]) can have installed. 0 = unlimited.
+	MaxFiltersPerClient int
+	// FilterTimeout is the duration after which a filter that has not
+	// been polled is uninstalled. 0 = no timeout.
+	FilterTimeout time.Duration
+}
+
+var (
+	errFilterNotFound     = errors.New("filter not found")
+	errTooManyFilters     = errors.New("too many filters installed")
+	errFilterKindMismatch = errors.New("filter is not a logs filter")
+)
+
+type filterKind int
+
+const (
+	logsFilter filterKind = iota
+	blocksFilter
+	pendingTransactionsFilter
+)
+
+type filter struct {
+	kind        filterKind
+	client      string
+	query       ethereum.FilterQuery
+	timer       *time.Timer
+	unsubscribe func()
+
+	mu     sync.Mutex
+	logs   []*types.Log
+	hashes []common.Hash
+}
+
+// FilterManager keeps track of the filters installed by the clients of a
+// JSON-RPC server. Filter changes are accumulated from the EVMChain events
+// (without blocking the publisher) until they are polled with
+// eth_getFilterChanges. Filters that are not polled within
+// [FiltersLimits.FilterTimeout] are uninstalled.
+type FilterManager struct {
+	evmChain *EVMChain
+	params   *Parameters
+
+	mu      sync.Mutex
+	filters map[rpc.ID]*filter
+}
+
+func NewFilterManager(evmChain *EVMChain, params *Parameters) *FilterManager {
+	return &FilterManager{
+		evmChain: evmChain,
+		params:   params,
+		filters:  make(map[rpc.ID]*filter),
+	}
+}
+
+type clientIDContextKey struct{}
+
+// ContextWithClientID returns a context identifying the client of an HTTP
+// JSON-RPC request, e.g. by the real IP reported by a reverse proxy. It must be
+// set on the request context before it is served, otherwise the remote address
+// of the connection is used.
+func ContextWithClientID(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, clientIDContextKey{}, clientID)
+}
+
+// filterClient identifies the client that issued the request, so that the
+// amount of filters per client can be limited. Websocket clients are
+// identified by their connection, so that the clients behind the same proxy
+// do not share the limit.
+func filterClient(ctx context.Context) string {
+	if clientID, ok := ctx.Value(clientIDContextKey{}).(string); ok && clientID != "" {
+		return clientID
+	}
+	peer := rpc.PeerInfoFromContext(ctx)
+	if peer.Transport == "ws" {
+		return websocketFilterClient(peer.RemoteAddr)
+	}
+	remoteAddr := peer.RemoteAddr
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+func websocketFilterClient(remoteAddr string) string {
+	return "ws:" + remoteAddr
+}
+
+func (m *FilterManager) install(ctx context.Context, f *filter, subscribe func(f *filter) (unsubscribe func())) (rpc.ID, error) {
+	f.client = filterClient(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if maxFilters := m.params.Filters.MaxFiltersPerClient; maxFilters > 0 {
+		n := 0
+		for _, other := range m.filters {
+			if other.client == f.client {
+				n++
+			}
+		}
+		if n >= maxFilters {
+			return "", errTooManyFilters
+		}
+	}
+
+	id := rpc.NewID()
+	f.unsubscribe = subscribe(f)
+	if timeout := m.params.Filters.FilterTimeout; timeout > 0 {
+		f.timer = time.AfterFunc(timeout, func() {
+			m.Uninstall(id)
+		})
+	}
+	m.filters[id] = f
+	return id, nil
+}
+
+// NewLogsFilter installs a filter that accumulates the logs matching the query.
+func (m *FilterManager) NewLogsFilter(ctx context.Context, q *ethereum.FilterQuery) (rpc.ID, error) {
+	if len(q.Topics) > maxLogsTopics {
+		return "", errors.New("too many topics in filter")
+	}
+	f := &filter{kind: logsFilter, query: *q}
+	return m.install(ctx, f, func(f *filter) func() {
+		return m.evmChain.hookLogs(&f.query, func(logs []*types.Log) {
+			f.appendLogs(logs, m.params.Logs.MaxLogsInResult)
+		})
+	})
+}
+
+// NewBlocksFilter installs a filter that accumulates the hashes of new blocks.
+func (m *FilterManager) NewBlocksFilter(ctx context.Context) (rpc.ID, error) {
+	f := &filter{kind: blocksFilter}
+	return m.install(ctx, f, func(f *filter) func() {
+		return m.evmChain.hookNewHeads(func(h *types.Header) {
+			f.appendHash(h.Hash(), m.params.Logs.MaxLogsInResult)
+		})
+	})
+}
+
+// NewPendingTransactionsFilter installs a filter that accumulates the hashes
+// of the transactions sent to the chain through this server.
+func (m *FilterManager) NewPendingTransactionsFilter(ctx context.Context) (rpc.ID, error) {
+	f := &filter{kind: pendingTransactionsFilter}
+	return m.install(ctx, f, func(f *filter) func() {
+		return m.evmChain.hookPendingTransactions(func(h common.Hash) {
+			f.appendHash(h, m.params.Logs.MaxLogsInResult)
+		})
+	})
+}
+
+// Uninstall removes the filter, returning false if it does not exist.
+func (m *FilterManager) Uninstall(id rpc.ID) bool {
+	m.mu.Lock()
+	f, ok := m.filters[id]
+	delete(m.filters, id)
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	f.unsubscribe()
+	return true
+}
+
+// UninstallWebsocketFilters removes all the filters installed through the
+// websocket connection with the given remote address. It must be called when
+// the connection is closed, as its filters cannot be polled anymore.
+func (m *FilterManager) UninstallWebsocketFilters(remoteAddr string) {
+	client := websocketFilterClient(remoteAddr)
+	m.mu.Lock()
+	var ids []rpc.ID
+	for id, f := range m.filters {
+		if f.client == client {
+			ids = append(ids, id)
+		}
+	}
+	m.mu.Unlock()
+	for _, id := range ids {
+		m.Uninstall(id)
+	}
+}
+
+func (m *FilterManager) get(id rpc.ID) (*filter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.filters[id]
+	if !ok {
+		return nil, errFilterNotFound
+	}
+	if f.timer != nil {
+		f.timer.Reset(m.params.Filters.FilterTimeout)
+	}
+	return f, nil
+}
+
+// Changes returns the logs or hashes accumulated by the filter since the
+// last poll.
+func (m *FilterManager) Changes(id rpc.ID) (any, error) {
+	f, err := m.get(id)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.kind == logsFilter {
+		logs := f.logs
+		f.logs = nil
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+		return logs, nil
+	}
+	hashes := f.hashes
+	f.hashes = nil
+	if hashes == nil {
+		hashes = []common.Hash{}
+	}
+	return hashes, nil
+}
+
+// Query returns the query of a logs filter.
+func (m *FilterManager) Query(id rpc.ID) (*ethereum.FilterQuery, error) {
+	f, err := m.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if f.kind != logsFilter {
+		return nil, errFilterKindMismatch
+	}
+	q := f.query
+	return &q, nil
+}
+
+// appendLogs accumulates logs, keeping at most maxLen of the most recent ones.
+func (f *filter) appendLogs(logs []*types.Log, maxLen int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, logs...)
+	if maxLen > 0 && len(f.logs) > maxLen {
+		f.logs = f.logs[len(f.logs)-maxLen:]
+	}
+}
+
+// appendHash accumulates hashes, keeping at most maxLen of the most recent ones.
+func (f *filter) appendHash(h common.Hash, maxLen int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hashes = append(f.hashes, h)
+	if maxLen > 0 && len(f.hashes) > maxLen {
+		f.hashes = f.hashes[len(f.hashes)-maxLen:]
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -75,6 +76,7 @@ func newSoloTestEnv(t testing.TB) *soloTestEnv {
 		accounts,
 		metrics.NewChainWebAPIMetricsProvider().CreateForChain(chain.ChainID),
 		jsonrpc.ParametersDefault(),
+		nil,
 	)
 	require.NoError(t, err)
 	t.Cleanup(rpcsrv.Stop)
@@ -1364,3 +1366,231 @@ func TestSupportsInterfaceRPCEthCall(t *testing.T) {
 		})
 	}
 }
+
+func TestRPCGetProof(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, _ := env.deployStorageContract(creator)
+
+	l1Commitment := env.soloChain.GetL1Commitment()
+	slots := []common.Hash{{}, common.HexToHash("0x1")}
+
+	for _, addr := range []common.Address{creatorAddress, contractAddress} {
+		var proof evmutil.AccountProof
+		err := env.RawClient.Call(&proof, "eth_getProof", addr, []string{slots[0].Hex(), slots[1].Hex()}, "latest")
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(l1Commitment, jsonrpc.AccountProofKeys(addr, slots)))
+
+		// proofs of an account cannot be passed off as proofs of another one
+		_, otherAddress := solo.NewEthereumAccount()
+		require.Error(t, proof.Verify(l1Commitment, jsonrpc.AccountProofKeys(otherAddress, slots)))
+
+		// tampering with the values is detected
+		proof.Nonce++
+		require.Error(t, proof.Verify(l1Commitment, jsonrpc.AccountProofKeys(addr, slots)))
+	}
+
+	var proof evmutil.AccountProof
+	err := env.RawClient.Call(&proof, "eth_getProof", contractAddress, []string{slots[0].Hex()}, "latest")
+	require.NoError(t, err)
+	require.EqualValues(t, 42, (*big.Int)(proof.StorageProof[0].Value).Uint64())
+	require.NotEmpty(t, proof.Code)
+}
+
+func TestRPCFeeHistory(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	env.deployStorageContract(creator)
+
+	gasPrice := env.MustGetGasPrice()
+
+	history, err := env.Client.FeeHistory(context.Background(), 10, nil, []float64{10, 50, 90})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, history.OldestBlock.Uint64())
+	require.Len(t, history.GasUsedRatio, 3) // blocks 0, 1, 2
+	require.Len(t, history.BaseFee, 4)
+	require.Len(t, history.Reward, 3)
+	for _, baseFee := range history.BaseFee {
+		require.Equal(t, gasPrice, baseFee)
+	}
+	require.NotZero(t, history.GasUsedRatio[2])
+	for _, reward := range history.Reward {
+		require.Len(t, reward, 3)
+		for _, r := range reward {
+			require.Zero(t, r.Sign())
+		}
+	}
+
+	_, err = env.Client.FeeHistory(context.Background(), 10, nil, []float64{50, 10})
+	require.Error(t, err)
+
+	tip, err := env.Client.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, tip.Sign())
+}
+
+func TestRPCCreateAccessList(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, contractABI := env.deployStorageContract(creator)
+
+	createAccessList := func(method string, args ...any) *jsonrpc.RPCAccessListResult {
+		var res jsonrpc.RPCAccessListResult
+		err := env.RawClient.Call(&res, "eth_createAccessList", map[string]any{
+			"from":  creatorAddress,
+			"to":    contractAddress,
+			"input": hexutil.Bytes(lo.Must(contractABI.Pack(method, args...))),
+		}, "latest")
+		require.NoError(t, err)
+		return &res
+	}
+
+	res := createAccessList("retrieve")
+	require.Empty(t, res.Error)
+	require.NotZero(t, res.GasUsed)
+	require.Len(t, *res.AccessList, 1)
+	require.Equal(t, contractAddress, (*res.AccessList)[0].Address)
+	require.Equal(t, []common.Hash{{}}, (*res.AccessList)[0].StorageKeys)
+
+	res = createAccessList("store", uint32(43))
+	require.Empty(t, res.Error)
+	require.Len(t, *res.AccessList, 1)
+
+	// the state is not modified
+	var v uint32
+	ret, err := env.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From: creatorAddress,
+		To:   &contractAddress,
+		Data: lo.Must(contractABI.Pack("retrieve")),
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, contractABI.UnpackIntoInterface(&v, "retrieve", ret))
+	require.EqualValues(t, 42, v)
+}
+
+func TestRPCCallStateOverride(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, contractABI := env.deployStorageContract(creator)
+
+	args := map[string]any{
+		"from":  creatorAddress,
+		"to":    contractAddress,
+		"input": hexutil.Bytes(lo.Must(contractABI.Pack("retrieve"))),
+	}
+	overrides := map[string]any{
+		contractAddress.Hex(): map[string]any{
+			"stateDiff": map[string]any{
+				common.Hash{}.Hex(): common.BigToHash(big.NewInt(44)).Hex(),
+			},
+		},
+	}
+	retrieve := func(params ...any) uint32 {
+		var ret hexutil.Bytes
+		err := env.RawClient.Call(&ret, "eth_call", append([]any{args, "latest"}, params...)...)
+		require.NoError(t, err)
+		var v uint32
+		require.NoError(t, contractABI.UnpackIntoInterface(&v, "retrieve", ret))
+		return v
+	}
+
+	require.EqualValues(t, 44, retrieve(overrides))
+	// the state is not modified
+	require.EqualValues(t, 42, retrieve())
+
+	var gas hexutil.Uint64
+	err := env.RawClient.Call(&gas, "eth_estimateGas", args, "latest", overrides)
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+
+	// an account without funds can send value if its balance is overridden
+	_, poorAddress := solo.NewEthereumAccount()
+	transfer := map[string]any{
+		"from":  poorAddress,
+		"to":    creatorAddress,
+		"value": (*hexutil.Big)(big.NewInt(1_000_000_000_000)),
+	}
+	err = env.RawClient.Call(&gas, "eth_estimateGas", transfer, "latest")
+	require.Error(t, err)
+	err = env.RawClient.Call(&gas, "eth_estimateGas", transfer, "latest", map[string]any{
+		poorAddress.Hex(): map[string]any{
+			"balance": (*hexutil.Big)(big.NewInt(1_000_000_000_000_000_000)),
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+}
+
+func TestRPCSimulateV1(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, contractABI := env.deployStorageContract(creator)
+
+	call := func(method string, args ...any) map[string]any {
+		return map[string]any{
+			"from":  creatorAddress,
+			"to":    contractAddress,
+			"input": hexutil.Bytes(lo.Must(contractABI.Pack(method, args...))),
+		}
+	}
+	retrieved := func(c jsonrpc.RPCSimulatedCall) uint32 {
+		require.Nil(t, c.Error)
+		var v uint32
+		require.NoError(t, contractABI.UnpackIntoInterface(&v, "retrieve", c.ReturnData))
+		return v
+	}
+
+	var res []struct {
+		Number    hexutil.Uint64             `json:"number"`
+		Timestamp hexutil.Uint64             `json:"timestamp"`
+		Calls     []jsonrpc.RPCSimulatedCall `json:"calls"`
+	}
+	err := env.RawClient.Call(&res, "eth_simulateV1", map[string]any{
+		"blockStateCalls": []any{
+			map[string]any{
+				"calls": []any{
+					call("store", uint32(43)),
+					call("retrieve"),
+				},
+			},
+			map[string]any{
+				"blockOverrides": map[string]any{
+					"time": hexutil.Uint64(time.Now().Add(time.Hour).Unix()),
+				},
+				"stateOverrides": map[string]any{
+					contractAddress.Hex(): map[string]any{
+						"stateDiff": map[string]any{
+							common.Hash{}.Hex(): common.BigToHash(big.NewInt(44)).Hex(),
+						},
+					},
+				},
+				"calls": []any{
+					call("retrieve"),
+				},
+			},
+		},
+	}, "latest")
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, res[0].Number+1, res[1].Number)
+	require.Greater(t, res[1].Timestamp, res[0].Timestamp)
+
+	require.Len(t, res[0].Calls, 2)
+	require.Nil(t, res[0].Calls[0].Error)
+	require.NotZero(t, res[0].Calls[0].GasUsed)
+	require.EqualValues(t, 43, retrieved(res[0].Calls[1]))
+
+	require.Len(t, res[1].Calls, 1)
+	require.EqualValues(t, 44, retrieved(res[1].Calls[0]))
+
+	// the state is not modified
+	var v uint32
+	ret, err := env.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From: creatorAddress,
+		To:   &contractAddress,
+		Data: lo.Must(contractABI.Pack("retrieve")),
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, contractABI.UnpackIntoInterface(&v, "retrieve", ret))
+	require.EqualValues(t, 42, v)
+}
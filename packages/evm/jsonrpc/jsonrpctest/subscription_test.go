@@ -5,6 +5,7 @@ package jsonrpctest
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -90,3 +91,61 @@ func TestSubscriptionLogs(t *testing.T) {
 		}
 	}
 }
+
+func TestFilters(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	creator, creatorAddress := env.NewAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(env.T, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+	var blockFilterID, logsFilterID, txFilterID string
+	require.NoError(t, env.RawClient.Call(&blockFilterID, "eth_newBlockFilter"))
+	require.NoError(t, env.RawClient.Call(&logsFilterID, "eth_newFilter", map[string]any{
+		"address": contractAddress,
+	}))
+	require.NoError(t, env.RawClient.Call(&txFilterID, "eth_newPendingTransactionFilter"))
+
+	tx, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Equal(t, 1, len(receipt.Logs))
+
+	var txHashes []common.Hash
+	require.NoError(t, env.RawClient.Call(&txHashes, "eth_getFilterChanges", txFilterID))
+	require.Equal(t, []common.Hash{tx.Hash()}, txHashes)
+
+	// new blocks are published asynchronously
+	var blockHashes []common.Hash
+	require.Eventually(t, func() bool {
+		var changes []common.Hash
+		require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", blockFilterID))
+		blockHashes = append(blockHashes, changes...)
+		return slices.Contains(blockHashes, receipt.BlockHash)
+	}, testmisc.GetTimeout(5*time.Second), 10*time.Millisecond)
+
+	var logs []types.Log
+	require.Eventually(t, func() bool {
+		var changes []types.Log
+		require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", logsFilterID))
+		logs = append(logs, changes...)
+		return len(logs) > 0
+	}, testmisc.GetTimeout(5*time.Second), 10*time.Millisecond)
+	require.Len(t, logs, 1)
+	require.Equal(t, contractAddress, logs[0].Address)
+
+	// changes are reset after each poll
+	var changes []types.Log
+	require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", logsFilterID))
+	require.Empty(t, changes)
+
+	// eth_getFilterLogs returns all matching logs
+	require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterLogs", logsFilterID))
+	require.Len(t, changes, 1)
+
+	var uninstalled bool
+	require.NoError(t, env.RawClient.Call(&uninstalled, "eth_uninstallFilter", logsFilterID))
+	require.True(t, uninstalled)
+	require.NoError(t, env.RawClient.Call(&uninstalled, "eth_uninstallFilter", logsFilterID))
+	require.False(t, uninstalled)
+	require.Error(t, env.RawClient.Call(&changes, "eth_getFilterChanges", logsFilterID))
+}
@@ -13,6 +13,8 @@ import (
 
 type Parameters struct {
 	Logs                                LogsLimits
+	Filters                             FiltersLimits
+	MaxBlocksInFeeHistory               int
 	WebsocketRateLimitMessagesPerSecond int
 	WebsocketRateLimitBurst             int
 	WebsocketRateLimitEnabled           bool
@@ -23,6 +25,9 @@ type Parameters struct {
 func NewParameters(
 	maxBlocksInLogsFilterRange int,
 	maxLogsInResult int,
+	maxFiltersPerClient int,
+	filterTimeout time.Duration,
+	maxBlocksInFeeHistory int,
 	websocketRateLimitMessagesPerSecond int,
 	websocketRateLimitBurst int,
 	websocketConnectionCleanupDuration time.Duration,
@@ -34,6 +39,11 @@ func NewParameters(
 			MaxBlocksInLogsFilterRange: maxBlocksInLogsFilterRange,
 			MaxLogsInResult:            maxLogsInResult,
 		},
+		Filters: FiltersLimits{
+			MaxFiltersPerClient: maxFiltersPerClient,
+			FilterTimeout:       filterTimeout,
+		},
+		MaxBlocksInFeeHistory:               maxBlocksInFeeHistory,
 		WebsocketRateLimitMessagesPerSecond: websocketRateLimitMessagesPerSecond,
 		WebsocketRateLimitBurst:             websocketRateLimitBurst,
 		WebsocketRateLimitEnabled:           websocketRateLimitEnabled,
@@ -48,6 +58,11 @@ func ParametersDefault() *Parameters {
 			MaxBlocksInLogsFilterRange: 1000,
 			MaxLogsInResult:            10000,
 		},
+		Filters: FiltersLimits{
+			MaxFiltersPerClient: 100,
+			FilterTimeout:       5 * time.Minute,
+		},
+		MaxBlocksInFeeHistory:               1024,
 		WebsocketRateLimitMessagesPerSecond: 20,
 		WebsocketRateLimitBurst:             5,
 		WebsocketRateLimitEnabled:           true,
@@ -68,11 +83,15 @@ func NewEngineAPI(evmChain *EVMChain, accountManager *AccountManager, metrics *m
 	return rpcsrv, nil
 }
 
+// NewServer creates the JSON-RPC server of the chain. filters keeps track of
+// the filters installed by the clients; if nil, a new [FilterManager] is
+// created.
 func NewServer(
 	evmChain *EVMChain,
 	accountManager *AccountManager,
 	metrics *metrics.ChainWebAPIMetrics,
 	params *Parameters,
+	filters *FilterManager,
 ) (*rpc.Server, error) {
 	chainID := evmChain.ChainID()
 	rpcsrv := rpc.NewServer()
@@ -83,9 +102,9 @@ func NewServer(
 	}{
 		{"web3", NewWeb3Service()},
 		{"net", NewNetService(int(chainID))},
-		{"eth", NewEthService(evmChain, accountManager, metrics, params)},
+		{"eth", NewEthService(evmChain, accountManager, metrics, params, filters)},
 		{"debug", NewDebugService(evmChain, metrics)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain, metrics)},
 		{"evm", NewEVMService(evmChain)},
 		{"trace", NewTraceService(evmChain, metrics)},
 	} {
@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
@@ -26,9 +27,9 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/iotaledger/wasp/v2/packages/evm/evmerrors"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/metrics"
-	"github.com/iotaledger/wasp/v2/packages/parameters"
 	vmerrors "github.com/iotaledger/wasp/v2/packages/vm/core/errors"
 )
 
@@ -42,19 +43,27 @@ type EthService struct {
 	accounts *AccountManager
 	metrics  *metrics.ChainWebAPIMetrics
 	params   *Parameters
+	filters  *FilterManager
 }
 
+// NewEthService creates the `eth_*` service. If filters is nil, a new
+// [FilterManager] is created.
 func NewEthService(
 	evmChain *EVMChain,
 	accounts *AccountManager,
 	metrics *metrics.ChainWebAPIMetrics,
 	params *Parameters,
+	filters *FilterManager,
 ) *EthService {
+	if filters == nil {
+		filters = NewFilterManager(evmChain, params)
+	}
 	return &EthService{
 		evmChain: evmChain,
 		accounts: accounts,
 		metrics:  metrics,
 		params:   params,
+		filters:  filters,
 	}
 }
 
@@ -212,12 +221,11 @@ func (e *EthService) GetTransactionReceipt(txHash common.Hash) (map[string]any,
 		if err != nil {
 			return nil, err
 		}
-		effectiveGasPrice := tx.GasPrice()
-		if effectiveGasPrice.Sign() == 0 && !feePolicy.GasPerToken.IsEmpty() {
-			// tx sent before gasPrice was mandatory
-			effectiveGasPrice = feePolicy.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
+		feeMultiplier, err := e.evmChain.FeeMultiplier(blockNumber, txHash)
+		if err != nil {
+			return nil, e.resolveError(err)
 		}
-		return RPCMarshalReceipt(r, tx, effectiveGasPrice), nil
+		return RPCMarshalReceipt(r, tx, effectiveGasPrice(tx, feePolicy, feeMultiplier)), nil
 	})
 }
 
@@ -234,20 +242,45 @@ func (e *EthService) SendRawTransaction(txBytes hexutil.Bytes) (common.Hash, err
 	})
 }
 
-func (e *EthService) Call(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+func (e *EthService) Call(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, overrides *RPCStateOverride) (hexutil.Bytes, error) {
 	return withMetrics(e.metrics, "eth_call", func() (hexutil.Bytes, error) {
-		ret, err := e.evmChain.CallContract(args.parse(), blockNumberOrHash)
+		ret, err := e.evmChain.CallContract(args.parse(), blockNumberOrHash, overrides.parse())
 		return ret, e.resolveError(err)
 	})
 }
 
-func (e *EthService) EstimateGas(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+func (e *EthService) EstimateGas(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, overrides *RPCStateOverride) (hexutil.Uint64, error) {
 	return withMetrics(e.metrics, "eth_estimateGas", func() (hexutil.Uint64, error) {
-		gas, err := e.evmChain.EstimateGas(args.parse(), blockNumberOrHash)
+		gas, err := e.evmChain.EstimateGas(args.parse(), blockNumberOrHash, overrides.parse())
 		return hexutil.Uint64(gas), e.resolveError(err)
 	})
 }
 
+func (e *EthService) CreateAccessList(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash) (*RPCAccessListResult, error) {
+	return withMetrics(e.metrics, "eth_createAccessList", func() (*RPCAccessListResult, error) {
+		ret, err := e.evmChain.CreateAccessList(args.parse(), blockNumberOrHash)
+		return ret, e.resolveError(err)
+	})
+}
+
+func (e *EthService) SimulateV1(opts *RPCSimulateOpts, blockNumberOrHash *rpc.BlockNumberOrHash) ([]map[string]any, error) {
+	return withMetrics(e.metrics, "eth_simulateV1", func() ([]map[string]any, error) {
+		blocks, err := opts.parse()
+		if err != nil {
+			return nil, err
+		}
+		simulated, err := e.evmChain.SimulateV1(blocks, blockNumberOrHash)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		ret := make([]map[string]any, len(simulated))
+		for i, block := range simulated {
+			ret[i] = RPCMarshalSimulatedBlock(block, e.resolveError)
+		}
+		return ret, nil
+	})
+}
+
 func (e *EthService) GetStorageAt(address common.Address, key string, blockNumberOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
 	return withMetrics(e.metrics, "eth_getStorageAt", func() (hexutil.Bytes, error) {
 		ret, err := e.evmChain.StorageAt(address, common.HexToHash(key), blockNumberOrHash)
@@ -466,6 +499,14 @@ func (e *EthService) GetBlockReceipts(blockNumber rpc.BlockNumberOrHash) ([]map[
 			return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(receipts), len(txs))
 		}
 
+		var feeMultipliers map[isc.RequestID]*uint32
+		if len(receipts) > 0 {
+			feeMultipliers, err = e.evmChain.FeeMultipliers(receipts[0].BlockNumber.Uint64())
+			if err != nil {
+				return nil, e.resolveError(err)
+			}
+		}
+
 		result := make([]map[string]any, len(receipts))
 		for i, receipt := range receipts {
 			// This is pretty ugly, maybe we should shift to uint64 for internals too.
@@ -478,33 +519,115 @@ func (e *EthService) GetBlockReceipts(blockNumber rpc.BlockNumberOrHash) ([]map[
 				return nil, err
 			}
 
-			effectiveGasPrice := txs[i].GasPrice()
-			if effectiveGasPrice.Sign() == 0 && !feePolicy.GasPerToken.IsEmpty() {
-				// tx sent before gasPrice was mandatory
-				effectiveGasPrice = feePolicy.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
+			feeMultiplier := feeMultipliers[isc.RequestIDFromEVMTxHash(txs[i].Hash())]
+			result[i] = RPCMarshalReceipt(receipt, txs[i], effectiveGasPrice(txs[i], feePolicy, feeMultiplier))
+		}
+
+		return result, nil
+	})
+}
+
+// GetProof implements eth_getProof. Since the ISC state is not a
+// Merkle-Patricia trie, the proof format differs from EIP-1186; see
+// [evmutil.AccountProof].
+func (e *EthService) GetProof(address common.Address, storageKeys []string, blockNumberOrHash *rpc.BlockNumberOrHash) (*evmutil.AccountProof, error) {
+	return withMetrics(e.metrics, "eth_getProof", func() (*evmutil.AccountProof, error) {
+		keys := make([]common.Hash, len(storageKeys))
+		for i, k := range storageKeys {
+			key, err := decodeStorageKey(k)
+			if err != nil {
+				return nil, &invalidParamsError{fmt.Sprintf("%v: %q", err, k)}
 			}
+			keys[i] = key
+		}
+		proof, err := e.evmChain.Proof(address, keys, blockNumberOrHash)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return proof, nil
+	})
+}
 
-			result[i] = RPCMarshalReceipt(receipt, txs[i], effectiveGasPrice)
+func (e *EthService) NewFilter(ctx context.Context, q *RPCFilterQuery) (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newFilter", func() (rpc.ID, error) {
+		if q == nil {
+			q = &RPCFilterQuery{}
 		}
+		return e.filters.NewLogsFilter(ctx, (*ethereum.FilterQuery)(q))
+	})
+}
 
-		return result, nil
+func (e *EthService) NewBlockFilter(ctx context.Context) (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newBlockFilter", func() (rpc.ID, error) {
+		return e.filters.NewBlocksFilter(ctx)
+	})
+}
+
+func (e *EthService) NewPendingTransactionFilter(ctx context.Context) (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newPendingTransactionFilter", func() (rpc.ID, error) {
+		return e.filters.NewPendingTransactionsFilter(ctx)
+	})
+}
+
+// GetFilterChanges returns the logs (for filters created with eth_newFilter)
+// or hashes (for filters created with eth_newBlockFilter and
+// eth_newPendingTransactionFilter) received since the last poll.
+func (e *EthService) GetFilterChanges(id rpc.ID) (any, error) {
+	return withMetrics(e.metrics, "eth_getFilterChanges", func() (any, error) {
+		return e.filters.Changes(id)
+	})
+}
+
+// GetFilterLogs returns all logs matching the query of a filter created with
+// eth_newFilter.
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return withMetrics(e.metrics, "eth_getFilterLogs", func() ([]*types.Log, error) {
+		q, err := e.filters.Query(id)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := e.evmChain.Logs(q, &e.params.Logs)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return logs, nil
+	})
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) (bool, error) {
+	return withMetrics(e.metrics, "eth_uninstallFilter", func() (bool, error) {
+		return e.filters.Uninstall(id), nil
+	})
+}
+
+// FeeHistory implements eth_feeHistory. See [EVMChain.FeeHistory].
+func (e *EthService) FeeHistory(blockCount math.HexOrDecimal64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*RPCFeeHistory, error) {
+	return withMetrics(e.metrics, "eth_feeHistory", func() (*RPCFeeHistory, error) {
+		maxBlockCount, err := safecast.Convert[uint64](e.params.MaxBlocksInFeeHistory)
+		if err != nil {
+			return nil, err
+		}
+		ret, err := e.evmChain.FeeHistory(uint64(blockCount), newestBlock, rewardPercentiles, maxBlockCount)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return ret, nil
+	})
+}
+
+func (e *EthService) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	return withMetrics(e.metrics, "eth_maxPriorityFeePerGas", func() (*hexutil.Big, error) {
+		tip, err := e.evmChain.MaxPriorityFeePerGas()
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return (*hexutil.Big)(tip), nil
 	})
 }
 
 /*
 Not implemented:
 func (e *EthService) BlobBaseFee()
-func (e *EthService) CreateAccessList()
-func (e *EthService) FeeHistory()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
-func (e *EthService) GetProof()
-func (e *EthService) MaxPriorityFeePerGas()
-func (e *EthService) NewBlockFilter()
-func (e *EthService) NewFilter()
-func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) SimulateV1()
-func (e *EthService) UninstallFilter()
 */
 
 type NetService struct {
@@ -538,31 +661,81 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
-
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+type TxPoolService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+func NewTxPoolService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *TxPoolService {
+	return &TxPoolService{
+		evmChain: evmChain,
+		metrics:  metrics,
 	}
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
-	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
-	}
+func (s *TxPoolService) Content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(s.metrics, "txpool_content", func() (map[string]map[string]map[string]*RPCTransaction, error) {
+		content, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		format := func(txs map[common.Address]map[uint64]*types.Transaction) map[string]map[string]*RPCTransaction {
+			ret := make(map[string]map[string]*RPCTransaction)
+			for sender, txsByNonce := range txs {
+				ret[sender.Hex()] = make(map[string]*RPCTransaction)
+				for nonce, tx := range txsByNonce {
+					ret[sender.Hex()][strconv.FormatUint(nonce, 10)] = newRPCTransaction(tx, common.Hash{}, 0, 0)
+				}
+			}
+			return ret
+		}
+		return map[string]map[string]map[string]*RPCTransaction{
+			"pending": format(content.Pending),
+			"queued":  format(content.Queued),
+		}, nil
+	})
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
-	}
+func (s *TxPoolService) Inspect() (map[string]map[string]map[string]string, error) {
+	return withMetrics(s.metrics, "txpool_inspect", func() (map[string]map[string]map[string]string, error) {
+		content, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		format := func(txs map[common.Address]map[uint64]*types.Transaction) map[string]map[string]string {
+			ret := make(map[string]map[string]string)
+			for sender, txsByNonce := range txs {
+				ret[sender.Hex()] = make(map[string]string)
+				for nonce, tx := range txsByNonce {
+					ret[sender.Hex()][strconv.FormatUint(nonce, 10)] = txPoolInspectSummary(tx)
+				}
+			}
+			return ret
+		}
+		return map[string]map[string]map[string]string{
+			"pending": format(content.Pending),
+			"queued":  format(content.Queued),
+		}, nil
+	})
+}
+
+func (s *TxPoolService) Status() (map[string]hexutil.Uint, error) {
+	return withMetrics(s.metrics, "txpool_status", func() (map[string]hexutil.Uint, error) {
+		content, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		count := func(txs map[common.Address]map[uint64]*types.Transaction) (n hexutil.Uint) {
+			for _, txsByNonce := range txs {
+				n += hexutil.Uint(len(txsByNonce))
+			}
+			return n
+		}
+		return map[string]hexutil.Uint{
+			"pending": count(content.Pending),
+			"queued":  count(content.Queued),
+		}, nil
+	})
 }
 
 type DebugService struct {
@@ -0,0 +1,88 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/emulator"
+)
+
+// TxPoolContent contains the EVM transactions waiting in the mempool, grouped
+// by sender and nonce.
+type TxPoolContent struct {
+	// Pending contains the transactions that can be executed, i.e. the ones
+	// following the current nonce of the sender.
+	Pending map[common.Address]map[uint64]*types.Transaction
+	// Queued contains the transactions that cannot be executed until a gap in
+	// the nonces of the sender is filled.
+	Queued map[common.Address]map[uint64]*types.Transaction
+}
+
+// TxPoolContent returns the EVM transactions waiting in the mempool.
+func (e *EVMChain) TxPoolContent() (*TxPoolContent, error) {
+	e.log.LogDebugf("TxPoolContent()")
+	_, latestState, err := e.backend.ISCLatestState()
+	if err != nil {
+		return nil, err
+	}
+	return newTxPoolContent(e.backend.EVMPendingTransactions(), func(sender common.Address) uint64 {
+		return emulator.GetNonce(stateDBSubrealmR(latestState), sender)
+	}), nil
+}
+
+// newTxPoolContent splits the transactions of each sender into pending and
+// queued, given the current nonce of the senders.
+func newTxPoolContent(txs []*types.Transaction, nonceOf func(sender common.Address) uint64) *TxPoolContent {
+	txsBySender := map[common.Address][]*types.Transaction{}
+	for _, tx := range txs {
+		sender, err := evmutil.GetSender(tx)
+		if err != nil {
+			continue
+		}
+		txsBySender[sender] = append(txsBySender[sender], tx)
+	}
+
+	ret := &TxPoolContent{
+		Pending: map[common.Address]map[uint64]*types.Transaction{},
+		Queued:  map[common.Address]map[uint64]*types.Transaction{},
+	}
+	add := func(m map[common.Address]map[uint64]*types.Transaction, sender common.Address, tx *types.Transaction) {
+		if m[sender] == nil {
+			m[sender] = map[uint64]*types.Transaction{}
+		}
+		m[sender][tx.Nonce()] = tx
+	}
+	for sender, txs := range txsBySender {
+		slices.SortFunc(txs, func(a, b *types.Transaction) int { return cmp.Compare(a.Nonce(), b.Nonce()) })
+		nonce := nonceOf(sender)
+		for _, tx := range txs {
+			switch {
+			case tx.Nonce() < nonce:
+				// already processed, not yet removed from the mempool
+			case tx.Nonce() == nonce:
+				add(ret.Pending, sender, tx)
+				nonce++
+			default:
+				add(ret.Queued, sender, tx)
+			}
+		}
+	}
+	return ret
+}
+
+// txPoolInspectSummary returns the summary of the transaction shown by
+// txpool_inspect, in the same format as go-ethereum.
+func txPoolInspectSummary(tx *types.Transaction) string {
+	if to := tx.To(); to != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+}
@@ -0,0 +1,65 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+)
+
+func TestTxPoolContent(t *testing.T) {
+	chainID := big.NewInt(1074)
+	newTx := func(nonce uint64) (*types.Transaction, common.Address) {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		return signTestTx(t, key, chainID, nonce), crypto.PubkeyToAddress(key.PublicKey)
+	}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	// nonce 4 was already processed, 5 and 6 follow the current nonce,
+	// 8 cannot be executed until 7 arrives
+	txs := []*types.Transaction{
+		signTestTx(t, key, chainID, 8),
+		signTestTx(t, key, chainID, 6),
+		signTestTx(t, key, chainID, 4),
+		signTestTx(t, key, chainID, 5),
+	}
+	otherTx, otherSender := newTx(3)
+	txs = append(txs, otherTx)
+
+	content := newTxPoolContent(txs, func(addr common.Address) uint64 {
+		if addr == sender {
+			return 5
+		}
+		return 0
+	})
+
+	require.Len(t, content.Pending, 1)
+	require.Len(t, content.Pending[sender], 2)
+	require.EqualValues(t, 5, content.Pending[sender][5].Nonce())
+	require.EqualValues(t, 6, content.Pending[sender][6].Nonce())
+
+	require.Len(t, content.Queued, 2)
+	require.Len(t, content.Queued[sender], 1)
+	require.EqualValues(t, 8, content.Queued[sender][8].Nonce())
+	require.Len(t, content.Queued[otherSender], 1)
+	require.Equal(t, otherTx.Hash(), content.Queued[otherSender][3].Hash())
+}
+
+func signTestTx(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, nonce uint64) *types.Transaction {
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(10), nil),
+		evmutil.Signer(chainID),
+		key,
+	)
+	require.NoError(t, err)
+	return tx
+}
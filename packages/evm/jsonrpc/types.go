@@ -5,11 +5,14 @@ package jsonrpc
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"slices"
+	"strings"
+	"time"
 
 	"fortio.org/safecast"
 
@@ -22,6 +25,7 @@ import (
 
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
@@ -165,6 +169,16 @@ func parseBlockNumber(bn rpc.BlockNumber) *big.Int {
 	return big.NewInt(n)
 }
 
+// effectiveGasPrice returns the gas price paid by the transaction, taking
+// into account the fee multiplier of the sender, if any
+func effectiveGasPrice(tx *types.Transaction, feePolicy *gas.FeePolicy, feeMultiplier *uint32) *big.Int {
+	gasPrice := evmutil.EffectiveGasPrice(tx, feePolicy)
+	if feeMultiplier != nil {
+		return evmutil.ApplyFeeMultiplier(gasPrice, *feeMultiplier)
+	}
+	return gasPrice
+}
+
 func RPCMarshalReceipt(r *types.Receipt, tx *types.Transaction, effectiveGasPrice *big.Int) map[string]any {
 	// fix for an already fixed bug where some old failed receipts contain non-empty logs
 	if r.Status != types.ReceiptStatusSuccessful {
@@ -224,8 +238,9 @@ type RPCCallArgs struct {
 	Value    *hexutil.Big    `json:"value"`
 	// We accept "data" and "input" for backwards-compatibility reasons. "input" is the
 	// newer name and should be preferred by clients.
-	Data  *hexutil.Bytes `json:"data"`
-	Input *hexutil.Bytes `json:"input"`
+	Data       *hexutil.Bytes    `json:"data"`
+	Input      *hexutil.Bytes    `json:"input"`
+	AccessList *types.AccessList `json:"accessList"`
 }
 
 func (c *RPCCallArgs) parse() (ret ethereum.CallMsg) {
@@ -242,9 +257,160 @@ func (c *RPCCallArgs) parse() (ret ethereum.CallMsg) {
 	if c.Input != nil {
 		ret.Data = *c.Input
 	}
+	if c.AccessList != nil {
+		ret.AccessList = *c.AccessList
+	}
 	return
 }
 
+// RPCAccessListResult is the result of eth_createAccessList
+type RPCAccessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// RPCAccountOverride contains the fields of an account that are replaced
+// before executing a call.
+type RPCAccountOverride struct {
+	Nonce     *hexutil.Uint64             `json:"nonce"`
+	Code      *hexutil.Bytes              `json:"code"`
+	Balance   *hexutil.Big                `json:"balance"`
+	State     map[common.Hash]common.Hash `json:"state"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// RPCStateOverride is the set of account overrides, by address.
+type RPCStateOverride map[common.Address]RPCAccountOverride
+
+func (o *RPCStateOverride) parse() evmutil.StateOverride {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+	ret := make(evmutil.StateOverride, len(*o))
+	for addr, account := range *o {
+		override := &evmutil.AccountOverride{
+			Nonce:     (*uint64)(account.Nonce),
+			Balance:   (*big.Int)(account.Balance),
+			State:     account.State,
+			StateDiff: account.StateDiff,
+		}
+		if account.Code != nil {
+			override.Code = append([]byte{}, *account.Code...)
+		}
+		ret[addr] = override
+	}
+	return ret
+}
+
+// RPCSimulateOpts are the arguments of eth_simulateV1
+type RPCSimulateOpts struct {
+	BlockStateCalls []RPCSimulateBlock `json:"blockStateCalls"`
+	Validation      bool               `json:"validation"`
+	TraceTransfers  bool               `json:"traceTransfers"`
+}
+
+// RPCSimulateBlock is a virtual block to be simulated by eth_simulateV1
+type RPCSimulateBlock struct {
+	BlockOverrides *RPCBlockOverrides `json:"blockOverrides"`
+	StateOverrides RPCStateOverride   `json:"stateOverrides"`
+	Calls          []RPCCallArgs      `json:"calls"`
+}
+
+// RPCBlockOverrides contains the block fields that can be overridden in
+// eth_simulateV1. Only the timestamp is supported.
+type RPCBlockOverrides struct {
+	Time *hexutil.Uint64 `json:"time"`
+}
+
+func (o *RPCSimulateOpts) parse() ([]*evmutil.SimulateBlock, error) {
+	if o.Validation {
+		return nil, errors.New("validation mode is not supported")
+	}
+	if o.TraceTransfers {
+		return nil, errors.New("traceTransfers is not supported")
+	}
+	ret := make([]*evmutil.SimulateBlock, len(o.BlockStateCalls))
+	for i, b := range o.BlockStateCalls {
+		block := &evmutil.SimulateBlock{
+			StateOverride: b.StateOverrides.parse(),
+			Calls:         make([]ethereum.CallMsg, len(b.Calls)),
+		}
+		if b.BlockOverrides != nil && b.BlockOverrides.Time != nil {
+			t, err := safecast.Convert[int64](uint64(*b.BlockOverrides.Time))
+			if err != nil {
+				return nil, err
+			}
+			block.Time = time.Unix(t, 0)
+		}
+		for j := range b.Calls {
+			block.Calls[j] = b.Calls[j].parse()
+		}
+		ret[i] = block
+	}
+	return ret, nil
+}
+
+// RPCSimulateCallError is the error returned by a simulated call
+type RPCSimulateCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// RPCSimulatedCall is the result of a call simulated by eth_simulateV1
+type RPCSimulatedCall struct {
+	ReturnData hexutil.Bytes         `json:"returnData"`
+	Logs       []*types.Log          `json:"logs"`
+	GasUsed    hexutil.Uint64        `json:"gasUsed"`
+	Status     hexutil.Uint64        `json:"status"`
+	Error      *RPCSimulateCallError `json:"error,omitempty"`
+}
+
+// simulateVMErrorCode is the JSON error code for a simulated call that
+// failed for a reason other than a revert
+const simulateVMErrorCode = -32015
+
+// RPCMarshalSimulatedBlock converts the given simulated block to the RPC
+// output. resolveError is used to convert the errors of the failed calls.
+func RPCMarshalSimulatedBlock(block *evmutil.SimulatedBlock, resolveError func(error) error) map[string]any {
+	fields := RPCMarshalHeader(block.Header)
+	calls := make([]*RPCSimulatedCall, len(block.Calls))
+	var gasUsed uint64
+	for i, call := range block.Calls {
+		gasUsed += call.GasUsed
+		c := &RPCSimulatedCall{
+			ReturnData: call.ReturnData,
+			Logs:       call.Logs,
+			GasUsed:    hexutil.Uint64(call.GasUsed),
+			Status:     hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+		if c.Logs == nil {
+			c.Logs = []*types.Log{}
+		}
+		if call.Error != nil {
+			c.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			err := resolveError(call.Error)
+			c.Error = &RPCSimulateCallError{
+				Code:    simulateVMErrorCode,
+				Message: err.Error(),
+			}
+			var revertErr *revertError
+			if errors.As(err, &revertErr) {
+				c.Error.Code = revertErr.ErrorCode()
+				c.Error.Data = revertErr.reason
+			}
+		}
+		calls[i] = c
+	}
+	fields["gasUsed"] = hexutil.Uint64(gasUsed)
+	if block.Header.BaseFee != nil {
+		fields["baseFeePerGas"] = (*hexutil.Big)(block.Header.BaseFee)
+	}
+	fields["calls"] = calls
+	return fields
+}
+
 // SendTxArgs represents the arguments to submit a new transaction into the transaction pool.
 type SendTxArgs struct {
 	From     common.Address  `json:"from"`
@@ -429,6 +595,37 @@ func decodeTopic(s string) (common.Hash, error) {
 	return common.BytesToHash(b), err
 }
 
+// decodeStorageKey decodes a storage slot key of eth_getProof. As in
+// go-ethereum, the key may be shorter than 32 bytes, and the 0x prefix is
+// optional.
+func decodeStorageKey(s string) (common.Hash, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return common.Hash{}, errors.New("hex string invalid")
+	}
+	if len(b) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("hex string too long, want at most %d bytes", common.HashLength)
+	}
+	return common.BytesToHash(b), nil
+}
+
+type invalidParamsError struct {
+	message string
+}
+
+func (e *invalidParamsError) Error() string {
+	return e.message
+}
+
+// ErrorCode returns the JSON error code for invalid parameters.
+func (e *invalidParamsError) ErrorCode() int {
+	return -32602
+}
+
 type revertError struct {
 	error
 	reason string // revert reason hex encoded
@@ -0,0 +1,46 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
+)
+
+func TestEffectiveGasPriceWithFeeMultiplier(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := signTestTx(t, key, big.NewInt(1074), 0)
+	feePolicy := gas.DefaultFeePolicy()
+	gasPrice := evmutil.EffectiveGasPrice(tx, feePolicy)
+
+	require.Equal(t, gasPrice, effectiveGasPrice(tx, feePolicy, nil))
+	require.Equal(t, gasPrice, effectiveGasPrice(tx, feePolicy, lo.ToPtr(uint32(gas.GasPriceMultiplierBase))))
+	require.Equal(t, new(big.Int).Div(gasPrice, big.NewInt(2)), effectiveGasPrice(tx, feePolicy, lo.ToPtr(uint32(gas.GasPriceMultiplierBase/2))))
+}
+
+func TestDecodeStorageKey(t *testing.T) {
+	key, err := decodeStorageKey("0x01")
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(1)), key)
+
+	key, err = decodeStorageKey("0x0")
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, key)
+
+	full := common.HexToHash("0xdeadbeef00000000000000000000000000000000000000000000000000000001")
+	key, err = decodeStorageKey(full.Hex()[2:])
+	require.NoError(t, err)
+	require.Equal(t, full, key)
+
+	_, err = decodeStorageKey("0xzz")
+	require.Error(t, err)
+	_, err = decodeStorageKey(full.Hex() + "00")
+	require.Error(t, err)
+}
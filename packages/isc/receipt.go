@@ -20,6 +20,11 @@ type Receipt struct {
 	RequestIndex  uint16             `json:"requestIndex"`
 	ResolvedError string             `json:"resolvedError"`
 	GasBurnLog    *gas.BurnLog       `json:"-"`
+	// Sponsor is the account that paid the gas fee instead of the sender, if any
+	Sponsor AgentID `json:"sponsor,omitempty"`
+	// FeeMultiplier is the discount applied to the gas fee of the sender, in
+	// basis points, if the sender has one
+	FeeMultiplier *uint32 `json:"feeMultiplier,omitempty"`
 }
 
 func (r Receipt) DeserializedRequest() Request {
@@ -36,6 +41,12 @@ func (r Receipt) String() string {
 	ret += fmt.Sprintf("Block/Request index: %d / %d\n", r.BlockIndex, r.RequestIndex)
 	ret += fmt.Sprintf("Gas budget / burned / fee charged: %d / %d /%d\n", r.GasBudget, r.GasBurned, r.GasFeeCharged)
 	ret += fmt.Sprintf("Storage deposit charged: %d\n", r.SDCharged)
+	if r.Sponsor != nil {
+		ret += fmt.Sprintf("Sponsor: %s\n", r.Sponsor)
+	}
+	if r.FeeMultiplier != nil {
+		ret += fmt.Sprintf("Fee multiplier: %d\n", *r.FeeMultiplier)
+	}
 	ret += fmt.Sprintf("Call data: %s\n", hex.EncodeToString(r.Request))
 	return ret
 }
@@ -24,7 +24,7 @@ type Request interface {
 }
 
 func init() {
-	bcs.RegisterEnumType5[Request, *OnLedgerRequestData, *OffLedgerRequestData, *evmOffLedgerTxRequest, *evmOffLedgerCallRequest, *ImpersonatedOffLedgerRequestData]()
+	bcs.RegisterEnumType7[Request, *OnLedgerRequestData, *OffLedgerRequestData, *evmOffLedgerTxRequest, *evmOffLedgerCallRequest, *ImpersonatedOffLedgerRequestData, *ScheduledRequest, *SponsoredOffLedgerRequestData]()
 }
 
 func EVMCallDataFromTx(tx *types.Transaction) *ethereum.CallMsg {
@@ -36,6 +36,15 @@ func NewEVMOffLedgerTxRequest(chainID ChainID, tx *types.Transaction) (OffLedger
 	}, nil
 }
 
+// EVMTransaction returns the EVM transaction wrapped by the request, or nil if
+// the request is not an EVM transaction.
+func EVMTransaction(req Request) *types.Transaction {
+	if evmReq, ok := req.(*evmOffLedgerTxRequest); ok {
+		return evmReq.tx
+	}
+	return nil
+}
+
 func (req *evmOffLedgerTxRequest) BCSInit() error {
 	// derive req.sender from req.tx
 	sender, err := evmutil.GetSender(req.tx)
@@ -0,0 +1,91 @@
+package isc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+	"golang.org/x/crypto/blake2b"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
+	"github.com/iotaledger/wasp/v2/packages/hashing"
+)
+
+// SponsoredOffLedgerRequestData is an off-ledger request countersigned by a
+// sponsor. If the sponsor has approved the sender, the gas fee is charged to
+// the sponsor's account instead of the sender's.
+type SponsoredOffLedgerRequestData struct {
+	OffLedgerRequestData
+
+	sponsorSignature *cryptolib.Signature `bcs:"export"`
+}
+
+var (
+	_ Request          = new(SponsoredOffLedgerRequestData)
+	_ OffLedgerRequest = new(SponsoredOffLedgerRequestData)
+	_ Calldata         = new(SponsoredOffLedgerRequestData)
+)
+
+// NewSponsoredOffLedgerRequest countersigns a request signed by the sender
+func NewSponsoredOffLedgerRequest(req *OffLedgerRequestData, sponsor cryptolib.Signer) OffLedgerRequest {
+	return &SponsoredOffLedgerRequestData{
+		OffLedgerRequestData: *req,
+		sponsorSignature:     lo.Must(sponsor.Sign(req.sponsorMessageToSign())),
+	}
+}
+
+// sponsorMessageToSign is the message signed by the sponsor, which includes
+// the signature of the sender
+func (req *OffLedgerRequestData) sponsorMessageToSign() []byte {
+	ret := blake2b.Sum256(req.Bytes())
+	return ret[:]
+}
+
+func (req *SponsoredOffLedgerRequestData) Bytes() []byte {
+	var r Request = req
+	return bcs.MustMarshal(&r)
+}
+
+func (req *SponsoredOffLedgerRequestData) ID() RequestID {
+	return RequestID(hashing.HashData(req.Bytes()))
+}
+
+// Sponsor returns the account that pays the gas fee if it has approved the
+// sender
+func (req *SponsoredOffLedgerRequestData) Sponsor() AgentID {
+	return NewAddressAgentID(req.sponsorSignature.GetPublicKey().AsAddress())
+}
+
+func (req *SponsoredOffLedgerRequestData) String() string {
+	return fmt.Sprintf("sponsoredOffLedgerRequestData::{ ID: %s, sender: %s, sponsor: %s, target: %s, entrypoint: %s, Params: %s, nonce: %d }",
+		req.ID().String(),
+		req.SenderAccount().String(),
+		req.Sponsor().String(),
+		req.msg.Target.Contract.String(),
+		req.msg.Target.EntryPoint.String(),
+		req.msg.Params,
+		req.nonce,
+	)
+}
+
+// VerifySignature verifies the signatures of both the sender and the sponsor
+func (req *SponsoredOffLedgerRequestData) VerifySignature() error {
+	if err := req.OffLedgerRequestData.VerifySignature(); err != nil {
+		return err
+	}
+	if !req.sponsorSignature.Validate(req.OffLedgerRequestData.sponsorMessageToSign()) {
+		return errors.New("invalid sponsor signature")
+	}
+	return nil
+}
+
+// RequestSponsor returns the sponsor of the request, or nil if the request is
+// not sponsored
+func RequestSponsor(req Request) AgentID {
+	sponsored, ok := req.(*SponsoredOffLedgerRequestData)
+	if !ok {
+		return nil
+	}
+	return sponsored.Sponsor()
+}
@@ -3,6 +3,8 @@ package isc_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	bcs "github.com/iotaledger/bcs-go"
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
@@ -16,3 +18,21 @@ func TestOffLedgerRequestCodec(t *testing.T) {
 	offLedgerRequest = isc.NewOffLedgerRequest(isctest.TestChainID, isc.NewMessage(3, 14), 123, 200).Sign(cryptolib.TestKeyPair)
 	bcs.TestCodecAndHash(t, offLedgerRequest.(*isc.OffLedgerRequestData), "0b76ea31b34a")
 }
+
+func TestSponsoredOffLedgerRequest(t *testing.T) {
+	sender := cryptolib.NewKeyPair()
+	sponsor := cryptolib.NewKeyPair()
+	signed := isc.NewOffLedgerRequest(isctest.RandomChainID(), isc.NewMessage(3, 14), 123, 200).Sign(sender)
+	req := isc.NewSponsoredOffLedgerRequest(signed.(*isc.OffLedgerRequestData), sponsor)
+	bcs.TestCodec(t, isc.Request(req))
+
+	require.NoError(t, req.VerifySignature())
+	require.True(t, req.SenderAccount().Equals(isc.NewAddressAgentID(sender.Address())))
+	require.True(t, isc.RequestSponsor(req).Equals(isc.NewAddressAgentID(sponsor.Address())))
+	require.Nil(t, isc.RequestSponsor(signed))
+	require.NotEqual(t, signed.ID(), req.ID())
+
+	decoded, err := isc.RequestFromBytes(req.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, decoded.(isc.OffLedgerRequest).VerifySignature())
+}
@@ -0,0 +1,97 @@
+package isc
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/wasp/v2/packages/hashing"
+)
+
+// ScheduledRequest is the request executed by the VM at the start of a block
+// on behalf of a scheduled job. It is never sent to the chain: it only
+// exists in the receipts of the blocks where the job was executed.
+type ScheduledRequest struct {
+	chainID    ChainID `bcs:"export"`
+	jobID      uint32  `bcs:"export"`
+	blockIndex uint32  `bcs:"export"`
+	sender     AgentID `bcs:"export"`
+	msg        Message `bcs:"export"`
+	gasBudget  uint64  `bcs:"export"`
+}
+
+var (
+	_ Request  = new(ScheduledRequest)
+	_ Calldata = new(ScheduledRequest)
+)
+
+func NewScheduledRequest(chainID ChainID, jobID, blockIndex uint32, sender AgentID, msg Message, gasBudget uint64) *ScheduledRequest {
+	return &ScheduledRequest{
+		chainID:    chainID,
+		jobID:      jobID,
+		blockIndex: blockIndex,
+		sender:     sender,
+		msg:        msg,
+		gasBudget:  gasBudget,
+	}
+}
+
+func (req *ScheduledRequest) Allowance() (*Assets, error) {
+	return NewEmptyAssets(), nil
+}
+
+func (req *ScheduledRequest) Assets() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *ScheduledRequest) Bytes() []byte {
+	var r Request = req
+	return bcs.MustMarshal(&r)
+}
+
+func (req *ScheduledRequest) Message() Message {
+	return req.msg
+}
+
+func (req *ScheduledRequest) GasBudget() (gas uint64, isEVM bool) {
+	return req.gasBudget, false
+}
+
+func (req *ScheduledRequest) ID() RequestID {
+	return RequestID(hashing.HashData(req.Bytes()))
+}
+
+func (req *ScheduledRequest) IsOffLedger() bool {
+	return false
+}
+
+func (req *ScheduledRequest) SenderAccount() AgentID {
+	return req.sender
+}
+
+func (req *ScheduledRequest) EVMCallMsg() *ethereum.CallMsg {
+	return nil
+}
+
+// JobID returns the ID of the job that produced the request
+func (req *ScheduledRequest) JobID() uint32 {
+	return req.jobID
+}
+
+// BlockIndex returns the index of the block where the request is executed
+func (req *ScheduledRequest) BlockIndex() uint32 {
+	return req.blockIndex
+}
+
+func (req *ScheduledRequest) String() string {
+	return fmt.Sprintf("scheduledRequest::{ ID: %s, job: %d, block: %d, sender: %s, target: %s, entrypoint: %s, Params: %s }",
+		req.ID().String(),
+		req.jobID,
+		req.blockIndex,
+		req.sender.String(),
+		req.msg.Target.Contract.String(),
+		req.msg.Target.EntryPoint.String(),
+		req.msg.Params,
+	)
+}
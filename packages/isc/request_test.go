@@ -133,6 +133,14 @@ func TestRequestDataSerialization(t *testing.T) {
 		require.NoError(t, err)
 		bcs.TestCodecAndHash(t, isc.Request(req), "5e4b3106e265")
 	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		req := isc.NewScheduledRequest(isctest.RandomChainID(), 1, 42, isctest.NewRandomAgentID(), isc.NewMessage(3, 14), 100)
+		bcs.TestCodec(t, isc.Request(req))
+		rwutil.BytesTest(t, isc.Request(req), func(data []byte) (isc.Request, error) {
+			return bcs.Unmarshal[isc.Request](data)
+		})
+	})
 }
 
 func TestRequestIDSerialization(t *testing.T) {
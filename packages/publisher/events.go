@@ -46,6 +46,15 @@ type BlockWithTrieRoot struct {
 	TrieRoot  trie.Hash
 }
 
+// BlockEvents contains the events emitted by the requests of a block
+type BlockEvents struct {
+	BlockIndex uint32
+	Events     []*isc.Event
+	// Senders contains, for each event, the sender of the request that
+	// emitted it (nil if unknown)
+	Senders []isc.AgentID
+}
+
 type ReceiptWithError struct {
 	RequestReceipt *isc.Receipt
 	Error          *isc.VMError
@@ -70,34 +79,68 @@ func triggerEvent[T any](events *Events, event *event.Event1[*ISCEvent[T]], obj
 // PublishBlockEvents extracts the events from a block, its returns a chan of ISCEventType, so they can be filtered
 func PublishBlockEvents(blockApplied *blockApplied, events *Events, log log.Logger) {
 	block := blockApplied.block
-	chainID := blockApplied.chainID
-	//
+	blockEvents, err := ISCEventsForBlock(
+		blockApplied.chainID,
+		block.StateIndex(),
+		block.TrieRoot(),
+		blocklog.NewStateReaderFromBlockMutations(block),
+		errors.NewStateReaderFromChainState(blockApplied.latestState),
+		log,
+	)
+	if err != nil {
+		log.LogErrorf("%v", err)
+		return
+	}
+
 	// Publish notifications about the state change (new block).
-	blockIndex := block.StateIndex()
-	blocklogState := blocklog.NewStateReaderFromBlockMutations(block)
+	triggerEvent(events, events.NewBlock, blockEvents.NewBlock)
+	// Publish receipts of processed requests.
+	for _, receipt := range blockEvents.Receipts {
+		triggerEvent(events, events.RequestReceipt, receipt)
+	}
+	// Publish contract-issued events.
+	triggerEvent(events, events.BlockEvents, blockEvents.BlockEvents)
+}
+
+// BlockISCEvents contains the events published for a single block
+type BlockISCEvents struct {
+	NewBlock    *ISCEvent[*BlockWithTrieRoot]
+	Receipts    []*ISCEvent[*ReceiptWithError]
+	BlockEvents *ISCEvent[*BlockEvents]
+}
+
+// ISCEventsForBlock builds the events published for the given block, reading
+// them from the blocklog state. It is used both for the blocks being applied
+// and to replay the events of past blocks.
+func ISCEventsForBlock(
+	chainID isc.ChainID,
+	blockIndex uint32,
+	trieRoot trie.Hash,
+	blocklogState *blocklog.StateReader,
+	errorsState *errors.StateReader,
+	log log.Logger,
+) (*BlockISCEvents, error) {
 	blockInfo, ok := blocklogState.GetBlockInfo(blockIndex)
 	if !ok {
-		log.LogErrorf("unable to get blockInfo for blockIndex %d", blockIndex)
+		return nil, fmt.Errorf("unable to get blockInfo for blockIndex %d", blockIndex)
 	}
 
-	triggerEvent(events, events.NewBlock, &ISCEvent[*BlockWithTrieRoot]{
-		Kind:   ISCEventKindNewBlock,
-		Issuer: &isc.NilAgentID{},
-		Payload: &BlockWithTrieRoot{
-			BlockInfo: blockInfo,
-			TrieRoot:  block.TrieRoot(),
+	ret := &BlockISCEvents{
+		NewBlock: &ISCEvent[*BlockWithTrieRoot]{
+			Kind:   ISCEventKindNewBlock,
+			Issuer: &isc.NilAgentID{},
+			Payload: &BlockWithTrieRoot{
+				BlockInfo: blockInfo,
+				TrieRoot:  trieRoot,
+			},
+			ChainID: chainID,
 		},
-		ChainID: chainID,
-	})
+	}
 
-	//
-	// Publish receipts of processed requests.
-	receipts, err := blocklog.RequestReceiptsFromBlock(block)
+	_, receipts, err := blocklogState.GetRequestReceiptsInBlock(blockIndex)
 	if err != nil {
 		log.LogErrorf("unable to get receipts from a block: %v", err)
 	} else {
-		errorsState := errors.NewStateReaderFromChainState(blockApplied.latestState)
-
 		for index, receipt := range receipts {
 			vmError, resolveError := errorsState.Resolve(receipt.Error)
 			if resolveError != nil {
@@ -109,7 +152,7 @@ func PublishBlockEvents(blockApplied *blockApplied, events *Events, log log.Logg
 
 			parsedReceipt := receipt.ToISCReceipt(vmError)
 
-			triggerEvent(events, events.RequestReceipt, &ISCEvent[*ReceiptWithError]{
+			ret.Receipts = append(ret.Receipts, &ISCEvent[*ReceiptWithError]{
 				Kind:      ISCEventKindReceipt,
 				Issuer:    receipt.Request.SenderAccount(),
 				Payload:   &ReceiptWithError{RequestReceipt: parsedReceipt, Error: vmError},
@@ -119,20 +162,26 @@ func PublishBlockEvents(blockApplied *blockApplied, events *Events, log log.Logg
 		}
 	}
 
-	// Publish contract-issued events.
-	blockEvents := blocklogState.GetEventsByBlockIndex(blockIndex, blockInfo.TotalRequests)
-	var payload []*isc.Event
-	for _, eventData := range blockEvents {
-		event, err := isc.EventFromBytes(eventData)
-		if err != nil {
-			panic(err)
+	payload := &BlockEvents{BlockIndex: blockIndex}
+	for reqIdx := uint16(0); reqIdx < blockInfo.TotalRequests; reqIdx++ {
+		var sender isc.AgentID
+		if int(reqIdx) < len(receipts) {
+			sender = receipts[reqIdx].Request.SenderAccount()
+		}
+		for _, eventData := range blocklogState.GetEventsByRequestIndex(blockIndex, reqIdx) {
+			event, err := isc.EventFromBytes(eventData)
+			if err != nil {
+				panic(err)
+			}
+			payload.Events = append(payload.Events, event)
+			payload.Senders = append(payload.Senders, sender)
 		}
-		payload = append(payload, event)
 	}
-	triggerEvent(events, events.BlockEvents, &ISCEvent[[]*isc.Event]{
+	ret.BlockEvents = &ISCEvent[*BlockEvents]{
 		Kind:    ISCEventKindBlockEvents,
 		Issuer:  &isc.NilAgentID{},
 		Payload: payload,
 		ChainID: chainID,
-	})
+	}
+	return ret, nil
 }
@@ -15,7 +15,7 @@ import (
 )
 
 type Events struct {
-	BlockEvents    *event.Event1[*ISCEvent[[]*isc.Event]]
+	BlockEvents    *event.Event1[*ISCEvent[*BlockEvents]]
 	NewBlock       *event.Event1[*ISCEvent[*BlockWithTrieRoot]]
 	RequestReceipt *event.Event1[*ISCEvent[*ReceiptWithError]]
 
@@ -49,7 +49,7 @@ func New(log log.Logger) *Publisher {
 		Events: &Events{
 			NewBlock:       event.New1[*ISCEvent[*BlockWithTrieRoot]](),
 			RequestReceipt: event.New1[*ISCEvent[*ReceiptWithError]](),
-			BlockEvents:    event.New1[*ISCEvent[[]*isc.Event]](),
+			BlockEvents:    event.New1[*ISCEvent[*BlockEvents]](),
 			Published:      event.New1[*ISCEvent[any]](),
 		},
 	}
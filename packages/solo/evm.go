@@ -16,6 +16,7 @@ import (
 	hivedb "github.com/iotaledger/hive.go/db"
 	"github.com/iotaledger/wasp/v2/packages/chainutil"
 	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/v2/packages/hashing"
 	"github.com/iotaledger/wasp/v2/packages/isc"
@@ -55,7 +56,7 @@ func (b *jsonRPCSoloBackend) EVMSendTransaction(tx *types.Transaction) error {
 	return err
 }
 
-func (b *jsonRPCSoloBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) ([]byte, error) {
+func (b *jsonRPCSoloBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) ([]byte, error) {
 	return chainutil.EVMCall(
 		anchor,
 		l1Params,
@@ -63,10 +64,11 @@ func (b *jsonRPCSoloBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.C
 		b.Chain.proc,
 		b.Chain.log,
 		callMsg,
+		stateOverride,
 	)
 }
 
-func (b *jsonRPCSoloBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (uint64, error) {
+func (b *jsonRPCSoloBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) (uint64, error) {
 	return chainutil.EVMEstimateGas(
 		anchor,
 		l1Params,
@@ -74,6 +76,29 @@ func (b *jsonRPCSoloBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg eth
 		b.Chain.proc,
 		b.Chain.log,
 		callMsg,
+		stateOverride,
+	)
+}
+
+func (b *jsonRPCSoloBackend) EVMCreateAccessList(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (types.AccessList, uint64, *isc.VMError, error) {
+	return chainutil.EVMCreateAccessList(
+		anchor,
+		l1Params,
+		b.Chain.store,
+		b.Chain.proc,
+		b.Chain.log,
+		callMsg,
+	)
+}
+
+func (b *jsonRPCSoloBackend) EVMSimulate(anchor *isc.StateAnchor, blocks []*evmutil.SimulateBlock, l1Params *parameters.L1Params) ([]*evmutil.SimulatedBlock, error) {
+	return chainutil.EVMSimulate(
+		anchor,
+		l1Params,
+		b.Chain.store,
+		b.Chain.proc,
+		b.Chain.log,
+		blocks,
 	)
 }
 
@@ -118,6 +143,11 @@ func (b *jsonRPCSoloBackend) ISCStateByTrieRoot(trieRoot trie.Hash) (state.State
 	return b.Chain.store.StateByTrieRoot(trieRoot)
 }
 
+func (b *jsonRPCSoloBackend) EVMPendingTransactions() []*types.Transaction {
+	// requests are executed as soon as they are posted in Solo
+	return nil
+}
+
 func (b *jsonRPCSoloBackend) ISCChainID() *isc.ChainID {
 	return &b.Chain.ChainID
 }
@@ -65,7 +65,8 @@ func (ch *Chain) L2AssetsAtStateIndex(agentID isc.AgentID, stateIndex uint32) *i
 	res, err := ch.CallViewAtState(chainState, accounts.ViewBalance.Message(&agentID))
 	require.NoError(ch.Env.T, err)
 
-	cb := lo.Must(accounts.ViewBalance.DecodeOutput(res))
+	cb, _, err := accounts.ViewBalance.DecodeOutput(res)
+	require.NoError(ch.Env.T, err)
 
 	assets := isc.NewEmptyAssets()
 	for coinType, balance := range cb.Iterate() {
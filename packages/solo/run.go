@@ -129,7 +129,13 @@ func (ch *Chain) runRequestsNolock(reqs []isc.Request) (
 	})
 
 	ch.settleStateTransition(res.StateDraft)
-	return ptbRes, res.RequestResults
+	// the results of the scheduled jobs executed in the block are only
+	// available in the receipts
+	results := lo.Filter(res.RequestResults, func(r *vm.RequestResult, _ int) bool {
+		_, scheduled := r.Request.(*isc.ScheduledRequest)
+		return !scheduled
+	})
+	return ptbRes, results
 }
 
 func (ch *Chain) settleStateTransition(stateDraft state.StateDraft) {
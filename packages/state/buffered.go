@@ -25,8 +25,8 @@ func newBufferedKVStore(db kvstore.KVStore) *bufferedKVStore {
 	}
 }
 
-func (*bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
-	panic("should no be called")
+func (b *bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
+	return &bufferedBatch{store: b, muts: buffered.NewMutations()}, nil
 }
 
 func (*bufferedKVStore) Clear() error {
@@ -123,3 +123,40 @@ func (*bufferedKVStore) WithRealm(realm []byte) (kvstore.KVStore, error) {
 func (*bufferedKVStore) WithExtendedRealm(realm []byte) (kvstore.KVStore, error) {
 	panic("should no be called")
 }
+
+// bufferedBatch collects mutations that are applied to the bufferedKVStore
+// on Commit.
+type bufferedBatch struct {
+	store *bufferedKVStore
+	muts  *buffered.Mutations
+}
+
+var _ kvstore.BatchedMutations = &bufferedBatch{}
+
+func (b *bufferedBatch) Set(key []byte, value []byte) error {
+	b.muts.Set(kv.Key(key), value)
+	return nil
+}
+
+func (b *bufferedBatch) Delete(key []byte) error {
+	b.muts.Del(kv.Key(key), true)
+	return nil
+}
+
+func (b *bufferedBatch) Cancel() {
+	b.muts = buffered.NewMutations()
+}
+
+func (b *bufferedBatch) Commit() error {
+	for k, v := range b.muts.Sets {
+		if err := b.store.Set([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	for k := range b.muts.Dels {
+		if err := b.store.Delete([]byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
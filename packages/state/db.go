@@ -205,6 +205,27 @@ func (db *storeDB) takeSnapshot(root trie.Hash, w io.Writer) error {
 	return trie.TakeSnapshot(w)
 }
 
+func (db *storeDB) takeDeltaSnapshot(base, root trie.Hash, w io.Writer) error {
+	if !db.hasBlock(base) {
+		return fmt.Errorf("base trie root %s not found", base)
+	}
+	block, err := db.readBlock(root)
+	if err != nil {
+		return err
+	}
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint8(snapshotVersion)
+	ww.WriteBytes(block.Bytes())
+	if ww.Err != nil {
+		return ww.Err
+	}
+	trie, err := db.trieReader(block.TrieRoot())
+	if err != nil {
+		return err
+	}
+	return trie.TakeDeltaSnapshot(base, w)
+}
+
 func (db *storeDB) restoreSnapshot(root trie.Hash, r io.Reader, refcountsEnabled bool) error {
 	rr := rwutil.NewReader(r)
 	v := rr.ReadUint8()
@@ -36,6 +36,20 @@ func New(s state.Store) IndexedStore {
 	}
 }
 
+// NewBuffered returns an IndexedStore that reads through to the given one,
+// but keeps all written data in memory. See [state.NewBufferedStore].
+func NewBuffered(s IndexedStore) (IndexedStore, error) {
+	var st state.Store = s
+	if is, ok := s.(*istore); ok {
+		st = is.Store
+	}
+	buffered, err := state.NewBufferedStore(st)
+	if err != nil {
+		return nil, err
+	}
+	return New(buffered), nil
+}
+
 func (s *istore) BlockByIndex(index uint32) (state.Block, error) {
 	root, err := s.findTrieRootByIndex(index)
 	if err != nil {
@@ -0,0 +1,126 @@
+package state
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/wasp/v2/packages/kvstore"
+	"github.com/iotaledger/wasp/v2/packages/trie"
+)
+
+// IntegrityReport lists the problems found by Store.VerifyIntegrity
+type IntegrityReport struct {
+	// Blocks is the number of blocks in the store
+	Blocks int
+	// BlockErrors are the problems with reading the blocks and with the links
+	// between them
+	BlockErrors []error
+	// MissingBlocks are the blocks, which are referenced as previous by other
+	// blocks, but are neither in the store nor pruned
+	MissingBlocks []*MissingBlock
+	// Trie lists the problems of the tries of all the blocks
+	Trie *trie.IntegrityReport
+}
+
+type MissingBlock struct {
+	StateIndex uint32
+	Commitment *L1Commitment
+}
+
+func (r *IntegrityReport) IsOK() bool {
+	return len(r.BlockErrors) == 0 && len(r.MissingBlocks) == 0 && r.Trie.IsOK()
+}
+
+func (s *store) VerifyIntegrity() *IntegrityReport {
+	report := &IntegrityReport{}
+
+	// check PrefixLatestTrieRoot
+	if s.db.hasLatestTrieRoot() {
+		latestTrieRoot, err := s.LatestTrieRoot()
+		if err != nil {
+			report.BlockErrors = append(report.BlockErrors, fmt.Errorf("failed to read latest trie root: %w", err))
+		} else if !s.HasTrieRoot(latestTrieRoot) {
+			report.BlockErrors = append(report.BlockErrors, fmt.Errorf("latest trie root %s not found", latestTrieRoot))
+		}
+	}
+
+	latestPruned, latestErr := s.db.largestPrunedBlockIndex()
+	hasPrunedBlocks := latestErr == nil
+
+	// check PrefixBlockByTrieRoot
+	var blockTrieRoots []trie.Hash
+	err := s.db.IterateKeys(keyBlockByTrieRootNoTrieRoot(), func(key kvstore.Key) bool {
+		trieRoot, err := trie.HashFromBytes(key[1:])
+		if err != nil {
+			report.BlockErrors = append(report.BlockErrors, fmt.Errorf("invalid block key %x: %w", key, err))
+			return true
+		}
+		blockTrieRoots = append(blockTrieRoots, trieRoot)
+		return true
+	})
+	if err != nil {
+		report.BlockErrors = append(report.BlockErrors, fmt.Errorf("failed to iterate blocks: %w", err))
+	}
+	report.Blocks = len(blockTrieRoots)
+
+	// Each commit of a block references its trie root once. The trie root is
+	// referenced once more, if the block was committed on top of a block with
+	// the same trie root.
+	rootRefs := make(map[trie.Hash]uint32, len(blockTrieRoots))
+	missingBlocks := make(map[trie.Hash]*MissingBlock)
+	for _, trieRoot := range blockTrieRoots {
+		rootRefs[trieRoot]++
+		block, err := s.db.readBlock(trieRoot)
+		if err != nil {
+			report.BlockErrors = append(report.BlockErrors, fmt.Errorf("failed to read block %s: %w", trieRoot, err))
+			continue
+		}
+		if block.TrieRoot() != trieRoot {
+			report.BlockErrors = append(report.BlockErrors, fmt.Errorf("block %d stored under trie root %s has trie root %s", block.StateIndex(), trieRoot, block.TrieRoot()))
+			continue
+		}
+		if block.PreviousL1Commitment() == nil {
+			continue
+		}
+		previousTrieRoot := block.PreviousL1Commitment().TrieRoot()
+		if previousTrieRoot == trieRoot {
+			rootRefs[trieRoot]++
+			continue
+		}
+		if s.HasTrieRoot(previousTrieRoot) {
+			previousBlock, err := s.db.readBlock(previousTrieRoot)
+			if err == nil && block.StateIndex() != previousBlock.StateIndex()+1 {
+				report.BlockErrors = append(report.BlockErrors, fmt.Errorf("block %d %s follows block %d %s", block.StateIndex(), trieRoot, previousBlock.StateIndex(), previousTrieRoot))
+			}
+		} else if !hasPrunedBlocks || latestPruned < block.StateIndex()-1 {
+			missingBlocks[previousTrieRoot] = &MissingBlock{
+				StateIndex: block.StateIndex() - 1,
+				Commitment: block.PreviousL1Commitment(),
+			}
+		}
+	}
+	report.MissingBlocks = lo.Values(missingBlocks)
+	slices.SortFunc(report.MissingBlocks, func(a, b *MissingBlock) int {
+		return cmp.Compare(a.StateIndex, b.StateIndex)
+	})
+
+	// check PrefixTrie
+	var trieRoots []trie.Hash
+	for _, trieRoot := range blockTrieRoots {
+		for range rootRefs[trieRoot] {
+			trieRoots = append(trieRoots, trieRoot)
+		}
+	}
+	report.Trie = trie.NewTrieR(trieStore(s.db)).VerifyIntegrity(trieRoots)
+	return report
+}
+
+func (s *store) RepairRefcounts(report *IntegrityReport) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	return trie.NewTrieRW(trieStore(s.db)).RepairRefcounts(report.Trie.RefcountMismatches)
+}
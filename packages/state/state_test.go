@@ -203,6 +203,29 @@ func Test1Block(t *testing.T) {
 	cs.CheckIntegrity(io.Discard)
 }
 
+func TestBufferedStore(t *testing.T) {
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{initializedStore(db)}
+	block0 := cs.LatestBlock()
+
+	bs, err := state.NewBufferedStore(cs.Store)
+	require.NoError(t, err)
+	buffered := mustChainStore{bs}
+
+	d := buffered.NewStateDraft(time.Now(), block0.L1Commitment())
+	d.Set("a", []byte{1})
+	block1, _, _ := lo.Must3(buffered.Commit(d))
+	require.NoError(t, buffered.SetLatest(block1.TrieRoot()))
+	require.EqualValues(t, 1, buffered.LatestBlockIndex())
+	require.EqualValues(t, []byte{1}, buffered.StateByTrieRoot(block1.TrieRoot()).Get("a"))
+	buffered.checkTrie(block1.TrieRoot())
+
+	// the underlying store is not modified
+	require.False(t, cs.HasTrieRoot(block1.TrieRoot()))
+	require.EqualValues(t, 0, cs.LatestBlockIndex())
+	require.Equal(t, block0.TrieRoot(), cs.LatestBlock().TrieRoot())
+}
+
 func TestReorg(t *testing.T) {
 	db := mapdb.NewMapDB()
 	cs := mustChainStore{initializedStore(db)}
@@ -713,6 +736,46 @@ func TestSnapshot(t *testing.T) {
 	require.EqualValues(t, []byte(strings.Repeat("v", 70)), state.Get("x"))
 }
 
+func TestDeltaSnapshot(t *testing.T) {
+	orig, _ := makeRandomDB(t, 10)
+	baseBlock := orig.BlockByIndex(5)
+	lastBlock := orig.LatestBlock()
+
+	full := new(bytes.Buffer)
+	require.NoError(t, orig.TakeSnapshot(lastBlock.TrieRoot(), full))
+	delta := new(bytes.Buffer)
+	require.NoError(t, orig.TakeDeltaSnapshot(baseBlock.TrieRoot(), lastBlock.TrieRoot(), delta))
+	require.Less(t, delta.Len(), full.Len())
+
+	base := new(bytes.Buffer)
+	require.NoError(t, orig.TakeSnapshot(baseBlock.TrieRoot(), base))
+
+	// restore the base snapshot, then the delta on top of it
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{statetest.NewStoreWithUniqueWriteMutex(db)}
+	require.NoError(t, cs.RestoreSnapshot(baseBlock.TrieRoot(), bytes.NewReader(base.Bytes()), true))
+	require.NoError(t, cs.RestoreSnapshot(lastBlock.TrieRoot(), bytes.NewReader(delta.Bytes()), true))
+	require.NoError(t, cs.SetLatest(lastBlock.TrieRoot()))
+	cs.CheckIntegrity(io.Discard)
+
+	require.EqualValues(t, lastBlock.Hash(), cs.LatestBlock().Hash())
+	state := cs.LatestState()
+	for i := byte(1); i <= 10; i++ {
+		require.EqualValues(t, []byte("v"), state.Get(kv.Key(fmt.Sprintf("k%d", i))))
+	}
+	require.EqualValues(t, []byte{10}, state.Get("k"))
+	require.EqualValues(t, []byte(strings.Repeat("v", 70)), state.Get("x"))
+
+	// both tries can be pruned independently
+	_, err := cs.Prune(baseBlock.TrieRoot())
+	require.NoError(t, err)
+	cs.CheckIntegrity(io.Discard)
+	require.EqualValues(t, []byte{10}, cs.LatestState().Get("k"))
+
+	err = orig.TakeDeltaSnapshot(lastBlock.TrieRoot(), lastBlock.TrieRoot(), io.Discard)
+	require.Error(t, err)
+}
+
 func TestRestoreSnapshotEmptyDB(t *testing.T) {
 	trieRoot, _, snapshot := makeRandomDBSnapshot(t, 10)
 
@@ -967,3 +1030,48 @@ func TestManyRandomBlocks(t *testing.T) {
 		require.Equal(t, expectedMap, toMap(r.db))
 	}
 }
+
+func TestVerifyIntegrity(t *testing.T) {
+	r := newRandomState(t)
+	blocks := []state.Block{r.cs.LatestBlock()}
+	for i := range int64(3) {
+		block, _, _ := r.commitNewBlock(blocks[len(blocks)-1], time.UnixMilli(i))
+		blocks = append(blocks, block)
+	}
+	report := r.cs.VerifyIntegrity()
+	require.True(t, report.IsOK())
+	require.Equal(t, 4, report.Blocks)
+
+	snapshot := new(bytes.Buffer)
+	require.NoError(t, r.cs.TakeSnapshot(blocks[1].TrieRoot(), snapshot))
+
+	// damage the store
+	orphan := trie.Hash{1, 2, 3}
+	require.NoError(t, r.db.Set(append([]byte{chaindb.PrefixTrie, 2}, orphan.Bytes()...), codec.Encode[uint32](1)))
+	require.NoError(t, r.db.Delete(append([]byte{chaindb.PrefixBlockByTrieRoot}, blocks[1].TrieRoot().Bytes()...)))
+	require.NoError(t, r.db.Delete(append([]byte{chaindb.PrefixTrie, 0}, blocks[2].TrieRoot().Bytes()...)))
+	require.NoError(t, r.db.Set(append([]byte{chaindb.PrefixTrie, 2}, blocks[3].TrieRoot().Bytes()...), codec.Encode[uint32](2)))
+
+	report = r.cs.VerifyIntegrity()
+	require.False(t, report.IsOK())
+	require.Equal(t, 3, report.Blocks)
+	require.Empty(t, report.BlockErrors)
+	require.Len(t, report.MissingBlocks, 1)
+	require.EqualValues(t, 1, report.MissingBlocks[0].StateIndex)
+	require.Equal(t, blocks[1].L1Commitment(), report.MissingBlocks[0].Commitment)
+	require.Equal(t, []trie.Hash{blocks[2].TrieRoot()}, report.Trie.MissingNodes)
+	require.True(t, lo.ContainsBy(report.Trie.RefcountMismatches, func(m *trie.RefcountMismatch) bool {
+		return m.Node != nil && *m.Node == orphan && m.Expected == 0 && m.Stored == 1
+	}))
+	require.True(t, lo.ContainsBy(report.Trie.RefcountMismatches, func(m *trie.RefcountMismatch) bool {
+		return m.Node != nil && *m.Node == blocks[3].TrieRoot() && m.Expected == 1 && m.Stored == 2
+	}))
+
+	// the missing block is restored from the snapshot, and the refcounts are repaired
+	require.NoError(t, r.cs.RestoreSnapshot(blocks[1].TrieRoot(), snapshot, true))
+	require.NoError(t, r.cs.RepairRefcounts(r.cs.VerifyIntegrity()))
+	report = r.cs.VerifyIntegrity()
+	require.Empty(t, report.MissingBlocks)
+	require.Empty(t, report.Trie.RefcountMismatches)
+	require.Equal(t, []trie.Hash{blocks[2].TrieRoot()}, report.Trie.MissingNodes)
+}
@@ -83,6 +83,29 @@ func NewStoreReadonly(db kvstore.KVStore) (Store, error) {
 	}, nil
 }
 
+// NewBufferedStore returns a Store that reads through to the given one, but
+// keeps all written data (e.g. committed blocks) in memory, leaving the
+// underlying DB untouched. It allows to simulate the execution of blocks on
+// top of the chain state.
+func NewBufferedStore(s Store) (Store, error) {
+	st, ok := s.(*store)
+	if !ok {
+		return nil, fmt.Errorf("cannot create a buffered store from %T", s)
+	}
+	stateCache, err := lru.New[trie.Hash, *state](cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	_, storedb := st.db.buffered()
+	return &store{
+		db:               storedb,
+		stateCache:       stateCache,
+		metrics:          nil,
+		writeMutex:       &sync.Mutex{},
+		refcountsEnabled: st.refcountsEnabled,
+	}, nil
+}
+
 func (s *store) blockByTrieRoot(root trie.Hash) (Block, error) {
 	return s.db.readBlock(root)
 }
@@ -323,6 +346,10 @@ func (s *store) TakeSnapshot(root trie.Hash, w io.Writer) error {
 	return s.db.takeSnapshot(root, w)
 }
 
+func (s *store) TakeDeltaSnapshot(base, root trie.Hash, w io.Writer) error {
+	return s.db.takeDeltaSnapshot(base, root, w)
+}
+
 func (s *store) RestoreSnapshot(root trie.Hash, r io.Reader, refcountsEnabled bool) error {
 	if s.db.hasBlock(root) {
 		return nil
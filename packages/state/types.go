@@ -84,6 +84,13 @@ type Store interface {
 	// TakeSnapshot takes a snapshot of the block and trie at the given trie root.
 	TakeSnapshot(trie.Hash, io.Writer) error
 
+	// TakeDeltaSnapshot takes a snapshot of the block at the given trie root
+	// (second parameter), which contains only the trie nodes and values that
+	// are not present in the trie of the given base root (first parameter).
+	// It can be restored with RestoreSnapshot only if the base trie is present
+	// in the DB.
+	TakeDeltaSnapshot(trie.Hash, trie.Hash, io.Writer) error
+
 	// RestoreSnapshot restores the block and trie from the given snapshot.
 	// It is not required for the previous trie root to be present in the DB.
 	RestoreSnapshot(trie.Hash, io.Reader, bool) error
@@ -93,6 +100,15 @@ type Store interface {
 
 	// CheckIntegrity verifies the data integrity, for testing or debugging purposes.
 	CheckIntegrity(w io.Writer)
+
+	// VerifyIntegrity verifies the blocks and the tries of all the retained
+	// trie roots. Unlike CheckIntegrity, it reports all the problems found
+	// instead of panicking on the first one.
+	VerifyIntegrity() *IntegrityReport
+
+	// RepairRefcounts overwrites the trie refcounts, which were found to be
+	// wrong by VerifyIntegrity, with the expected ones.
+	RepairRefcounts(*IntegrityReport) error
 }
 
 // A Block contains the mutations between the previous and current states,
@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -33,10 +34,13 @@ func DummyEVMRequest(chainID isc.ChainID, gasPrice *big.Int) isc.OffLedgerReques
 	if err != nil {
 		panic(err)
 	}
+	return DummyEVMRequestForKey(chainID, key, 0, gasPrice)
+}
 
+func DummyEVMRequestForKey(chainID isc.ChainID, key *ecdsa.PrivateKey, nonce uint64, gasPrice *big.Int) isc.OffLedgerRequest {
 	tx := types.MustSignNewTx(key, types.NewEIP155Signer(big.NewInt(0)),
 		&types.LegacyTx{
-			Nonce:    0,
+			Nonce:    nonce,
 			To:       &common.MaxAddress,
 			Value:    big.NewInt(123),
 			Gas:      10000,
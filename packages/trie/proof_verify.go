@@ -170,3 +170,22 @@ func (p *MerkleProof) ValidateValue(trieRoot Hash, value []byte) error {
 	tc := CommitToData(value)
 	return p.ValidateWithTerminal(trieRoot.Bytes(), tc.Bytes())
 }
+
+// ValidateKeyValue checks the proof against the trie root, and checks that the proof is about
+// the given key and commits to the given value. If the value is empty, the proof is expected
+// to be a proof of absence of the key
+func (p *MerkleProof) ValidateKeyValue(trieRoot Hash, key, value []byte) error {
+	if !bytes.Equal(p.Key, unpackBytes(key)) {
+		return errors.New("the proof is not about the given key")
+	}
+	if len(value) == 0 {
+		if err := p.Validate(trieRoot.Bytes()); err != nil {
+			return err
+		}
+		if !p.IsProofOfAbsence() {
+			return errors.New("proof of absence expected")
+		}
+		return nil
+	}
+	return p.ValidateValue(trieRoot, value)
+}
@@ -1,12 +1,34 @@
 package trie
 
 import (
+	"errors"
 	"io"
 
 	"github.com/iotaledger/wasp/v2/packages/util/rwutil"
 )
 
 func (tr *TrieRFromRoot) TakeSnapshot(w io.Writer) error {
+	return tr.takeSnapshot(w, nil)
+}
+
+// TakeDeltaSnapshot writes only the nodes and values of the trie, which are
+// not present in the trie with the given base root. The format is the same as
+// in TakeSnapshot, so it can be restored with RestoreSnapshot to a store that
+// already contains the base trie.
+func (tr *TrieRFromRoot) TakeDeltaSnapshot(base Hash, w io.Writer) error {
+	if base == tr.Root {
+		return errors.New("base of the delta snapshot is the same as its root")
+	}
+	_, added := tr.R.Diff(base, tr.Root)
+	return tr.takeSnapshot(w, func(n *NodeData) bool {
+		_, ok := added[n.Commitment]
+		return ok
+	})
+}
+
+// takeSnapshot writes the nodes of the trie; if `include` is not nil, the
+// subtrees of the nodes for which it returns false are skipped
+func (tr *TrieRFromRoot) takeSnapshot(w io.Writer, include func(*NodeData) bool) error {
 	// Some duplicated nodes and values might be written more than once in the snapshot;
 	// Using a size-capped map to prevent this.
 	// If the cap is reached, the generated snapshot will contain duplicate information,
@@ -17,6 +39,9 @@ func (tr *TrieRFromRoot) TakeSnapshot(w io.Writer) error {
 
 	ww := rwutil.NewWriter(w)
 	tr.IterateNodes(func(_ []byte, n *NodeData, depth int) IterateNodesAction {
+		if include != nil && !include(n) {
+			return IterateSkipSubtree
+		}
 		if _, seen := seenNodes[n.Commitment]; seen {
 			return IterateContinue
 		}
@@ -32,10 +32,16 @@ func TestProofScenariosBlake2b(t *testing.T) {
 				p := trr.MerkleProof([]byte(k))
 				err := p.Validate(root.Bytes())
 				require.NoError(t, err)
+				err = p.ValidateKeyValue(root, []byte(k), []byte(v))
+				require.NoError(t, err)
+				err = p.ValidateKeyValue(root, []byte(k+"?"), []byte(v))
+				require.Error(t, err)
 				if v != "" {
 					cID := trie.CommitToData([]byte(v))
 					err = p.ValidateWithTerminal(root.Bytes(), cID.Bytes())
 					require.NoError(t, err)
+					err = p.ValidateKeyValue(root, []byte(k), nil)
+					require.Error(t, err)
 				} else {
 					require.True(t, p.IsProofOfAbsence())
 				}
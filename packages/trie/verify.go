@@ -0,0 +1,140 @@
+package trie
+
+import (
+	"errors"
+
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+)
+
+// IntegrityReport lists the problems found by VerifyIntegrity
+type IntegrityReport struct {
+	MissingNodes       []Hash
+	CorruptNodes       []Hash
+	MissingValues      []*Tcommitment
+	RefcountMismatches []*RefcountMismatch
+	// Refcounts are the refcounts expected from the verified trie roots
+	Refcounts *Refcounts
+}
+
+// RefcountMismatch is a refcount of a node or a value, which is different in
+// the store than expected from the trie structure. Exactly one of Node and
+// Value is set.
+type RefcountMismatch struct {
+	Node     *Hash
+	Value    *Tcommitment
+	Expected uint32
+	Stored   uint32
+}
+
+func (r *IntegrityReport) IsOK() bool {
+	return len(r.MissingNodes) == 0 &&
+		len(r.CorruptNodes) == 0 &&
+		len(r.MissingValues) == 0 &&
+		len(r.RefcountMismatches) == 0
+}
+
+// VerifyIntegrity walks the tries with the given roots and reports the missing
+// or corrupt nodes and values. If refcounts are enabled, it also compares the
+// refcounts in the store with the ones expected from the trie structure. A
+// root may be given more than once, if it is referenced more than once.
+// Unlike DebugDump, it does not panic on the first problem.
+func (tr *TrieR) VerifyIntegrity(roots []Hash) *IntegrityReport {
+	report := &IntegrityReport{Refcounts: NewRefcounts()}
+	var toVisit []Hash
+	addNode := func(commitment Hash) {
+		if report.Refcounts.incNode(commitment) == 1 {
+			toVisit = append(toVisit, commitment)
+		}
+	}
+	for _, root := range roots {
+		addNode(root)
+	}
+	for len(toVisit) > 0 {
+		commitment := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+
+		nodeBin := tr.store.Get(dbKeyNodeData(commitment))
+		if len(nodeBin) == 0 {
+			report.MissingNodes = append(report.MissingNodes, commitment)
+			continue
+		}
+		n, err := nodeDataFromBytes(nodeBin)
+		if err == nil {
+			n.updateCommitment()
+		}
+		if err != nil || n.Commitment != commitment {
+			report.CorruptNodes = append(report.CorruptNodes, commitment)
+			continue
+		}
+		if n.CommitsToExternalValue() && report.Refcounts.incValue(n.Terminal) == 1 {
+			if !tr.store.Has(n.Terminal.dbKeyValue()) {
+				report.MissingValues = append(report.MissingValues, n.Terminal)
+			}
+		}
+		n.iterateChildren(func(_ byte, childCommitment Hash) bool {
+			addNode(childCommitment)
+			return true
+		})
+	}
+	if tr.IsRefcountsEnabled() {
+		report.RefcountMismatches = tr.refcountMismatches(report.Refcounts)
+	}
+	return report
+}
+
+// refcountMismatches compares the refcounts stored in the DB with the expected
+// ones. Refcounts, which cannot be decoded, are treated as 0.
+func (tr *TrieR) refcountMismatches(expected *Refcounts) []*RefcountMismatch {
+	stored := NewRefcounts()
+	tr.store.Iterate([]byte{partitionRefcountNodes}, func(k, v []byte) bool {
+		commitment, err := HashFromBytes(k[1:])
+		if err == nil {
+			stored.Nodes[commitment], _ = codec.Decode[uint32](v, 0)
+		}
+		return true
+	})
+	tr.store.Iterate([]byte{partitionRefcountValues}, func(k, v []byte) bool {
+		stored.Values[string(k[1:])], _ = codec.Decode[uint32](v, 0)
+		return true
+	})
+
+	var ret []*RefcountMismatch
+	for commitment, n := range expected.Nodes {
+		if stored.Nodes[commitment] != n {
+			ret = append(ret, &RefcountMismatch{Node: &commitment, Expected: n, Stored: stored.Nodes[commitment]})
+		}
+	}
+	for commitment, n := range stored.Nodes {
+		if _, ok := expected.Nodes[commitment]; !ok {
+			ret = append(ret, &RefcountMismatch{Node: &commitment, Stored: n})
+		}
+	}
+	for data, n := range expected.Values {
+		if stored.Values[data] != n {
+			ret = append(ret, &RefcountMismatch{Value: &Tcommitment{Data: []byte(data)}, Expected: n, Stored: stored.Values[data]})
+		}
+	}
+	for data, n := range stored.Values {
+		if _, ok := expected.Values[data]; !ok {
+			ret = append(ret, &RefcountMismatch{Value: &Tcommitment{Data: []byte(data)}, Stored: n})
+		}
+	}
+	return ret
+}
+
+// RepairRefcounts overwrites the mismatched refcounts in the store with the
+// expected ones. Nodes and values, which are not referenced by any verified
+// trie root, are left in the store; only their refcounts are deleted.
+func (tr *TrieRW) RepairRefcounts(mismatches []*RefcountMismatch) error {
+	if !tr.IsRefcountsEnabled() {
+		return errors.New("refcounts disabled, cannot repair refcounts")
+	}
+	for _, m := range mismatches {
+		if m.Node != nil {
+			tr.setNodeRefcount(*m.Node, m.Expected)
+		} else {
+			tr.setValueRefcount(m.Value, m.Expected)
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/kv"
 	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/kv/collections"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
 	"github.com/iotaledger/wasp/v2/packages/util"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
@@ -61,6 +62,16 @@ func (s *StateReader) GetBaseTokensBalanceDiscardExtraDecimals(agentID isc.Agent
 	return bts
 }
 
+// BaseTokensBalanceKeys returns the chain state keys under which the base
+// tokens balance of the account is stored (the coin balance and the wei
+// remainder). It is used to produce proofs of the account balance.
+func BaseTokensBalanceKeys(agentID isc.AgentID) (balanceKey, weiRemainderKey kv.Key) {
+	accKey := accountKey(agentID)
+	balanceKey = kv.Key(Contract.FullKey([]byte(collections.MapElemKey(accountCoinBalancesKey(accKey), coin.BaseTokenType.Bytes()))))
+	weiRemainderKey = kv.Key(Contract.FullKey([]byte(accountWeiRemainderKey(accKey))))
+	return balanceKey, weiRemainderKey
+}
+
 func accountWeiRemainderKey(accountKey kv.Key) kv.Key {
 	return prefixAccountWeiRemainder + accountKey
 }
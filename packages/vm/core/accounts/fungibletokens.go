@@ -56,6 +56,9 @@ func (s *StateWriter) DebitFromAccount(agentID isc.AgentID, coins isc.CoinBalanc
 	if coins.IsEmpty() {
 		return
 	}
+	if !s.canDebit(agentID, coins) {
+		panic(fmt.Errorf("cannot debit (%s) from %s: %w", coins, agentID, ErrLockedFunds))
+	}
 	if !s.debitFromAccount(accountKey(agentID), coins) {
 		panic(fmt.Errorf("cannot debit (%s) from %s: %w", coins, agentID, ErrNotEnoughFunds))
 	}
@@ -95,6 +98,9 @@ func (s *StateWriter) DebitFromAccountFullDecimals(agentID isc.AgentID, amount *
 	if !bigint.IsPositive(amount) {
 		return
 	}
+	if !s.canDebitFullDecimals(agentID, amount) {
+		panic(fmt.Errorf("cannot debit (%s) from %s: %w", amount.String(), agentID, ErrLockedFunds))
+	}
 	if !s.debitFromAccountFullDecimals(accountKey(agentID), amount) {
 		panic(fmt.Errorf("cannot debit (%s) from %s: %w", amount.String(), agentID, ErrNotEnoughFunds))
 	}
@@ -1,6 +1,8 @@
 package accounts
 
 import (
+	"time"
+
 	"github.com/samber/lo"
 
 	"github.com/iotaledger/wasp/v2/packages/coin"
@@ -11,6 +13,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 )
 
 func CommonAccount() isc.AgentID {
@@ -24,9 +27,14 @@ var Processor = Contract.Processor(nil,
 	FuncDeposit.WithHandler(deposit),
 	FuncTransferAllowanceTo.WithHandler(transferAllowanceTo),
 	FuncWithdraw.WithHandler(withdraw),
+	FuncWithdrawTo.WithHandler(withdrawTo),
+	FuncWithdrawBatch.WithHandler(withdrawBatch),
 	SetCoinMetadata.WithHandler(setCoinMetadata),
 	DeleteCoinMetadata.WithHandler(deleteCoinMetadata),
 	AdjustCommonAccountBaseTokens.WithHandler(adjustCommonAccountBaseTokens),
+	FuncCreateLockup.WithHandler(createLockup),
+	FuncSetSponsorship.WithHandler(setSponsorship),
+	FuncApproveLockupFunder.WithHandler(approveLockupFunder),
 
 	// views
 	ViewAccountObjects.WithHandler(viewAccountObjects),
@@ -35,6 +43,10 @@ var Processor = Contract.Processor(nil,
 	ViewBalanceBaseTokenEVM.WithHandler(viewBalanceBaseTokenEVM),
 	ViewBalanceCoin.WithHandler(viewBalanceCoin),
 	ViewGetAccountNonce.WithHandler(viewGetAccountNonce),
+	ViewLockedBalance.WithHandler(viewLockedBalance),
+	ViewGetLockups.WithHandler(viewGetLockups),
+	ViewIsLockupFunderApproved.WithHandler(viewIsLockupFunderApproved),
+	ViewGetSponsorship.WithHandler(viewGetSponsorship),
 	ViewTotalAssets.WithHandler(viewTotalAssets),
 )
 
@@ -96,18 +108,131 @@ func withdraw(ctx isc.Sandbox) {
 	if !ok {
 		panic(errCallerMustHaveL1Address)
 	}
+	sendAllowanceToL1(ctx, callerAddress, allowance)
+}
+
+// withdrawTo sends the allowed funds to the given L1 address.
+// Unlike withdraw, the caller can be any agent, including contracts and
+// Ethereum addresses.
+func withdrawTo(ctx isc.Sandbox, targetAddress *cryptolib.Address) {
+	allowance := ctx.AllowanceAvailable()
+	ctx.Log().Debugf("accounts.withdrawTo.begin -- %s", allowance)
+	if allowance.IsEmpty() {
+		panic(ErrNotEnoughAllowance)
+	}
+	ctx.Requiref(targetAddress != nil, "target address is required")
+	sendAllowanceToL1(ctx, targetAddress, allowance)
+}
+
+func sendAllowanceToL1(ctx isc.Sandbox, targetAddress *cryptolib.Address, allowance *isc.Assets) {
 	remains := ctx.TransferAllowedFunds(ctx.AccountID())
 	ctx.Requiref(remains.IsEmpty(), "internal: allowance remains must be empty")
 	ctx.Send(isc.RequestParameters{
-		TargetAddress: callerAddress,
+		TargetAddress: targetAddress,
 		Assets:        allowance,
 	})
 	ctx.Log().Debugf("accounts.withdraw.success. Sent to address %s: %s",
-		callerAddress.String(),
+		targetAddress.String(),
 		allowance.String(),
 	)
 }
 
+var errInvalidWithdrawal = coreerrors.Register("invalid withdrawal #%d")
+
+// withdrawBatch sends the allowed funds to several L1 addresses in a single
+// request. The sum of the withdrawn assets is taken from the allowance, the
+// rest of the allowance is left to the caller.
+func withdrawBatch(ctx isc.Sandbox, withdrawals []*Withdrawal) {
+	ctx.Log().Debugf("accounts.withdrawBatch.begin -- %d withdrawals", len(withdrawals))
+	ctx.Requiref(len(withdrawals) > 0, "no withdrawals")
+	ctx.Requiref(len(withdrawals) <= MaxWithdrawalsPerBatch, "too many withdrawals: max %d", MaxWithdrawalsPerBatch)
+
+	total := isc.NewEmptyAssets()
+	for i, w := range withdrawals {
+		if !isValidWithdrawal(w, total) {
+			panic(errInvalidWithdrawal.Create(uint16(i))) //nolint:gosec // bounded by MaxWithdrawalsPerBatch
+		}
+		total.Add(w.Assets)
+	}
+
+	ctx.TransferAllowedFunds(ctx.AccountID(), total)
+	for _, w := range withdrawals {
+		ctx.Send(isc.RequestParameters{
+			TargetAddress: w.TargetAddress,
+			Assets:        w.Assets,
+		})
+	}
+	ctx.Log().Debugf("accounts.withdrawBatch.success. Sent: %s", total.String())
+}
+
+// isValidWithdrawal checks that the withdrawal is not empty, and does not send
+// an object already sent by a previous withdrawal of the batch
+func isValidWithdrawal(w *Withdrawal, previous *isc.Assets) bool {
+	if w == nil || w.TargetAddress == nil || w.Assets == nil || w.Assets.IsEmpty() {
+		return false
+	}
+	for obj := range w.Assets.Objects.Iterate() {
+		if previous.Objects.Has(obj.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	errInvalidLockupSchedule    = coreerrors.Register("invalid lockup schedule")
+	errTooManyLockups           = coreerrors.Register("too many lockups (max %d)")
+	errTooManyLockupsFromFunder = coreerrors.Register("too many lockups from the same funder (max %d)")
+	errLockupFunderNotApproved  = coreerrors.Register("lockup funder not approved by the beneficiary")
+)
+
+// createLockup moves the allowed coins from the caller to the beneficiary,
+// locked until they are released by the vesting schedule: nothing before
+// cliffTime, then linearly between startTime and endTime.
+// The caller must be approved by the beneficiary with approveLockupFunder.
+func createLockup(ctx isc.Sandbox, beneficiary isc.AgentID, startTime, cliffTime, endTime time.Time) {
+	ctx.Requiref(ctx.SchemaVersion() >= allmigrations.SchemaVersionLockups, "lockups are not enabled")
+	state := NewStateWriterFromSandbox(ctx)
+	if !beneficiary.Equals(ctx.Caller()) && !state.IsLockupFunderApproved(beneficiary, ctx.Caller()) {
+		panic(errLockupFunderNotApproved.Create())
+	}
+	allowance := ctx.AllowanceAvailable()
+	ctx.Log().Debugf("accounts.createLockup.begin -- %s", allowance)
+	if allowance.Coins.IsEmpty() {
+		panic(ErrNotEnoughAllowance)
+	}
+	ctx.Requiref(allowance.Objects.Size() == 0, "objects cannot be locked")
+	if !startTime.Before(endTime) || cliffTime.Before(startTime) || cliffTime.After(endTime) {
+		panic(errInvalidLockupSchedule.Create())
+	}
+
+	coins := allowance.Coins.Clone()
+	ctx.TransferAllowedFunds(beneficiary, coins.ToAssets())
+	if err := state.AddLockup(beneficiary, &Lockup{
+		Funder:    ctx.Caller(),
+		Coins:     coins,
+		StartTime: startTime,
+		CliffTime: cliffTime,
+		EndTime:   endTime,
+	}); err != nil {
+		panic(err)
+	}
+	ctx.Log().Debugf("accounts.createLockup.success -- %s locked for %s", coins, beneficiary)
+}
+
+// approveLockupFunder approves or revokes the funder to create lockups for the
+// caller
+func approveLockupFunder(ctx isc.Sandbox, funder isc.AgentID, approved bool) {
+	NewStateWriterFromSandbox(ctx).SetLockupFunderApproval(ctx.Caller(), funder, approved)
+}
+
+// setSponsorship approves the sender to spend up to maxGasFee base tokens of
+// the caller on the gas fees of the requests sponsored by the caller.
+// A maxGasFee of 0 revokes the approval.
+func setSponsorship(ctx isc.Sandbox, sender isc.AgentID, maxGasFee coin.Value) {
+	NewStateWriterFromSandbox(ctx).SetSponsorship(ctx.Caller(), sender, maxGasFee)
+}
+
 func setCoinMetadata(ctx isc.Sandbox, coinInfo *parameters.IotaCoinInfo) {
 	ctx.RequireCallerIsChainAdmin()
 	NewStateWriterFromSandbox(ctx).SaveCoinInfo(coinInfo)
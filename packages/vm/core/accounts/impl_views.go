@@ -8,11 +8,13 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/isc/coreutil"
 )
 
-// viewBalance returns the balances of the account belonging to the AgentID
-func viewBalance(ctx isc.SandboxView, optionalAgentID *isc.AgentID) isc.CoinBalances {
+// viewBalance returns the balances of the account belonging to the AgentID,
+// and the part of them that is locked
+func viewBalance(ctx isc.SandboxView, optionalAgentID *isc.AgentID) (isc.CoinBalances, isc.CoinBalances) {
 	aid := coreutil.FromOptional(optionalAgentID, ctx.Caller())
 	ctx.Log().Debugf("accounts.viewBalance %s", aid)
-	return NewStateReaderFromSandbox(ctx).getFungibleTokens(accountKey(aid))
+	state := NewStateReaderFromSandbox(ctx)
+	return state.getFungibleTokens(accountKey(aid)), state.GetLockedCoins(aid)
 }
 
 // viewBalanceBaseToken returns the base tokens balance of the account belonging to the AgentID
@@ -41,6 +43,33 @@ func viewTotalAssets(ctx isc.SandboxView) isc.CoinBalances {
 	return NewStateReaderFromSandbox(ctx).getFungibleTokens(L2TotalsAccount)
 }
 
+// viewLockedBalance returns the locked and the releasable (i.e. spendable)
+// balances of the account belonging to the AgentID
+func viewLockedBalance(ctx isc.SandboxView, optionalAgentID *isc.AgentID) (isc.CoinBalances, isc.CoinBalances) {
+	aid := coreutil.FromOptional(optionalAgentID, ctx.Caller())
+	state := NewStateReaderFromSandbox(ctx)
+	return state.GetLockedCoins(aid), state.GetReleasableCoins(aid)
+}
+
+// viewGetLockups returns the lockups of the account belonging to the AgentID,
+// including the ones already fully released
+func viewGetLockups(ctx isc.SandboxView, optionalAgentID *isc.AgentID) []*Lockup {
+	aid := coreutil.FromOptional(optionalAgentID, ctx.Caller())
+	return NewStateReaderFromSandbox(ctx).GetLockups(aid)
+}
+
+// viewIsLockupFunderApproved returns whether the funder can create lockups for
+// the beneficiary
+func viewIsLockupFunderApproved(ctx isc.SandboxView, beneficiary, funder isc.AgentID) bool {
+	return NewStateReaderFromSandbox(ctx).IsLockupFunderApproved(beneficiary, funder)
+}
+
+// viewGetSponsorship returns the amount of base tokens the sponsor can still
+// spend on the gas fees of the sender
+func viewGetSponsorship(ctx isc.SandboxView, sponsor, sender isc.AgentID) coin.Value {
+	return NewStateReaderFromSandbox(ctx).GetSponsorship(sponsor, sender)
+}
+
 // nonces are only sent with off-ledger requests
 func viewGetAccountNonce(ctx isc.SandboxView, optionalAgentID *isc.AgentID) uint64 {
 	account := coreutil.FromOptional(optionalAgentID, ctx.Caller())
@@ -9,8 +9,10 @@ package accounts
 // views for querying account information and balances.
 import (
 	"math/big"
+	"time"
 
 	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/isc/coreutil"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
@@ -29,7 +31,13 @@ var (
 	FuncTransferAllowanceTo = coreutil.NewEP1(Contract, "transferAllowanceTo",
 		coreutil.Field[isc.AgentID]("agentID"),
 	)
-	FuncWithdraw    = coreutil.NewEP0(Contract, "withdraw")
+	FuncWithdraw   = coreutil.NewEP0(Contract, "withdraw")
+	FuncWithdrawTo = coreutil.NewEP1(Contract, "withdrawTo",
+		coreutil.Field[*cryptolib.Address]("targetAddress"),
+	)
+	FuncWithdrawBatch = coreutil.NewEP1(Contract, "withdrawBatch",
+		coreutil.Field[[]*Withdrawal]("withdrawals"),
+	)
 	SetCoinMetadata = coreutil.NewEP1(Contract, "setCoinMetadata",
 		coreutil.Field[*parameters.IotaCoinInfo]("coinInfo"),
 	)
@@ -40,6 +48,20 @@ var (
 		coreutil.Field[coin.Value]("credit"),
 		coreutil.Field[coin.Value]("debit"),
 	)
+	FuncCreateLockup = coreutil.NewEP4(Contract, "createLockup",
+		coreutil.Field[isc.AgentID]("beneficiary"),
+		coreutil.Field[time.Time]("startTime"),
+		coreutil.Field[time.Time]("cliffTime"),
+		coreutil.Field[time.Time]("endTime"),
+	)
+	FuncSetSponsorship = coreutil.NewEP2(Contract, "setSponsorship",
+		coreutil.Field[isc.AgentID]("sender"),
+		coreutil.Field[coin.Value]("maxGasFee"),
+	)
+	FuncApproveLockupFunder = coreutil.NewEP2(Contract, "approveLockupFunder",
+		coreutil.Field[isc.AgentID]("funder"),
+		coreutil.Field[bool]("approved"),
+	)
 
 	// Views
 	// TODO: implement pagination
@@ -48,9 +70,10 @@ var (
 		coreutil.Field[[]isc.IotaObject]("accountObjects"),
 	)
 	// TODO: implement pagination
-	ViewBalance = coreutil.NewViewEP11(Contract, "balance",
+	ViewBalance = coreutil.NewViewEP12(Contract, "balance",
 		coreutil.FieldOptional[isc.AgentID]("agentID"),
 		coreutil.Field[isc.CoinBalances]("coinBalances"),
+		coreutil.Field[isc.CoinBalances]("lockedCoinBalances"),
 	)
 	ViewBalanceBaseToken = coreutil.NewViewEP11(Contract, "balanceBaseToken",
 		coreutil.FieldOptional[isc.AgentID]("agentID"),
@@ -66,6 +89,26 @@ var (
 		coreutil.Field[coin.Value]("coinBalance"),
 	)
 
+	ViewLockedBalance = coreutil.NewViewEP12(Contract, "lockedBalance",
+		coreutil.FieldOptional[isc.AgentID]("agentID"),
+		coreutil.Field[isc.CoinBalances]("lockedCoinBalances"),
+		coreutil.Field[isc.CoinBalances]("releasableCoinBalances"),
+	)
+	ViewGetLockups = coreutil.NewViewEP11(Contract, "getLockups",
+		coreutil.FieldOptional[isc.AgentID]("agentID"),
+		coreutil.Field[[]*Lockup]("lockups"),
+	)
+	ViewIsLockupFunderApproved = coreutil.NewViewEP21(Contract, "isLockupFunderApproved",
+		coreutil.Field[isc.AgentID]("beneficiary"),
+		coreutil.Field[isc.AgentID]("funder"),
+		coreutil.Field[bool]("approved"),
+	)
+	ViewGetSponsorship = coreutil.NewViewEP21(Contract, "getSponsorship",
+		coreutil.Field[isc.AgentID]("sponsor"),
+		coreutil.Field[isc.AgentID]("sender"),
+		coreutil.Field[coin.Value]("maxGasFee"),
+	)
+
 	ViewGetAccountNonce = coreutil.NewViewEP11(Contract, "getAccountNonce",
 		coreutil.FieldOptional[isc.AgentID]("agentID"),
 		coreutil.Field[uint64]("nonce"),
@@ -75,3 +118,24 @@ var (
 		coreutil.Field[isc.CoinBalances]("coinBalances"),
 	)
 )
+
+// MaxWithdrawalsPerBatch is the maximum number of withdrawals in a single
+// withdrawBatch request
+const MaxWithdrawalsPerBatch = 50
+
+// MaxLockupsPerAccount is the maximum number of lockups not fully released in
+// a single account
+const MaxLockupsPerAccount = 16
+
+// MaxLockupsPerFunder is the maximum number of lockups not fully released in
+// a single account created by the same funder, so that a single funder cannot
+// fill the account with dust lockups. Only the funders approved by the
+// account can create lockups for it, so the lockups of unsolicited funders
+// never count against MaxLockupsPerAccount.
+const MaxLockupsPerFunder = 4
+
+// Withdrawal is a single transfer to L1 of a withdrawBatch request
+type Withdrawal struct {
+	TargetAddress *cryptolib.Address
+	Assets        *isc.Assets
+}
@@ -18,6 +18,7 @@ var (
 	ErrDuplicateTreasuryCap = coreerrors.Register("duplicate TreasuryCap").Create()
 	ErrTreasuryCapNotFound  = coreerrors.Register("TreasuryCap not found").Create()
 	ErrOverflow             = coreerrors.Register("overflow in token arithmetics").Create()
+	ErrLockedFunds          = coreerrors.Register("funds are locked").Create()
 )
 
 const (
@@ -52,6 +53,21 @@ const (
 	// keyObjectOwner stores a map of <ObjectID> => isc.AgentID
 	// Covered in: TODO
 	keyObjectOwner = "W"
+
+	// prefixLockups | <accountID> stores the []*Lockup of the account
+	// Covered in: TestAccounts_Lockup
+	prefixLockups = "L"
+
+	// prefixSponsorships | <accountID> stores a map of <agentID> => coin.Value,
+	// the amount of base tokens the account can still spend on gas fees for the
+	// requests sponsored for the agentID
+	// Covered in: TestAccounts_Sponsorship
+	prefixSponsorships = "S"
+
+	// prefixLockupFunders | <accountID> stores a map of <agentID> => true, the
+	// funders approved by the account to create lockups for it
+	// Covered in: TestAccounts_Lockup
+	prefixLockupFunders = "F"
 )
 
 func accountKey(agentID isc.AgentID) kv.Key {
@@ -95,6 +111,9 @@ func (s *StateReader) HasEnoughForAllowance(agentID isc.AgentID, allowance *isc.
 			return false
 		}
 	}
+	if !s.canDebit(agentID, allowance.Coins) {
+		return false
+	}
 	for obj := range allowance.Objects.Iterate() {
 		if !s.hasObject(agentID, obj.ID) {
 			return false
@@ -110,6 +129,9 @@ func (s *StateWriter) MoveBetweenAccounts(fromAgentID, toAgentID isc.AgentID, as
 		return nil
 	}
 
+	if !s.canDebit(fromAgentID, assets.Coins) {
+		return errors.New("MoveBetweenAccounts: funds are locked")
+	}
 	if !s.debitFromAccount(accountKey(fromAgentID), assets.Coins) {
 		return errors.New("MoveBetweenAccounts: not enough funds")
 	}
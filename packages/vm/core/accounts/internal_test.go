@@ -1,7 +1,9 @@
 package accounts_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -351,3 +353,53 @@ func testCreditDebitObject1(t *testing.T, v isc.SchemaVersion) {
 	accObjects = accounts.NewStateReader(v, state).GetAccountObjects(agentID1)
 	require.Len(t, accObjects, 0)
 }
+
+func TestLockup(t *testing.T) {
+	v := allmigrations.DefaultScheme.LatestSchemaVersion()
+	state := dict.New()
+	agentID := isctest.NewRandomAgentID()
+	start := time.Unix(1_000_000, 0)
+	lockup := &accounts.Lockup{
+		Funder:    isctest.NewRandomAgentID(),
+		Coins:     isc.NewCoinBalances().AddBaseTokens(1000).Add(dummyAssetID, 10),
+		StartTime: start,
+		CliffTime: start.Add(25 * time.Second),
+		EndTime:   start.Add(100 * time.Second),
+	}
+
+	// nothing is released before the cliff, then linearly until the end
+	require.True(t, lockup.Coins.Equals(lockup.LockedAt(start.Add(-time.Second))))
+	require.True(t, lockup.Coins.Equals(lockup.LockedAt(start.Add(24*time.Second))))
+	require.True(t, isc.NewCoinBalances().AddBaseTokens(750).Add(dummyAssetID, 8).Equals(lockup.LockedAt(start.Add(25*time.Second))))
+	require.True(t, isc.NewCoinBalances().AddBaseTokens(10).Add(dummyAssetID, 1).Equals(lockup.LockedAt(start.Add(99*time.Second))))
+	require.True(t, lockup.LockedAt(start.Add(100*time.Second)).IsEmpty())
+
+	now := start.Add(50 * time.Second)
+	w := accounts.NewStateWriter(v, state)
+	w.SetTimeSource(func() time.Time { return now })
+	w.CreditToAccount(agentID, isc.NewCoinBalances().AddBaseTokens(1100).Add(dummyAssetID, 10))
+	require.NoError(t, w.AddLockup(agentID, lockup))
+
+	require.True(t, isc.NewCoinBalances().AddBaseTokens(500).Add(dummyAssetID, 5).Equals(w.GetLockedCoins(agentID)))
+	require.True(t, isc.NewCoinBalances().AddBaseTokens(600).Add(dummyAssetID, 5).Equals(w.GetReleasableCoins(agentID)))
+	require.EqualValues(t, 600, w.GetReleasableBaseTokens(agentID))
+
+	// the locked coins cannot be debited
+	require.False(t, w.HasEnoughForAllowance(agentID, isc.NewAssets(601)))
+	require.True(t, w.HasEnoughForAllowance(agentID, isc.NewAssets(600)))
+	require.ErrorContains(t, w.MoveBetweenAccounts(agentID, isctest.NewRandomAgentID(), isc.NewAssets(601)), "locked")
+	require.PanicsWithError(t, fmt.Sprintf("cannot debit (%s) from %s: %s", isc.NewCoinBalances().Add(dummyAssetID, 6), agentID, accounts.ErrLockedFunds), func() {
+		w.DebitFromAccount(agentID, isc.NewCoinBalances().Add(dummyAssetID, 6))
+	})
+	w.DebitFromAccount(agentID, isc.NewCoinBalances().AddBaseTokens(600).Add(dummyAssetID, 5))
+	checkLedgerT(t, v, state)
+
+	// fully released
+	now = lockup.EndTime
+	require.True(t, w.GetLockedCoins(agentID).IsEmpty())
+	w.DebitFromAccount(agentID, isc.NewCoinBalances().AddBaseTokens(500).Add(dummyAssetID, 5))
+	checkLedgerT(t, v, state)
+
+	// without a time source the lockups are fully locked
+	require.True(t, lockup.Coins.Equals(accounts.NewStateReader(v, state).GetLockedCoins(agentID)))
+}
@@ -0,0 +1,193 @@
+package accounts
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kv"
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/kv/collections"
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/util"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
+)
+
+// Lockup is a vesting schedule of coins credited to an account. The coins
+// are part of the account balance, but they cannot be debited until they are
+// released: nothing is released before CliffTime, then the coins are released
+// linearly from StartTime until EndTime, by block timestamp.
+type Lockup struct {
+	Funder    isc.AgentID
+	Coins     isc.CoinBalances
+	StartTime time.Time
+	CliffTime time.Time
+	EndTime   time.Time
+}
+
+// LockedAt returns the coins of the lockup that are still locked at the given
+// time
+func (l *Lockup) LockedAt(t time.Time) isc.CoinBalances {
+	ret := isc.NewCoinBalances()
+	if !t.Before(l.EndTime) {
+		return ret
+	}
+	if t.Before(l.CliffTime) {
+		return l.Coins.Clone()
+	}
+	elapsed := big.NewInt(t.Sub(l.StartTime).Nanoseconds())
+	duration := big.NewInt(l.EndTime.Sub(l.StartTime).Nanoseconds())
+	for coinType, amount := range l.Coins.Iterate() {
+		released := new(big.Int).SetUint64(uint64(amount))
+		released.Mul(released, elapsed).Quo(released, duration)
+		ret.Add(coinType, amount-coin.Value(released.Uint64()))
+	}
+	return ret
+}
+
+func lockupsKey(agentID isc.AgentID) kv.Key {
+	return prefixLockups + accountKey(agentID)
+}
+
+func (s *StateReader) GetLockups(agentID isc.AgentID) []*Lockup {
+	return codec.MustDecode[[]*Lockup](s.state.Get(lockupsKey(agentID)), nil)
+}
+
+func (s *StateWriter) setLockups(agentID isc.AgentID, lockups []*Lockup) {
+	if len(lockups) == 0 {
+		s.state.Del(lockupsKey(agentID))
+		return
+	}
+	s.state.Set(lockupsKey(agentID), codec.Encode(lockups))
+}
+
+func lockupFundersMapKey(beneficiary isc.AgentID) string {
+	return prefixLockupFunders + string(accountKey(beneficiary))
+}
+
+// IsLockupFunderApproved returns whether the beneficiary has approved the
+// funder to create lockups for it
+func (s *StateReader) IsLockupFunderApproved(beneficiary, funder isc.AgentID) bool {
+	m := collections.NewMapReadOnly(s.state, lockupFundersMapKey(beneficiary))
+	return codec.MustDecode[bool](m.GetAt(funder.Bytes()), false)
+}
+
+// SetLockupFunderApproval approves or revokes the funder to create lockups
+// for the beneficiary
+func (s *StateWriter) SetLockupFunderApproval(beneficiary, funder isc.AgentID, approved bool) {
+	m := collections.NewMap(s.state, lockupFundersMapKey(beneficiary))
+	if !approved {
+		m.DelAt(funder.Bytes())
+		return
+	}
+	m.SetAt(funder.Bytes(), codec.Encode(true))
+}
+
+func (s *StateReader) currentTime() time.Time {
+	if s.now == nil {
+		return time.Time{}
+	}
+	return s.now()
+}
+
+// AddLockup adds a lockup to the account, removing the ones that are already
+// fully released. It fails if the account has too many lockups, in total or
+// from the same funder. The coins of the lockup must be credited to the
+// account separately.
+func (s *StateWriter) AddLockup(agentID isc.AgentID, lockup *Lockup) error {
+	now := s.currentTime()
+	lockups := []*Lockup{}
+	fromFunder := 0
+	for _, l := range s.GetLockups(agentID) {
+		if !now.Before(l.EndTime) {
+			continue
+		}
+		lockups = append(lockups, l)
+		if l.Funder.Equals(lockup.Funder) {
+			fromFunder++
+		}
+	}
+	if len(lockups) >= MaxLockupsPerAccount {
+		return errTooManyLockups.Create(uint16(MaxLockupsPerAccount))
+	}
+	if fromFunder >= MaxLockupsPerFunder {
+		return errTooManyLockupsFromFunder.Create(uint16(MaxLockupsPerFunder))
+	}
+	s.setLockups(agentID, append(lockups, lockup))
+	return nil
+}
+
+// GetLockedCoins returns the coins of the account that cannot be debited yet
+func (s *StateReader) GetLockedCoins(agentID isc.AgentID) isc.CoinBalances {
+	ret := isc.NewCoinBalances()
+	lockups := s.GetLockups(agentID)
+	if len(lockups) == 0 {
+		return ret
+	}
+	if s.burnGas != nil {
+		s.burnGas(gas.BurnCodeDecodeLockups1P, uint64(len(lockups)))
+	}
+	now := s.currentTime()
+	for _, l := range lockups {
+		for coinType, amount := range l.LockedAt(now).Iterate() {
+			ret.Add(coinType, amount)
+		}
+	}
+	return ret
+}
+
+// GetReleasableCoins returns the coins of the account that can be debited,
+// i.e. the balance minus the locked coins
+func (s *StateReader) GetReleasableCoins(agentID isc.AgentID) isc.CoinBalances {
+	ret := s.GetCoins(agentID)
+	for coinType, locked := range s.GetLockedCoins(agentID).Iterate() {
+		ret.Set(coinType, ret.Get(coinType)-min(locked, ret.Get(coinType)))
+	}
+	return ret
+}
+
+// GetReleasableBaseTokens returns the base tokens of the account that can be
+// debited
+func (s *StateReader) GetReleasableBaseTokens(agentID isc.AgentID) coin.Value {
+	balance := s.GetBaseTokensBalanceDiscardExtraDecimals(agentID)
+	return balance - min(s.GetLockedCoins(agentID).BaseTokens(), balance)
+}
+
+// canDebit checks that debiting the coins does not touch the locked coins of
+// the account. The balance itself is checked by the caller.
+// Before SchemaVersionLockups the lockups are not read, so that the gas used
+// by the debits does not change.
+func (s *StateReader) canDebit(agentID isc.AgentID, coins isc.CoinBalances) bool {
+	if s.v < allmigrations.SchemaVersionLockups {
+		return true
+	}
+	locked := s.GetLockedCoins(agentID)
+	if locked.IsEmpty() {
+		return true
+	}
+	accountKey := accountKey(agentID)
+	for coinType, amount := range coins.Iterate() {
+		balance := s.getCoinBalance(accountKey, coinType)
+		if balance >= amount && balance-amount < locked.Get(coinType) {
+			return false
+		}
+	}
+	return true
+}
+
+// canDebitFullDecimals is the same as canDebit, for base tokens with full
+// decimals
+func (s *StateReader) canDebitFullDecimals(agentID isc.AgentID, wei *big.Int) bool {
+	if s.v < allmigrations.SchemaVersionLockups {
+		return true
+	}
+	locked := s.GetLockedCoins(agentID).BaseTokens()
+	if locked == 0 {
+		return true
+	}
+	lockedWei := util.BaseTokensDecimalsToEthereumDecimals(locked, parameters.BaseTokenDecimals)
+	remaining := new(big.Int).Sub(s.getBaseTokensFullDecimals(accountKey(agentID)), wei)
+	return remaining.Sign() < 0 || remaining.Cmp(lockedWei) >= 0
+}
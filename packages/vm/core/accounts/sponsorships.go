@@ -0,0 +1,55 @@
+package accounts
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/kv/collections"
+)
+
+func sponsorshipsMapKey(sponsor isc.AgentID) string {
+	return prefixSponsorships + string(accountKey(sponsor))
+}
+
+func (s *StateWriter) sponsorshipsMap(sponsor isc.AgentID) *collections.Map {
+	return collections.NewMap(s.state, sponsorshipsMapKey(sponsor))
+}
+
+func (s *StateReader) sponsorshipsMapR(sponsor isc.AgentID) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(s.state, sponsorshipsMapKey(sponsor))
+}
+
+// GetSponsorship returns the amount of base tokens the sponsor can still
+// spend on gas fees for the requests of the sender, or 0 if the sponsor has
+// not approved the sender
+func (s *StateReader) GetSponsorship(sponsor, sender isc.AgentID) coin.Value {
+	return codec.MustDecode[coin.Value](s.sponsorshipsMapR(sponsor).GetAt(sender.Bytes()), 0)
+}
+
+// SetSponsorship approves the sender to spend up to maxGasFee base tokens of
+// the sponsor on gas fees. A maxGasFee of 0 revokes the approval.
+func (s *StateWriter) SetSponsorship(sponsor, sender isc.AgentID, maxGasFee coin.Value) {
+	if maxGasFee == 0 {
+		s.sponsorshipsMap(sponsor).DelAt(sender.Bytes())
+		return
+	}
+	s.sponsorshipsMap(sponsor).SetAt(sender.Bytes(), codec.Encode(maxGasFee))
+}
+
+// GetSponsoredGasFeeBalance returns the base tokens the sponsor can spend on
+// the gas fee of a request of the sender, taking into account both the
+// approval and the balance of the sponsor
+func (s *StateReader) GetSponsoredGasFeeBalance(sponsor, sender isc.AgentID) coin.Value {
+	approved := s.GetSponsorship(sponsor, sender)
+	if approved == 0 {
+		return 0
+	}
+	return min(approved, s.GetReleasableBaseTokens(sponsor))
+}
+
+// ConsumeSponsorship subtracts the gas fee charged to the sponsor from the
+// approval of the sender
+func (s *StateWriter) ConsumeSponsorship(sponsor, sender isc.AgentID, gasFee coin.Value) {
+	approved := s.GetSponsorship(sponsor, sender)
+	s.SetSponsorship(sponsor, sender, approved-min(gasFee, approved))
+}
@@ -5,14 +5,23 @@
 package accounts
 
 import (
+	"time"
+
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/kv"
 	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 type StateReader struct {
 	v     isc.SchemaVersion
 	state kv.KVStoreReader
+	// now returns the current time, used to compute the locked balances.
+	// If nil, the lockups are considered fully locked.
+	now func() time.Time
+	// burnGas charges the gas of the work not covered by the state reads,
+	// i.e. decoding the lockups. If nil, no gas is charged.
+	burnGas func(burnCode gas.BurnCode, par ...uint64)
 }
 
 func NewStateReader(v isc.SchemaVersion, contractState kv.KVStoreReader) *StateReader {
@@ -23,7 +32,10 @@ func NewStateReader(v isc.SchemaVersion, contractState kv.KVStoreReader) *StateR
 }
 
 func NewStateReaderFromSandbox(ctx isc.SandboxBase) *StateReader {
-	return NewStateReader(ctx.SchemaVersion(), ctx.StateR())
+	s := NewStateReader(ctx.SchemaVersion(), ctx.StateR())
+	s.SetTimeSource(ctx.Timestamp)
+	s.SetGasBurn(ctx.Gas().Burn)
+	return s
 }
 
 func NewStateReaderFromChainState(v isc.SchemaVersion, chainState kv.KVStoreReader) *StateReader {
@@ -43,7 +55,22 @@ func NewStateWriter(v isc.SchemaVersion, contractState kv.KVStore) *StateWriter
 }
 
 func NewStateWriterFromSandbox(ctx isc.Sandbox) *StateWriter {
-	return NewStateWriter(ctx.SchemaVersion(), ctx.State())
+	s := NewStateWriter(ctx.SchemaVersion(), ctx.State())
+	s.SetTimeSource(ctx.Timestamp)
+	s.SetGasBurn(ctx.Gas().Burn)
+	return s
+}
+
+// SetTimeSource sets the function returning the current time, used to
+// compute the locked balances. It is only called if the account has lockups.
+func (s *StateReader) SetTimeSource(now func() time.Time) {
+	s.now = now
+}
+
+// SetGasBurn sets the function charging the gas of decoding the lockups of the
+// debited accounts
+func (s *StateReader) SetGasBurn(burn func(burnCode gas.BurnCode, par ...uint64)) {
+	s.burnGas = burn
 }
 
 func AgentIDFromKey(key kv.Key) (isc.AgentID, error) {
@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/kv/collections"
 	"github.com/iotaledger/wasp/v2/packages/kv/dict"
 	"github.com/iotaledger/wasp/v2/packages/parameters/parameterstest"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
@@ -84,6 +85,70 @@ func TestPruneRequestIndexLookupTable(t *testing.T) {
 	require.Equal(t, len(RequestLookupKey{})*requestsToCreate, digest1Size-digest0Size)
 }
 
+func TestPruneShrinkWindow(t *testing.T) {
+	d := dict.Dict{}
+	s := NewStateWriter(d)
+	var latestBlockIndex uint32
+	saveBlocks := func(n int, blockKeepAmount int32) {
+		for range n {
+			s.SaveNextBlockInfo(&BlockInfo{
+				SchemaVersion: BlockInfoLatestSchemaVersion,
+				BlockIndex:    latestBlockIndex,
+				Timestamp:     time.Unix(int64(latestBlockIndex), 0),
+				L1Params:      parameterstest.L1Mock,
+			})
+			s.Prune(allmigrations.LatestSchemaVersion, latestBlockIndex, blockKeepAmount)
+			latestBlockIndex++
+		}
+	}
+	requireBlocks := func(pruned, kept uint32) {
+		for blockIndex := uint32(0); blockIndex <= pruned; blockIndex++ {
+			_, ok := s.GetBlockInfo(blockIndex)
+			require.False(t, ok, "block %d", blockIndex)
+		}
+		for blockIndex := kept; blockIndex < latestBlockIndex; blockIndex++ {
+			_, ok := s.GetBlockInfo(blockIndex)
+			require.True(t, ok, "block %d", blockIndex)
+		}
+	}
+
+	// blocks 0..149
+	saveBlocks(150, 100)
+	requireBlocks(49, 50)
+
+	// shrink the window: the blocks out of the window are deleted gradually
+	saveBlocks(1, 10)
+	requireBlocks(50+maxBlocksToPrunePerBlock-1, 50+maxBlocksToPrunePerBlock)
+	saveBlocks(10, 10)
+	requireBlocks(150, 151)
+
+	// grow the window: no blocks are deleted until the window is full
+	saveBlocks(10, 20)
+	requireBlocks(150, 151)
+	saveBlocks(1, 20)
+	requireBlocks(151, 152)
+}
+
+func TestPruneBeforeGradualPruning(t *testing.T) {
+	d := dict.Dict{}
+	s := NewStateWriter(d)
+	for blockIndex := uint32(0); blockIndex < 150; blockIndex++ {
+		s.SaveNextBlockInfo(&BlockInfo{
+			SchemaVersion: BlockInfoLatestSchemaVersion,
+			BlockIndex:    blockIndex,
+			Timestamp:     time.Unix(int64(blockIndex), 0),
+			L1Params:      parameterstest.L1Mock,
+		})
+		s.Prune(allmigrations.SchemaVersionIotaRebased, blockIndex, 100)
+	}
+	_, ok := s.GetBlockInfo(49)
+	require.False(t, ok)
+	_, ok = s.GetBlockInfo(50)
+	require.True(t, ok)
+	// the state must be the same as before the pruning cursor was introduced
+	require.False(t, d.Has(varNextBlockToPrune))
+}
+
 func eventTopic(block uint32, requestIndex uint16, eventIndex uint16) string {
 	topic := fmt.Sprintf("fakeEvent:%d.%d.%d", block, requestIndex, eventIndex)
 	return topic
@@ -148,10 +213,74 @@ func TestGetEventsInternal(t *testing.T) {
 	eventMap := collections.NewMap(d, prefixRequestEvents)
 	createEventLookupKeys(registry, eventMap, contractID, maxBlocks, maxRequests, maxEventsPerRequest)
 
-	events := NewStateWriter(d).getSmartContractEventsInternal(EventsForContractQuery{contractID, &BlockRange{blockFrom, blockTo}})
+	events, next := NewStateWriter(d).getSmartContractEventsInternal(&EventsForContractQuery{
+		Contract:   contractID,
+		BlockRange: &BlockRange{blockFrom, blockTo},
+	})
+	require.Nil(t, next)
 	validateEvents(t, events, maxRequests, maxEventsPerRequest, blockFrom, blockTo)
 }
 
+func TestGetEventsInternalPagination(t *testing.T) {
+	const maxBlocks = 20
+	const maxRequests = 5
+	const maxEventsPerRequest = 10
+
+	contractID := isc.Hn("testytest")
+
+	d := dict.Dict{}
+	registry := collections.NewArray(d, prefixBlockRegistry)
+	eventMap := collections.NewMap(d, prefixRequestEvents)
+	createEventLookupKeys(registry, eventMap, contractID, maxBlocks, maxRequests, maxEventsPerRequest)
+	// events of another contract
+	for blockIndex := uint32(0); blockIndex < maxBlocks; blockIndex++ {
+		event := isc.Event{Topic: "other", ContractID: isc.Hn("other")}
+		eventMap.SetAt(NewEventLookupKey(blockIndex, 0, maxEventsPerRequest).Bytes(), event.Bytes())
+	}
+
+	s := NewStateWriter(d)
+	q := &EventsForContractQuery{
+		Contract:   contractID,
+		BlockRange: &BlockRange{From: 3, To: 100},
+		MaxEvents:  7,
+	}
+	var topics []string
+	for {
+		events, next := s.getSmartContractEventsInternal(q)
+		require.LessOrEqual(t, len(events), 7)
+		for _, b := range events {
+			event := lo.Must(isc.EventFromBytes(b))
+			require.Equal(t, contractID, event.ContractID)
+			topics = append(topics, event.Topic)
+		}
+		if next == nil {
+			break
+		}
+		q.After = next
+	}
+	// all events, in order
+	var expected []string
+	for blockIndex := uint32(3); blockIndex < maxBlocks; blockIndex++ {
+		for reqIndex := uint16(0); reqIndex < maxRequests; reqIndex++ {
+			for eventIndex := uint16(0); eventIndex < maxEventsPerRequest; eventIndex++ {
+				expected = append(expected, eventTopic(blockIndex, reqIndex, eventIndex))
+			}
+		}
+	}
+	require.Equal(t, expected, topics)
+
+	// filter by topic
+	topic := eventTopic(4, 2, 7)
+	events, next := s.getSmartContractEventsInternal(&EventsForContractQuery{
+		Contract:   contractID,
+		BlockRange: &BlockRange{From: 0, To: 10},
+		Topic:      &topic,
+	})
+	require.Nil(t, next)
+	require.Len(t, events, 1)
+	require.Equal(t, topic, lo.Must(isc.EventFromBytes(events[0])).Topic)
+}
+
 func TestBlockInfoMarshalling(t *testing.T) {
 	t.Run("v0", func(t *testing.T) {
 		const v0hex = "002a00000000b421501f01000000640000000000000000000000000000000100000000000000000000000000000001000000000000000000000000000000e80300000000000000000000000000009edb91da930100000000000000000000005c2605000000000000000000000000000000000000000000000000000000000000000000000000000000000000000204696f746104494f5441000004496f746104494f544104494f54410f687474703a2f2f696f74612e6f726709e0afdabfb6f592bd8a010a0008000200e807f403"
@@ -1,6 +1,8 @@
 package blocklog
 
 import (
+	"cmp"
+
 	bcs "github.com/iotaledger/bcs-go"
 	"github.com/iotaledger/wasp/v2/packages/kv/codec"
 )
@@ -34,3 +36,11 @@ func (k *EventLookupKey) RequestEventIndex() uint16 {
 func (k *EventLookupKey) Bytes() []byte {
 	return bcs.MustMarshal(k)
 }
+
+func cmpEventLookupKey(a, b *EventLookupKey) int {
+	return cmp.Or(
+		cmp.Compare(a.BlockIndex(), b.BlockIndex()),
+		cmp.Compare(a.RequestIndex(), b.RequestIndex()),
+		cmp.Compare(a.RequestEventIndex(), b.RequestEventIndex()),
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/kv/collections"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 )
 
 func (s *StateReader) GetRequestReceiptsInBlock(blockIndex uint32) (*BlockInfo, []*RequestReceipt, error) {
@@ -68,22 +69,27 @@ func (s *StateReader) GetRequestRecordDataByRequestID(reqID isc.RequestID) (*Req
 
 func (s *StateReader) GetEventsByBlockIndex(blockIndex uint32, totalRequests uint16) [][]byte {
 	var ret [][]byte
-	events := collections.NewMapReadOnly(s.state, prefixRequestEvents)
 	for reqIdx := uint16(0); reqIdx < totalRequests; reqIdx++ {
-		eventIndex := uint16(0)
-		for {
-			key := NewEventLookupKey(blockIndex, reqIdx, eventIndex).Bytes()
-			eventData := events.GetAt(key)
-			if eventData == nil {
-				break
-			}
-			ret = append(ret, eventData)
-			eventIndex++
-		}
+		ret = append(ret, s.GetEventsByRequestIndex(blockIndex, reqIdx)...)
 	}
 	return ret
 }
 
+// GetEventsByRequestIndex returns the events emitted by the request with the
+// given index in the block
+func (s *StateReader) GetEventsByRequestIndex(blockIndex uint32, requestIndex uint16) [][]byte {
+	var ret [][]byte
+	events := collections.NewMapReadOnly(s.state, prefixRequestEvents)
+	for eventIndex := uint16(0); ; eventIndex++ {
+		key := NewEventLookupKey(blockIndex, requestIndex, eventIndex).Bytes()
+		eventData := events.GetAt(key)
+		if eventData == nil {
+			return ret
+		}
+		ret = append(ret, eventData)
+	}
+}
+
 func (s *StateReader) GetBlockInfo(blockIndex uint32) (*BlockInfo, bool) {
 	data := s.getBlockInfoBytes(blockIndex)
 	if data == nil {
@@ -96,7 +102,16 @@ func (s *StateReader) GetBlockInfo(blockIndex uint32) (*BlockInfo, bool) {
 	return ret, true
 }
 
-func (s *StateWriter) Prune(latestBlockIndex uint32, blockKeepAmount int32) {
+// Prune deletes the blocks that are older than the latest blockKeepAmount
+// blocks.
+//
+// If blockKeepAmount was reduced, the blocks left out of the window are
+// deleted gradually, at most maxBlocksToPrunePerBlock at a time. If it was
+// increased, nothing is deleted until the window is full again.
+//
+// Before SchemaVersionGradualPruning only the block that just left the window
+// is deleted, and the pruning cursor is not stored.
+func (s *StateWriter) Prune(v isc.SchemaVersion, latestBlockIndex uint32, blockKeepAmount int32) {
 	if blockKeepAmount <= 0 {
 		// keep all blocks
 		return
@@ -105,7 +120,26 @@ func (s *StateWriter) Prune(latestBlockIndex uint32, blockKeepAmount int32) {
 		return
 	}
 	toDelete := latestBlockIndex - uint32(blockKeepAmount)
-	// assume that all blocks prior to `toDelete` have been already deleted, so
-	// we only need to delete this one.
-	s.pruneBlock(toDelete)
+	if v < allmigrations.SchemaVersionGradualPruning {
+		// assume that all blocks prior to `toDelete` have been already
+		// deleted, so we only need to delete this one.
+		s.pruneBlock(toDelete)
+		return
+	}
+	nextToDelete, ok := s.getNextBlockToPrune()
+	if !ok {
+		// assume that all blocks prior to `toDelete` have been already deleted
+		nextToDelete = toDelete
+	}
+	if nextToDelete > toDelete {
+		// blockKeepAmount was increased, wait until the window is full again
+		return
+	}
+	// if blockKeepAmount was reduced, delete the blocks out of the window
+	// gradually
+	last := min(toDelete, nextToDelete+maxBlocksToPrunePerBlock-1)
+	for blockIndex := nextToDelete; blockIndex <= last; blockIndex++ {
+		s.pruneBlock(blockIndex)
+	}
+	s.setNextBlockToPrune(last + 1)
 }
@@ -14,6 +14,7 @@ import (
 var Processor = Contract.Processor(nil,
 	ViewGetBlockInfo.WithHandler(viewGetBlockInfo),
 	ViewGetEventsForBlock.WithHandler(viewGetEventsForBlock),
+	ViewGetEventsForContract.WithHandler(viewGetEventsForContract),
 	ViewGetEventsForRequest.WithHandler(viewGetEventsForRequest),
 	ViewGetRequestIDsForBlock.WithHandler(viewGetRequestIDsForBlock),
 	ViewGetRequestReceipt.WithHandler(viewGetRequestReceipt),
@@ -134,3 +135,18 @@ func viewGetEventsForBlock(ctx isc.SandboxView, blockIndexOptional *uint32) (uin
 		return lo.Must(isc.EventFromBytes(b))
 	})
 }
+
+// viewGetEventsForContract returns a page of the events emitted by a given
+// contract in a given range of blocks.
+func viewGetEventsForContract(ctx isc.SandboxView, q *EventsForContractQuery) *EventsForContractResponse {
+	ctx.Requiref(q.BlockRange != nil, "block range is required")
+	ctx.Requiref(q.BlockRange.From <= q.BlockRange.To, "invalid block range: %d > %d", q.BlockRange.From, q.BlockRange.To)
+	ctx.Requiref(q.BlockRange.To-q.BlockRange.From < MaxBlocksPerContractQuery, "the block range cannot exceed %d blocks", MaxBlocksPerContractQuery)
+	events, next := NewStateReaderFromSandbox(ctx).getSmartContractEventsInternal(q)
+	return &EventsForContractResponse{
+		Events: lo.Map(events, func(b []byte, _ int) *isc.Event {
+			return lo.Must(isc.EventFromBytes(b))
+		}),
+		Next: next,
+	}
+}
@@ -57,6 +57,10 @@ var (
 		coreutil.Field[uint32]("blockIndex"),
 		coreutil.Field[[]*isc.Event]("events"),
 	)
+	ViewGetEventsForContract = coreutil.NewViewEP11(Contract, "getEventsForContract",
+		coreutil.Field[*EventsForContractQuery]("query"),
+		coreutil.Field[*EventsForContractResponse]("events"),
+	)
 )
 
 const (
@@ -79,8 +83,25 @@ const (
 	//   EventLookupKey = blockIndex | requestIndex | eventIndex
 	// Covered in: TestGetEvents
 	prefixRequestEvents = "d"
+
+	// varNextBlockToPrune :: uint32
+	// All blocks before this one are already pruned
+	// Covered in: TestPruneShrinkWindow
+	varNextBlockToPrune = "p"
 )
 
+// MaxEventsPerContractQuery is the maximum amount of events returned by
+// ViewGetEventsForContract in a single call
+const MaxEventsPerContractQuery = 1000
+
+// MaxBlocksPerContractQuery is the maximum size of the block range scanned by
+// ViewGetEventsForContract in a single call
+const MaxBlocksPerContractQuery = 1000
+
+// maxBlocksToPrunePerBlock is the maximum amount of blocks deleted when
+// producing a single block
+const maxBlocksToPrunePerBlock = 20
+
 type OutputRequestReceipt struct{}
 
 func (OutputRequestReceipt) Name() string {
@@ -3,6 +3,7 @@ package blocklog
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/samber/lo"
 
@@ -140,11 +141,28 @@ type BlockRange struct {
 }
 
 type EventsForContractQuery struct {
-	Contract   isc.Hname
+	Contract isc.Hname
+	// BlockRange is the range of blocks to scan (both included). It cannot
+	// be larger than MaxBlocksPerContractQuery blocks.
 	BlockRange *BlockRange
+	// Topic, if set, filters the events by topic
+	Topic *string `bcs:"optional"`
+	// After, if set, skips all events up to this one (included).
+	// It is used to fetch the next page of results.
+	After *EventLookupKey `bcs:"optional"`
+	// MaxEvents is the maximum amount of events returned. 0 means
+	// MaxEventsPerContractQuery.
+	MaxEvents uint32
 }
 
-func (s *StateReader) getSmartContractEventsInternal(q EventsForContractQuery) [][]byte {
+type EventsForContractResponse struct {
+	Events []*isc.Event
+	// Next, if set, is the value of EventsForContractQuery.After that returns
+	// the next page of results
+	Next *EventLookupKey `bcs:"optional"`
+}
+
+func (s *StateReader) getSmartContractEventsInternal(q *EventsForContractQuery) (events [][]byte, next *EventLookupKey) {
 	registry := collections.NewArrayReadOnly(s.state, prefixBlockRegistry)
 	latestBlockIndex := registry.Len() - 1
 	adjustedToBlock := q.BlockRange.To
@@ -153,19 +171,57 @@ func (s *StateReader) getSmartContractEventsInternal(q EventsForContractQuery) [
 		adjustedToBlock = latestBlockIndex
 	}
 
+	maxEvents := q.MaxEvents
+	if maxEvents == 0 || maxEvents > MaxEventsPerContractQuery {
+		maxEvents = MaxEventsPerContractQuery
+	}
+
+	fromBlock := q.BlockRange.From
+	if q.After != nil && q.After.BlockIndex() > fromBlock {
+		fromBlock = q.After.BlockIndex()
+	}
+
+	type blockEvent struct {
+		key   *EventLookupKey
+		value []byte
+	}
+
 	filteredEvents := make([][]byte, 0)
-	for blockNumber := q.BlockRange.From; blockNumber <= adjustedToBlock; blockNumber++ {
+	for blockNumber := fromBlock; blockNumber <= adjustedToBlock; blockNumber++ {
+		var blockEvents []blockEvent
 		eventBlockKey := collections.MapElemKey(prefixRequestEvents, codec.Encode[uint32](blockNumber))
-		s.state.Iterate(eventBlockKey, func(_ kv.Key, value []byte) bool {
+		s.state.Iterate(eventBlockKey, func(key kv.Key, value []byte) bool {
 			parsedContractID, _ := isc.ContractIDFromEventBytes(value)
-			if parsedContractID == q.Contract {
-				filteredEvents = append(filteredEvents, value)
+			if parsedContractID != q.Contract {
+				return true
+			}
+			if q.Topic != nil {
+				event, err := isc.EventFromBytes(value)
+				if err != nil || event.Topic != *q.Topic {
+					return true
+				}
 			}
+			var lookupKey EventLookupKey
+			copy(lookupKey[:], key[len(key)-EventLookupKeyLength:])
+			if q.After != nil && cmpEventLookupKey(&lookupKey, q.After) <= 0 {
+				return true
+			}
+			blockEvents = append(blockEvents, blockEvent{key: &lookupKey, value: value})
 			return true
 		})
+		// the keys are not sorted by the kv store, since the indices are
+		// encoded in little endian
+		slices.SortFunc(blockEvents, func(a, b blockEvent) int { return cmpEventLookupKey(a.key, b.key) })
+		for _, e := range blockEvents {
+			if len(filteredEvents) == int(maxEvents) {
+				return filteredEvents, next
+			}
+			filteredEvents = append(filteredEvents, e.value)
+			next = e.key
+		}
 	}
 
-	return filteredEvents
+	return filteredEvents, nil
 }
 
 func (s *StateWriter) pruneEventsByBlockIndex(blockIndex uint32, totalRequests uint16) {
@@ -285,6 +341,18 @@ func getBlockIndexParams(ctx isc.SandboxView, blockIndexOptional *uint32) uint32
 	return registry.Len() - 1
 }
 
+func (s *StateReader) getNextBlockToPrune() (uint32, bool) {
+	data := s.state.Get(varNextBlockToPrune)
+	if data == nil {
+		return 0, false
+	}
+	return codec.MustDecode[uint32](data), true
+}
+
+func (s *StateWriter) setNextBlockToPrune(blockIndex uint32) {
+	s.state.Set(varNextBlockToPrune, codec.Encode(blockIndex))
+}
+
 func (s *StateWriter) pruneBlock(blockIndex uint32) {
 	blockInfo, ok := s.GetBlockInfo(blockIndex)
 	if !ok {
@@ -10,24 +10,107 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/kv/codec"
 	"github.com/iotaledger/wasp/v2/packages/state"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 // region RequestReceipt /////////////////////////////////////////////////////
 
+const (
+	// The receipts of schema version 0 are encoded without the schema version.
+	receiptSchemaVersion0 = iota
+	receiptSchemaVersionAddedSponsor
+	receiptSchemaVersionAddedFeeMultiplier
+
+	RequestReceiptLatestSchemaVersion = receiptSchemaVersionAddedFeeMultiplier
+)
+
+// RequestReceiptSchemaVersion returns the schema version of the receipts
+// written with the given chain schema version. Before
+// SchemaVersionVersionedReceipts the receipts are written with the legacy
+// encoding.
+func RequestReceiptSchemaVersion(v isc.SchemaVersion) uint8 {
+	if v < allmigrations.SchemaVersionVersionedReceipts {
+		return receiptSchemaVersion0
+	}
+	return RequestReceiptLatestSchemaVersion
+}
+
+// receiptSchemaVersionPrefix precedes the schema version of the encoded
+// receipts since receiptSchemaVersionAddedSponsor. The receipts of schema
+// version 0 start with the enum variant index of the request instead, which
+// is never equal to it.
+const receiptSchemaVersionPrefix = 0xff
+
 // RequestReceipt represents log record of processed request on the chain
 type RequestReceipt struct {
+	SchemaVersion uint8                  `json:"-"`
 	Request       isc.Request            `json:"request"`
 	Error         *isc.UnresolvedVMError `json:"error" bcs:"optional"`
 	GasBudget     uint64                 `json:"gasBudget" bcs:"compact"`
 	GasBurned     uint64                 `json:"gasBurned" bcs:"compact"`
 	GasFeeCharged coin.Value             `json:"gasFeeCharged" bcs:"compact"`
 	GasBurnLog    *gas.BurnLog           `json:"-" bcs:"optional"`
+	// Sponsor is the account that paid the gas fee instead of the sender, if
+	// any (since v1)
+	Sponsor isc.AgentID `json:"sponsor" bcs:"optional"`
+	// FeeMultiplier is the discount applied to the gas fee of the sender, in
+	// basis points, if the sender has one (since v2)
+	FeeMultiplier *uint32 `json:"feeMultiplier" bcs:"optional"`
 	// not persistent
 	BlockIndex   uint32 `json:"blockIndex" bcs:"-"`
 	RequestIndex uint16 `json:"requestIndex" bcs:"-"`
 }
 
+func (rec *RequestReceipt) MarshalBCS(e *bcs.Encoder) error {
+	if rec.SchemaVersion != receiptSchemaVersion0 {
+		e.WriteUint8(receiptSchemaVersionPrefix)
+		e.WriteUint8(rec.SchemaVersion)
+	}
+	e.Encode(&rec.Request)
+	e.EncodeOptional(rec.Error)
+	e.WriteCompactUint64(rec.GasBudget)
+	e.WriteCompactUint64(rec.GasBurned)
+	e.WriteCompactUint64(rec.GasFeeCharged.Uint64())
+	e.EncodeOptional(rec.GasBurnLog)
+	if rec.SchemaVersion >= receiptSchemaVersionAddedSponsor {
+		e.WriteOptionalFlag(rec.Sponsor != nil)
+		if rec.Sponsor != nil {
+			e.Encode(&rec.Sponsor)
+		}
+	}
+	if rec.SchemaVersion >= receiptSchemaVersionAddedFeeMultiplier {
+		e.EncodeOptional(rec.FeeMultiplier)
+	}
+	return nil
+}
+
+func (rec *RequestReceipt) UnmarshalBCS(d *bcs.Decoder) error {
+	first := d.ReadByte()
+	if first == receiptSchemaVersionPrefix {
+		rec.SchemaVersion = d.ReadUint8()
+	} else {
+		// the first byte belongs to the request
+		rec.SchemaVersion = receiptSchemaVersion0
+		d = bcs.NewDecoder(io.MultiReader(bytes.NewReader([]byte{first}), d))
+	}
+	d.Decode(&rec.Request)
+	_ = d.DecodeOptional(&rec.Error)
+	rec.GasBudget = d.ReadCompactUint64()
+	rec.GasBurned = d.ReadCompactUint64()
+	rec.GasFeeCharged = coin.Value(d.ReadCompactUint64())
+	_ = d.DecodeOptional(&rec.GasBurnLog)
+	if rec.SchemaVersion >= receiptSchemaVersionAddedSponsor {
+		if d.ReadOptionalFlag() {
+			d.Decode(&rec.Sponsor)
+		}
+	}
+	if rec.SchemaVersion >= receiptSchemaVersionAddedFeeMultiplier {
+		_ = d.DecodeOptional(&rec.FeeMultiplier)
+	}
+	return d.Err()
+}
+
 func RequestReceiptFromBytes(data []byte, blockIndex uint32, reqIndex uint16) (*RequestReceipt, error) {
 	return RequestReceiptFromReader(bytes.NewReader(data), blockIndex, reqIndex)
 }
@@ -57,6 +140,12 @@ func (rec *RequestReceipt) String() string {
 	ret += fmt.Sprintf("Err: %v\n", rec.Error)
 	ret += fmt.Sprintf("Block/Request index: %d / %d\n", rec.BlockIndex, rec.RequestIndex)
 	ret += fmt.Sprintf("Gas budget / burned / fee charged: %d / %d /%d\n", rec.GasBudget, rec.GasBurned, rec.GasFeeCharged)
+	if rec.Sponsor != nil {
+		ret += fmt.Sprintf("Sponsor: %s\n", rec.Sponsor)
+	}
+	if rec.FeeMultiplier != nil {
+		ret += fmt.Sprintf("Fee multiplier: %d\n", *rec.FeeMultiplier)
+	}
 	ret += fmt.Sprintf("Call data: %s\n", rec.Request)
 	ret += fmt.Sprintf("burn log: %s\n", rec.GasBurnLog)
 	return ret
@@ -92,6 +181,8 @@ func (rec *RequestReceipt) ToISCReceipt(resolvedError *isc.VMError) *isc.Receipt
 		RequestIndex:  rec.RequestIndex,
 		ResolvedError: resolvedError.Error(),
 		GasBurnLog:    rec.GasBurnLog,
+		Sponsor:       rec.Sponsor,
+		FeeMultiplier: rec.FeeMultiplier,
 	}
 }
 
@@ -11,12 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	bcs "github.com/iotaledger/bcs-go"
+	"github.com/iotaledger/wasp/v2/packages/coin"
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/isc/isctest"
 	"github.com/iotaledger/wasp/v2/packages/util"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
@@ -46,6 +48,86 @@ func TestReceiptCodec(t *testing.T) {
 			Params:    []isc.VMErrorParam{uint8(1), uint8(2), "string"},
 		},
 	}, "2e59447923e2")
+
+	// schema version 1 added the sponsor
+	bcs.TestCodec(t, blocklog.RequestReceipt{
+		SchemaVersion: 1,
+		Request: isc.NewOffLedgerRequest(
+			isctest.TestChainID,
+			isc.NewMessage(isc.Hn("account"), isc.Hn("deposit")),
+			123,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(cryptolib.TestKeyPair),
+		GasBudget:     1000,
+		GasBurned:     500,
+		GasFeeCharged: 50,
+		Sponsor:       isctest.NewRandomAgentID(),
+	})
+
+	bcs.TestCodec(t, blocklog.RequestReceipt{
+		SchemaVersion: blocklog.RequestReceiptLatestSchemaVersion,
+		Request: isc.NewOffLedgerRequest(
+			isctest.TestChainID,
+			isc.NewMessage(isc.Hn("account"), isc.Hn("deposit")),
+			123,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(cryptolib.TestKeyPair),
+		GasBudget:     1000,
+		GasBurned:     500,
+		GasFeeCharged: 50,
+		Sponsor:       isctest.NewRandomAgentID(),
+		FeeMultiplier: lo.ToPtr[uint32](5000),
+	})
+}
+
+// legacyRequestReceipt is the encoding of the receipts before the schema
+// version was added
+type legacyRequestReceipt struct {
+	Request       isc.Request
+	Error         *isc.UnresolvedVMError `bcs:"optional"`
+	GasBudget     uint64                 `bcs:"compact"`
+	GasBurned     uint64                 `bcs:"compact"`
+	GasFeeCharged coin.Value             `bcs:"compact"`
+	GasBurnLog    *gas.BurnLog           `bcs:"optional"`
+}
+
+func TestReceiptDecodeSchemaVersion0(t *testing.T) {
+	legacy := legacyRequestReceipt{
+		Request: isc.NewOffLedgerRequest(
+			isctest.TestChainID,
+			isc.NewMessage(isc.Hn("account"), isc.Hn("deposit")),
+			123,
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(cryptolib.TestKeyPair),
+		Error: &isc.UnresolvedVMError{
+			ErrorCode: blocklog.ErrBlockNotFound.Code(),
+			Params:    []isc.VMErrorParam{uint8(1), uint8(2), "string"},
+		},
+		GasBudget:     1000,
+		GasBurned:     500,
+		GasFeeCharged: 50,
+	}
+
+	rec, err := blocklog.RequestReceiptFromBytes(bcs.MustMarshal(&legacy), 3, 4)
+	require.NoError(t, err)
+	require.Zero(t, rec.SchemaVersion)
+	require.Equal(t, legacy.Request.ID(), rec.Request.ID())
+	require.Equal(t, legacy.Error, rec.Error)
+	require.EqualValues(t, 1000, rec.GasBudget)
+	require.EqualValues(t, 500, rec.GasBurned)
+	require.EqualValues(t, 50, rec.GasFeeCharged)
+	require.Nil(t, rec.Sponsor)
+	require.Nil(t, rec.FeeMultiplier)
+	require.EqualValues(t, 3, rec.BlockIndex)
+	require.EqualValues(t, 4, rec.RequestIndex)
+
+	// the receipts of schema version 0 are encoded as before
+	require.Equal(t, bcs.MustMarshal(&legacy), rec.Bytes())
+}
+
+func TestRequestReceiptSchemaVersion(t *testing.T) {
+	require.Zero(t, blocklog.RequestReceiptSchemaVersion(allmigrations.SchemaVersionGradualPruning))
+	require.EqualValues(t, blocklog.RequestReceiptLatestSchemaVersion, blocklog.RequestReceiptSchemaVersion(allmigrations.SchemaVersionVersionedReceipts))
 }
 
 func TestReceiptCodecEVM(t *testing.T) {
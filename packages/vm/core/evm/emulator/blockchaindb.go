@@ -41,7 +41,16 @@ const (
 	keyBlockNumberByTxHash    = "th:n" // covered in: TestStorageContract
 	keyBlockIndexByTxHash     = "th:i" // covered in: TestStorageContract
 
+	// pruning:
+
+	// all blocks before this one are already deleted
+	keyNextBlockToPrune = "p" // covered in: TestBlockchainDBPruneShrinkWindow
+
 	BlockKeepAll = -1
+
+	// maxBlocksToPrunePerBlock is the maximum amount of blocks deleted when
+	// minting a single block
+	maxBlocksToPrunePerBlock = 20
 )
 
 // BlockchainDB contains logic for storing a fake blockchain (more like a list of blocks),
@@ -50,6 +59,7 @@ type BlockchainDB struct {
 	kv              kv.KVStore
 	blockGasLimit   uint64
 	blockKeepAmount int32
+	gradualPruning  bool
 }
 
 func NewBlockchainDB(store kv.KVStore, blockGasLimit uint64, blockKeepAmount int32) *BlockchainDB {
@@ -60,6 +70,14 @@ func NewBlockchainDB(store kv.KVStore, blockGasLimit uint64, blockKeepAmount int
 	}
 }
 
+// EnableGradualPruning makes MintBlock keep track of the next block to prune,
+// so that the blocks left out of the window are deleted gradually when
+// blockKeepAmount is reduced. Without it, only the block that just left the
+// window is deleted.
+func (bc *BlockchainDB) EnableGradualPruning() {
+	bc.gradualPruning = true
+}
+
 func (bc *BlockchainDB) Initialized() bool {
 	return bc.kv.Get(keyChainID) != nil
 }
@@ -166,7 +184,7 @@ func (bc *BlockchainDB) AddTransaction(tx *types.Transaction, receipt *types.Rec
 	receiptArray.Push(evmtypes.EncodeReceipt(receipt))
 }
 
-func (bc *BlockchainDB) MintBlock(timestamp uint64) {
+func (bc *BlockchainDB) MintBlock(timestamp uint64) *types.Header {
 	blockNumber := bc.GetPendingBlockNumber()
 	header := bc.makeHeader(
 		bc.GetTransactionsByBlockNumber(blockNumber),
@@ -176,6 +194,7 @@ func (bc *BlockchainDB) MintBlock(timestamp uint64) {
 	)
 	bc.addBlock(header)
 	bc.prune(header.Number.Uint64())
+	return header
 }
 
 func (bc *BlockchainDB) prune(currentNumber uint64) {
@@ -187,9 +206,30 @@ func (bc *BlockchainDB) prune(currentNumber uint64) {
 		return
 	}
 	toDelete := currentNumber - uint64(bc.blockKeepAmount)
-	// assume that all blocks prior to `toDelete` have been already deleted, so
-	// we only need to delete this one.
-	bc.deleteBlock(toDelete)
+	if !bc.gradualPruning {
+		// assume that all blocks prior to `toDelete` have been already
+		// deleted, so we only need to delete this one.
+		bc.deleteBlock(toDelete)
+		return
+	}
+	var nextToDelete uint64
+	if data := bc.kv.Get(keyNextBlockToPrune); data != nil {
+		nextToDelete = codec.MustDecode[uint64](data)
+	} else {
+		// assume that all blocks prior to `toDelete` have been already deleted
+		nextToDelete = toDelete
+	}
+	if nextToDelete > toDelete {
+		// blockKeepAmount was increased, wait until the window is full again
+		return
+	}
+	// if blockKeepAmount was reduced, delete the blocks out of the window
+	// gradually
+	last := min(toDelete, nextToDelete+maxBlocksToPrunePerBlock-1)
+	for blockNumber := nextToDelete; blockNumber <= last; blockNumber++ {
+		bc.deleteBlock(blockNumber)
+	}
+	bc.kv.Set(keyNextBlockToPrune, codec.Encode(last+1))
 }
 
 func (bc *BlockchainDB) deleteBlock(blockNumber uint64) {
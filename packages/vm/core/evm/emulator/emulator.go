@@ -95,6 +95,12 @@ func getConfig(chainID int) *params.ChainConfig {
 	return c
 }
 
+// ActivePrecompiles returns the addresses of the precompiled contracts
+// enabled in the EVM. The set of precompiles does not depend on the chain ID.
+func ActivePrecompiles() []common.Address {
+	return vm.ActivePrecompiles(getConfig(0).Rules(common.Big0, true, 0))
+}
+
 const (
 	keyStateDB      = "s"
 	keyBlockchainDB = "b"
@@ -108,6 +114,12 @@ func StateDBSubrealmR(store kv.KVStoreReader) kv.KVStoreReader {
 	return subrealm.NewReadOnly(store, keyStateDB)
 }
 
+// StateDBKey returns the key under which the StateDB stores the given key,
+// relative to the emulator state
+func StateDBKey(key kv.Key) kv.Key {
+	return keyStateDB + key
+}
+
 func BlockchainDBSubrealm(store kv.KVStore) kv.KVStore {
 	return subrealm.New(store, keyBlockchainDB)
 }
@@ -208,7 +220,18 @@ func coreMsgFromCallMsg(call ethereum.CallMsg, gasEstimateMode bool, statedb *St
 }
 
 // CallContract executes a contract call, without committing changes to the state
-func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool) (*core.ExecutionResult, error) {
+func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool, tracer *tracing.Hooks) (*core.ExecutionResult, error) {
+	// don't commit changes to state
+	i := e.ctx.TakeSnapshot()
+	defer e.ctx.RevertToSnapshot(i)
+
+	return e.ApplyCall(call, gasEstimateMode, tracer)
+}
+
+// ApplyCall executes a contract call, keeping the changes to the state.
+// It is meant to be used when the whole state is going to be discarded
+// afterwards, e.g. when simulating a sequence of calls.
+func (e *EVMEmulator) ApplyCall(call ethereum.CallMsg, gasEstimateMode bool, tracer *tracing.Hooks) (*core.ExecutionResult, error) {
 	// Ensure message is initialized properly.
 	if call.Gas == 0 {
 		call.Gas = e.ctx.GasLimits().Call
@@ -219,17 +242,17 @@ func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool)
 
 	pendingHeader := e.BlockchainDB().GetPendingHeader(e.ctx.Timestamp())
 
-	statedb := e.StateDB()
-
-	// don't commit changes to state
-	i := statedb.Snapshot()
-	defer statedb.RevertToSnapshot(i)
+	statedbImpl := e.StateDB()
+	var statedb vm.StateDB = statedbImpl
+	if tracer != nil {
+		statedb = NewHookedState(statedbImpl, tracer)
+	}
 
 	return e.applyMessage(
-		coreMsgFromCallMsg(call, gasEstimateMode, statedb),
+		coreMsgFromCallMsg(call, gasEstimateMode, statedbImpl),
 		statedb,
 		pendingHeader,
-		nil,
+		tracer,
 		nil,
 	)
 }
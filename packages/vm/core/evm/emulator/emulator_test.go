@@ -77,7 +77,7 @@ func estimateGas(callMsg ethereum.CallMsg, e *EVMEmulator) (uint64, error) {
 	var lastErr error
 	for hi >= lo {
 		callMsg.Gas = (lo + hi) / 2
-		res, err := e.CallContract(callMsg, true)
+		res, err := e.CallContract(callMsg, true, nil)
 		if err != nil {
 			return 0, fmt.Errorf("CallContract failed: %w", err)
 		}
@@ -458,7 +458,7 @@ func TestStorageContract(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, callArguments)
 
-		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -486,7 +486,7 @@ func TestStorageContract(t *testing.T) {
 		res, err := emu.CallContract(ethereum.CallMsg{
 			To:   &contractAddress,
 			Data: callArguments,
-		}, false)
+		}, false, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -530,7 +530,7 @@ func TestERC20Contract(t *testing.T) {
 		callArguments, err2 := contractABI.Pack(name, args...)
 		require.NoError(t, err2)
 
-		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil)
 		require.NoError(t, err2)
 
 		v := new(big.Int)
@@ -724,3 +724,50 @@ func dbSize(db kv.KVStore) float64 {
 	})
 	return r
 }
+
+func TestBlockchainDBPruneShrinkWindow(t *testing.T) {
+	store := dict.New()
+	timestamp := uint64(time.Now().Unix())
+
+	bc := NewBlockchainDB(store, gasLimits.Block, 100)
+	bc.EnableGradualPruning()
+	bc.Init(evm.DefaultChainID, timestamp)
+	for range 150 {
+		timestamp++
+		bc.MintBlock(timestamp)
+	}
+	require.EqualValues(t, 150, bc.GetNumber())
+	require.Nil(t, bc.getHeaderByBlockNumber(50))
+	require.NotNil(t, bc.getHeaderByBlockNumber(51))
+
+	// shrink the window: the blocks out of the window are deleted gradually
+	bc = NewBlockchainDB(store, gasLimits.Block, 10)
+	bc.EnableGradualPruning()
+	timestamp++
+	bc.MintBlock(timestamp)
+	require.Nil(t, bc.getHeaderByBlockNumber(51+maxBlocksToPrunePerBlock-1))
+	require.NotNil(t, bc.getHeaderByBlockNumber(51+maxBlocksToPrunePerBlock))
+	for range 10 {
+		timestamp++
+		bc.MintBlock(timestamp)
+	}
+	require.EqualValues(t, 161, bc.GetNumber())
+	for i := uint64(0); i <= 151; i++ {
+		require.Nil(t, bc.getHeaderByBlockNumber(i), "block %d", i)
+	}
+	require.NotNil(t, bc.getHeaderByBlockNumber(152))
+
+	// grow the window: no blocks are deleted until the window is full
+	bc = NewBlockchainDB(store, gasLimits.Block, 20)
+	bc.EnableGradualPruning()
+	for range 10 {
+		timestamp++
+		bc.MintBlock(timestamp)
+	}
+	require.EqualValues(t, 171, bc.GetNumber())
+	require.NotNil(t, bc.getHeaderByBlockNumber(152))
+	timestamp++
+	bc.MintBlock(timestamp)
+	require.Nil(t, bc.getHeaderByBlockNumber(152))
+	require.NotNil(t, bc.getHeaderByBlockNumber(153))
+}
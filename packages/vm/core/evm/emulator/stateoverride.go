@@ -0,0 +1,68 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package emulator
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/v2/packages/kv"
+)
+
+// ApplyStateOverride applies the given overrides to the StateDB partition.
+// The account balances are not stored in the StateDB, so balance overrides
+// are applied by calling setBalance.
+func ApplyStateOverride(
+	stateDB kv.KVStore,
+	override evmutil.StateOverride,
+	setBalance func(addr common.Address, balance *big.Int),
+) error {
+	if err := override.Validate(); err != nil {
+		return err
+	}
+	for addr, account := range override {
+		if account == nil {
+			continue
+		}
+		if !Exist(addr, stateDB) {
+			CreateAccount(stateDB, addr)
+		}
+		if account.Nonce != nil {
+			SetNonce(stateDB, addr, *account.Nonce)
+		}
+		if account.Code != nil {
+			if len(account.Code) == 0 {
+				SetCode(stateDB, addr, nil)
+			} else {
+				SetCode(stateDB, addr, account.Code)
+			}
+		}
+		if account.State != nil {
+			clearStorage(stateDB, addr)
+			for key, value := range account.State {
+				SetState(stateDB, addr, key, value)
+			}
+		}
+		for key, value := range account.StateDiff {
+			SetState(stateDB, addr, key, value)
+		}
+		if account.Balance != nil {
+			setBalance(addr, account.Balance)
+		}
+	}
+	return nil
+}
+
+func clearStorage(stateDB kv.KVStore, addr common.Address) {
+	var keys []kv.Key
+	stateDB.IterateKeys(accountKey(keyAccountState, addr), func(key kv.Key) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for _, k := range keys {
+		stateDB.Del(k)
+	}
+}
@@ -197,8 +197,11 @@ func tryGetRevertError(res *core.ExecutionResult) error {
 	return res.Err
 }
 
-// callContract is called from the jsonrpc eth_estimateGas and eth_call endpoints.
-// The VM is in estimate gas mode, and any state mutations are discarded.
+// callContract is called from the jsonrpc eth_estimateGas, eth_call and
+// eth_simulateV1 endpoints.
+// In estimate gas mode the state draft is never committed, so the state
+// mutations are kept until the end of the task, so that subsequent simulated
+// calls can observe them. Otherwise, any state mutations are discarded.
 func callContract(ctx isc.Sandbox, callMsg ethereum.CallMsg) []byte {
 	cannotBeCalledFromContracts(ctx)
 
@@ -210,7 +213,11 @@ func callContract(ctx isc.Sandbox, callMsg ethereum.CallMsg) []byte {
 	ctx.RequireCaller(isc.NewEthereumAddressAgentID(callMsg.From))
 
 	emu := createEmulator(ctx)
-	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode())
+	applyCall := emu.CallContract
+	if ctx.Gas().EstimateGasMode() {
+		applyCall = emu.ApplyCall
+	}
+	res, err := applyCall(callMsg, ctx.Gas().EstimateGasMode(), getTracer(ctx))
 	ctx.RequireNoError(err)
 	ctx.RequireNoError(tryGetRevertError(res))
 
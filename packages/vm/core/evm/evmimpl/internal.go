@@ -20,14 +20,20 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/emulator"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/migrations/allmigrations"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 // MintBlock "mints" the Ethereum block after all requests in the ISC
 // block have been processed.
-// IMPORTANT: Must only be called from the ISC VM
-func MintBlock(evmPartition kv.KVStore, chainInfo *isc.ChainInfo, blockTimestamp time.Time) {
-	createBlockchainDB(evmPartition, chainInfo).MintBlock(timestamp(blockTimestamp))
+// IMPORTANT: Must only be called from the ISC VM, or on a throwaway state
+// when simulating blocks.
+func MintBlock(evmPartition kv.KVStore, chainInfo *isc.ChainInfo, v isc.SchemaVersion, blockTimestamp time.Time) *types.Header {
+	bc := createBlockchainDB(evmPartition, chainInfo)
+	if v >= allmigrations.SchemaVersionGradualPruning {
+		bc.EnableGradualPruning()
+	}
+	return bc.MintBlock(timestamp(blockTimestamp))
 }
 
 func getTracer(ctx isc.Sandbox) *tracing.Hooks {
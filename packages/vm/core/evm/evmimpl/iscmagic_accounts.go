@@ -6,9 +6,11 @@ package evmimpl
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/samber/lo"
 
 	"github.com/iotaledger/wasp/v2/packages/coin"
+	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/iscmagic"
@@ -31,6 +33,18 @@ func (h *magicContractHandler) GetL2BalanceCoin(
 	return lo.Must(accounts.ViewBalanceCoin.DecodeOutput(r))
 }
 
+// handler for ISCAccounts::getL2LockedBalanceCoin
+func (h *magicContractHandler) GetL2LockedBalanceCoin(
+	coinType iscmagic.CoinType,
+	agentID iscmagic.ISCAgentID,
+) (coin.Value, coin.Value) {
+	aid := lo.Must(agentID.Unwrap())
+	r := h.callView(accounts.ViewLockedBalance.Message(&aid))
+	locked, releasable := lo.Must2(accounts.ViewLockedBalance.DecodeOutput(r))
+	t := coin.MustTypeFromString(coinType)
+	return locked.Get(t), releasable.Get(t)
+}
+
 // handler for ISCAccounts::getL2Objects
 func (h *magicContractHandler) GetL2Objects(agentID iscmagic.ISCAgentID) []isc.IotaObject {
 	aid := lo.Must(agentID.Unwrap())
@@ -43,3 +57,23 @@ func (h *magicContractHandler) GetL2ObjectCount(agentID iscmagic.ISCAgentID) *bi
 	// TODO: avoid fetching the whole list of objects
 	return new(big.Int).SetUint64(uint64(len(h.GetL2Objects(agentID))))
 }
+
+// handler for ISCAccounts::withdrawBatch
+func (h *magicContractHandler) WithdrawBatch(withdrawals []iscmagic.ISCWithdrawal) {
+	total := isc.NewEmptyAssets()
+	batch := make([]*accounts.Withdrawal, len(withdrawals))
+	for i, w := range withdrawals {
+		batch[i] = &accounts.Withdrawal{
+			TargetAddress: cryptolib.NewAddressFromIota(&w.TargetAddress),
+			Assets:        w.Assets.Unwrap(),
+		}
+		total.Add(batch[i].Assets)
+	}
+
+	h.call(accounts.FuncWithdrawBatch.Message(batch), total)
+
+	// emit ERC20 events for coin transfers
+	for _, log := range makeTransferEvents(h.ctx, h.caller, common.Address{}, total) {
+		h.evm.StateDB.AddLog(log)
+	}
+}
@@ -82,7 +82,7 @@ func (e *EVMContractInstance) parseEthCallOptions(opts []ethCallOptions, callDat
 			GasPrice: opt.gasPrice,
 			Value:    opt.value,
 			Data:     callData,
-		}, nil)
+		}, nil, nil)
 		if err != nil {
 			return opt, fmt.Errorf("error estimating gas limit: %w", e.chain.resolveError(err))
 		}
@@ -172,7 +172,7 @@ func (e *EVMContractInstance) callView(fnName string, args []interface{}, v inte
 	if len(blockNumberOrHash) > 0 {
 		bn = &blockNumberOrHash[0]
 	}
-	ret, err := e.chain.evmChain.CallContract(callMsg, bn)
+	ret, err := e.chain.evmChain.CallContract(callMsg, bn, nil)
 	if err != nil {
 		return err
 	}
@@ -44,6 +44,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/evm/iscmagic"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/testcore/contracts/inccounter"
 	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
@@ -326,7 +327,7 @@ func TestEstimateGasWithoutFunds(t *testing.T) {
 		From: common.Address{},
 		To:   &iscTest.address,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 	t.Log(estimatedGas)
@@ -343,7 +344,7 @@ func TestLoopWithGasLeftEstimateGas(t *testing.T) {
 		From: ethAddr,
 		To:   &iscTest.address,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 	t.Log(estimatedGas)
@@ -385,7 +386,7 @@ func TestEstimateContractGas(t *testing.T) {
 	estimatedGas, err := env.evmChain.EstimateGas(ethereum.CallMsg{
 		From: contract.address,
 		To:   &ethAddr,
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 	require.NotZero(t, estimatedGas)
 }
@@ -403,7 +404,7 @@ func TestCallViewGasLimit(t *testing.T) {
 		Gas:  math.MaxUint64,
 		Data: callArguments,
 	})
-	_, err = loop.chain.evmChain.CallContract(callMsg, nil)
+	_, err = loop.chain.evmChain.CallContract(callMsg, nil, nil)
 	require.Contains(t, err.Error(), "out of gas")
 }
 
@@ -463,6 +464,29 @@ func TestISCCallView(t *testing.T) {
 	require.NotEmpty(t, ret)
 }
 
+func TestISCScheduleJobFromEOA(t *testing.T) {
+	env := InitEVM(t)
+	ethKey, _ := env.Chain.NewEthereumAccountWithL2Funds()
+
+	// only contracts and the chain admin can schedule jobs
+	_, err := env.ISCMagicSandbox(ethKey).CallFn(
+		[]ethCallOptions{{sender: ethKey, gasLimit: 200_000}},
+		"call",
+		iscmagic.WrapISCMessage(governance.FuncScheduleJob.Message(
+			accounts.FuncDeposit.Message(),
+			env.Chain.LatestBlockIndex()+2,
+			1,
+			gas.LimitsDefault.MinGasPerRequest*10,
+		)),
+		iscmagic.WrapISCAssets(isc.NewEmptyAssets()),
+	)
+	require.ErrorContains(t, err, "unauthorized access")
+
+	ret, err := env.Chain.CallView(governance.ViewGetScheduledJobs.Message())
+	require.NoError(t, err)
+	require.Empty(t, lo.Must(governance.ViewGetScheduledJobs.DecodeOutput(ret)))
+}
+
 func TestISCTriggerEvent(t *testing.T) {
 	env := InitEVM(t)
 	ethKey, _ := env.Chain.NewEthereumAccountWithL2Funds()
@@ -625,6 +649,33 @@ func TestSendPayableValueTX(t *testing.T) {
 	env.Chain.CheckAccountLedger()
 }
 
+func TestWithdrawBatch(t *testing.T) {
+	env := InitEVM(t)
+
+	ethKey, senderEthAddress := env.Chain.NewEthereumAccountWithL2Funds()
+	_, receiver1 := env.solo.NewKeyPair()
+	_, receiver2 := env.solo.NewKeyPair()
+	senderInitialBalance := env.Chain.L2BaseTokens(isc.NewEthereumAddressAgentID(senderEthAddress))
+
+	res, err := env.ISCMagicAccounts(ethKey).CallFn(
+		[]ethCallOptions{{sender: ethKey, gasLimit: 200_000}},
+		"withdrawBatch",
+		[]iscmagic.ISCWithdrawal{
+			{TargetAddress: *receiver1.AsIotaAddress(), Assets: iscmagic.WrapISCAssets(isc.NewAssets(1 * isc.Million))},
+			{TargetAddress: *receiver2.AsIotaAddress(), Assets: iscmagic.WrapISCAssets(isc.NewAssets(2 * isc.Million))},
+		},
+	)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 1*isc.Million, env.solo.L1BaseTokens(receiver1))
+	require.EqualValues(t, 2*isc.Million, env.solo.L1BaseTokens(receiver2))
+	require.EqualValues(t, senderInitialBalance-3*isc.Million-res.ISCReceipt.GasFeeCharged, env.Chain.L2BaseTokens(isc.NewEthereumAddressAgentID(senderEthAddress)))
+	// L2 balance of ISC magic contract (0x1074...) is 0
+	require.Zero(t, env.Chain.L2BaseTokens(isc.NewEthereumAddressAgentID(iscmagic.Address)))
+
+	env.Chain.CheckAccountLedger()
+}
+
 func TestSendBaseTokens(t *testing.T) {
 	env := InitEVM(t)
 
@@ -1233,7 +1284,7 @@ func TestSendEntireBalance(t *testing.T) {
 		To:    &someEthereumAddr,
 		Value: currentBalanceInEthDecimals,
 		Data:  []byte{},
-	}, nil)
+	}, nil, nil)
 	require.NoError(t, err)
 
 	feePolicy := env.Chain.GetGasFeePolicy()
@@ -1268,7 +1319,7 @@ func TestSolidityRevertMessage(t *testing.T) {
 		To:   &iscTest.address,
 		Gas:  100_000,
 		Data: callData,
-	}, nil)
+	}, nil, nil)
 	require.ErrorContains(t, err, "execution reverted")
 
 	revertData, err := evmerrors.ExtractRevertData(err)
@@ -29,6 +29,20 @@ interface ISCAccounts {
         ISCAgentID memory agentID
     ) external view returns (uint64);
 
+    /**
+     * @notice Retrieves the locked and the releasable L2 balance of a given coin type
+     *         for a given ISC Agent ID. The locked coins are part of the balance, but
+     *         cannot be spent until they are released by their vesting schedule.
+     * @param coinType The type of the coin as a string.
+     * @param agentID The ISC Agent ID whose L2 coin balance is to be queried.
+     * @return locked The locked L2 balance of the given coin type.
+     * @return releasable The L2 balance of the given coin type that can be spent.
+     */
+    function getL2LockedBalanceCoin(
+        string memory coinType,
+        ISCAgentID memory agentID
+    ) external view returns (uint64 locked, uint64 releasable);
+
     /**
      * @notice Retrieves a list of Objects owned by a given ISC Agent ID.
      * @param agentID The ISC Agent ID whose L2 objects are to be queried.
@@ -46,6 +60,13 @@ interface ISCAccounts {
      */
     function getL2ObjectsCount(ISCAgentID memory agentID) external view
         returns (uint256);
+
+    /**
+     * @notice Sends assets from the caller's L2 account to several L1 addresses,
+     *         in a single request.
+     * @param withdrawals The target L1 addresses and the assets sent to each of them.
+     */
+    function withdrawBatch(ISCWithdrawal[] memory withdrawals) external;
 }
 
 ISCAccounts constant __iscAccounts = ISCAccounts(ISC_MAGIC_ADDRESS);
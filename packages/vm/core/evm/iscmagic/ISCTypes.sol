@@ -89,6 +89,12 @@ struct IotaObject {
     string objectType;
 }
 
+/// A single transfer to L1 of ISCAccounts::withdrawBatch
+struct ISCWithdrawal {
+    IotaAddress targetAddress;
+    ISCAssets assets;
+}
+
 /**
  * @title ISCTypes
  * @notice Collection of utility functions used in the ISC system
@@ -85,6 +85,12 @@ func (a ISCAssets) Unwrap() *isc.Assets {
 	return assets
 }
 
+// ISCWithdrawal matches the struct definition in ISCTypes.sol
+type ISCWithdrawal struct {
+	TargetAddress iotago.Address
+	Assets        ISCAssets
+}
+
 // ISCDictItem matches the struct definition in ISCTypes.sol
 type ISCDictItem struct {
 	Key   []byte
@@ -40,3 +40,9 @@ func ISCMagicSubrealm(evmPartition kv.KVStore) kv.KVStore {
 func ISCMagicSubrealmR(evmPartition kv.KVStoreReader) kv.KVStoreReader {
 	return subrealm.NewReadOnly(evmPartition, keyISCMagic)
 }
+
+// EmulatorStateKey returns the chain state key under which the given key of
+// the emulator state is stored
+func EmulatorStateKey(key kv.Key) kv.Key {
+	return kv.Key(Contract.FullKey([]byte(keyEmulatorState + key)))
+}
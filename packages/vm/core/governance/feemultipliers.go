@@ -0,0 +1,63 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governance
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/kv/collections"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
+)
+
+// FeeMultiplier is a discount on the gas fee granted by the chain admin to an
+// agent. The multiplier is expressed in basis points: 0 means that the agent
+// does not pay any fee, and gas.GasPriceMultiplierBase means the full fee.
+type FeeMultiplier struct {
+	AgentID    isc.AgentID
+	Multiplier uint32
+}
+
+func (s *StateWriter) feeMultipliersMap() *collections.Map {
+	return collections.NewMap(s.state, varFeeMultipliers)
+}
+
+func (s *StateReader) feeMultipliersMap() *collections.ImmutableMap {
+	return collections.NewMapReadOnly(s.state, varFeeMultipliers)
+}
+
+// GetFeeMultiplier returns the multiplier applied to the gas fee of the
+// agent, which is gas.GasPriceMultiplierBase if the agent has no discount
+func (s *StateReader) GetFeeMultiplier(agentID isc.AgentID) uint32 {
+	return codec.MustDecode[uint32](s.feeMultipliersMap().GetAt(agentID.Bytes()), gas.GasPriceMultiplierBase)
+}
+
+func (s *StateReader) HasFeeMultiplier(agentID isc.AgentID) bool {
+	return s.feeMultipliersMap().HasAt(agentID.Bytes())
+}
+
+func (s *StateReader) NumFeeMultipliers() uint32 {
+	return s.feeMultipliersMap().Len()
+}
+
+func (s *StateReader) GetFeeMultipliers() []*FeeMultiplier {
+	ret := []*FeeMultiplier{}
+	s.feeMultipliersMap().Iterate(func(agentIDBytes []byte, multiplierBytes []byte) bool {
+		ret = append(ret, &FeeMultiplier{
+			AgentID:    codec.MustDecode[isc.AgentID](agentIDBytes),
+			Multiplier: codec.MustDecode[uint32](multiplierBytes),
+		})
+		return true
+	})
+	return ret
+}
+
+// SetFeeMultiplier sets the multiplier of the gas fee of the agent. Setting
+// it to gas.GasPriceMultiplierBase removes the agent from the list.
+func (s *StateWriter) SetFeeMultiplier(agentID isc.AgentID, multiplier uint32) {
+	if multiplier == gas.GasPriceMultiplierBase {
+		s.feeMultipliersMap().DelAt(agentID.Bytes())
+		return
+	}
+	s.feeMultipliersMap().SetAt(agentID.Bytes(), codec.Encode(multiplier))
+}
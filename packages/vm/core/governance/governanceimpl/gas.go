@@ -18,13 +18,19 @@ func setFeePolicy(ctx isc.Sandbox, fp *gas.FeePolicy) {
 	state.SetGasFeePolicy(fp)
 }
 
-// getFeePolicy returns fee policy in serialized form
+// getFeePolicy returns the fee policy in effect in the current block, with
+// the dynamic gas price adjustment, if enabled
 func getFeePolicy(ctx isc.SandboxView) *gas.FeePolicy {
 	state := governance.NewStateReaderFromSandbox(ctx)
 	return state.GetGasFeePolicy()
 }
 
-var errInvalidGasRatio = coreerrors.Register("invalid gas ratio").Create()
+var (
+	errInvalidGasRatio              = coreerrors.Register("invalid gas ratio").Create()
+	errInvalidDynamicGasPricePolicy = coreerrors.Register("invalid dynamic gas price policy").Create()
+	errInvalidFeeMultiplier         = coreerrors.Register("invalid fee multiplier %d")
+	errTooManyFeeMultipliers        = coreerrors.Register("too many fee multipliers (max %d)")
+)
 
 func setEVMGasRatio(ctx isc.Sandbox, ratio util.Ratio32) {
 	ctx.RequireCallerIsChainAdmin()
@@ -32,7 +38,7 @@ func setEVMGasRatio(ctx isc.Sandbox, ratio util.Ratio32) {
 		panic(errInvalidGasRatio)
 	}
 	state := governance.NewStateWriterFromSandbox(ctx)
-	policy := state.GetGasFeePolicy()
+	policy := state.GetBaseGasFeePolicy()
 	policy.EVMGasRatio = ratio
 	state.SetGasFeePolicy(policy)
 }
@@ -52,3 +58,54 @@ func getGasLimits(ctx isc.SandboxView) *gas.Limits {
 	state := governance.NewStateReaderFromSandbox(ctx)
 	return state.GetGasLimits()
 }
+
+// setDynamicGasPricePolicy enables the dynamic gas price with the given
+// policy, or disables it if the policy is not set
+func setDynamicGasPricePolicy(ctx isc.Sandbox, policyOpt **gas.DynamicGasPricePolicy) {
+	ctx.RequireCallerIsChainAdmin()
+	var policy *gas.DynamicGasPricePolicy
+	if policyOpt != nil {
+		policy = *policyOpt
+		if !policy.IsValid() {
+			panic(errInvalidDynamicGasPricePolicy)
+		}
+	}
+	state := governance.NewStateWriterFromSandbox(ctx)
+	state.SetDynamicGasPricePolicy(policy)
+}
+
+// getDynamicGasPrice returns the dynamic gas price policy, if enabled, and
+// the gas price multiplier of the current block
+func getDynamicGasPrice(ctx isc.SandboxView) (**gas.DynamicGasPricePolicy, uint32) {
+	state := governance.NewStateReaderFromSandbox(ctx)
+	policy := state.GetDynamicGasPricePolicy()
+	if policy == nil {
+		return nil, state.GetGasPriceMultiplier()
+	}
+	return &policy, state.GetGasPriceMultiplier()
+}
+
+// setFeeMultiplier sets a discount on the gas fee paid by the agent, in basis
+// points. Setting it to gas.GasPriceMultiplierBase removes the discount.
+func setFeeMultiplier(ctx isc.Sandbox, agentID isc.AgentID, multiplier uint32) {
+	ctx.RequireCallerIsChainAdmin()
+	if multiplier > gas.GasPriceMultiplierBase {
+		panic(errInvalidFeeMultiplier.Create(multiplier))
+	}
+	state := governance.NewStateWriterFromSandbox(ctx)
+	if multiplier != gas.GasPriceMultiplierBase && !state.HasFeeMultiplier(agentID) &&
+		state.NumFeeMultipliers() >= governance.MaxFeeMultipliers {
+		panic(errTooManyFeeMultipliers.Create(uint32(governance.MaxFeeMultipliers)))
+	}
+	state.SetFeeMultiplier(agentID, multiplier)
+}
+
+func getFeeMultiplier(ctx isc.SandboxView, agentID isc.AgentID) uint32 {
+	state := governance.NewStateReaderFromSandbox(ctx)
+	return state.GetFeeMultiplier(agentID)
+}
+
+func getFeeMultipliers(ctx isc.SandboxView) []*governance.FeeMultiplier {
+	state := governance.NewStateReaderFromSandbox(ctx)
+	return state.GetFeeMultipliers()
+}
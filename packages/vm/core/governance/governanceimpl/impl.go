@@ -20,6 +20,11 @@ var Processor = governance.Contract.Processor(nil,
 	// fees
 	governance.FuncSetFeePolicy.WithHandler(setFeePolicy),
 	governance.ViewGetFeePolicy.WithHandler(getFeePolicy),
+	governance.FuncSetDynamicGasPricePolicy.WithHandler(setDynamicGasPricePolicy),
+	governance.ViewGetDynamicGasPrice.WithHandler(getDynamicGasPrice),
+	governance.FuncSetFeeMultiplier.WithHandler(setFeeMultiplier),
+	governance.ViewGetFeeMultiplier.WithHandler(getFeeMultiplier),
+	governance.ViewGetFeeMultipliers.WithHandler(getFeeMultipliers),
 	governance.FuncSetEVMGasRatio.WithHandler(setEVMGasRatio),
 	governance.ViewGetEVMGasRatio.WithHandler(getEVMGasRatio),
 	governance.FuncSetGasLimits.WithHandler(setGasLimits),
@@ -39,7 +44,16 @@ var Processor = governance.Contract.Processor(nil,
 	governance.FuncStopMaintenance.WithHandler(stopMaintenance),
 	governance.ViewGetMaintenanceStatus.WithHandler(getMaintenanceStatus),
 
+	// state pruning
+	governance.FuncSetBlockKeepAmount.WithHandler(setBlockKeepAmount),
+	governance.ViewGetBlockKeepAmount.WithHandler(getBlockKeepAmount),
+
 	// L1 metadata
 	governance.FuncSetMetadata.WithHandler(setMetadata),
 	governance.ViewGetMetadata.WithHandler(getMetadata),
+
+	// scheduled jobs
+	governance.FuncScheduleJob.WithHandler(scheduleJob),
+	governance.FuncCancelJob.WithHandler(cancelJob),
+	governance.ViewGetScheduledJobs.WithHandler(getScheduledJobs),
 )
@@ -0,0 +1,29 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governanceimpl
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/errors/coreerrors"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+)
+
+var errInvalidBlockKeepAmount = coreerrors.Register("invalid block keep amount %d")
+
+// setBlockKeepAmount changes the amount of blocks kept in the blocklog and in
+// the EVM blockchain. The change takes effect from the next block; if the
+// amount is reduced, the blocks out of the new window are pruned gradually.
+func setBlockKeepAmount(ctx isc.Sandbox, n int32) {
+	ctx.RequireCallerIsChainAdmin()
+	if n != governance.BlockKeepAll && n < governance.MinBlockKeepAmount {
+		panic(errInvalidBlockKeepAmount.Create(n))
+	}
+	state := governance.NewStateWriterFromSandbox(ctx)
+	state.SetBlockKeepAmount(n)
+}
+
+func getBlockKeepAmount(ctx isc.SandboxView) int32 {
+	state := governance.NewStateReaderFromSandbox(ctx)
+	return state.GetBlockKeepAmount()
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governanceimpl
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/vm"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/errors/coreerrors"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+)
+
+var (
+	errScheduledJobNotFound     = coreerrors.Register("scheduled job %d not found")
+	errTooManyScheduledJobs     = coreerrors.Register("too many scheduled jobs (max %d)")
+	errInvalidScheduledJobBlock = coreerrors.Register("invalid block index %d for scheduled job")
+)
+
+// scheduleJob registers a call to be executed by the VM at the start of the
+// given block, and then every interval blocks if interval > 0. The call is
+// executed on behalf of the caller, who pays the gas: the gas cannot be paid
+// from another account, as it would allow to drain it without its consent.
+// Only contracts and the chain admin can schedule jobs, so that no user can
+// fill the MaxScheduledJobs slots.
+func scheduleJob(ctx isc.Sandbox, msg isc.Message, blockIndex uint32, interval uint32, gasBudget uint64) uint32 {
+	caller := ctx.Caller()
+	switch {
+	case caller.Kind() == isc.AgentIDKindContract:
+	case caller.Equals(ctx.ChainAdmin()):
+	default:
+		panic(vm.ErrUnauthorized)
+	}
+	if blockIndex <= ctx.StateIndex() {
+		panic(errInvalidScheduledJobBlock.Create(blockIndex))
+	}
+	ctx.Requiref(gasBudget > 0, "gas budget must be greater than 0")
+
+	state := governance.NewStateWriterFromSandbox(ctx)
+	if state.NumScheduledJobs() >= governance.MaxScheduledJobs {
+		panic(errTooManyScheduledJobs.Create(uint32(governance.MaxScheduledJobs)))
+	}
+	return state.AddScheduledJob(&governance.ScheduledJob{
+		Owner:          caller,
+		Message:        msg,
+		GasBudget:      gasBudget,
+		NextBlockIndex: blockIndex,
+		Interval:       interval,
+	})
+}
+
+// cancelJob removes a scheduled job. Only the owner of the job and the chain
+// admin can cancel it.
+func cancelJob(ctx isc.Sandbox, id uint32) {
+	state := governance.NewStateWriterFromSandbox(ctx)
+	job := state.GetScheduledJob(id)
+	if job == nil {
+		panic(errScheduledJobNotFound.Create(id))
+	}
+	if !ctx.Caller().Equals(job.Owner) {
+		ctx.RequireCallerIsChainAdmin()
+	}
+	state.DeleteScheduledJob(id)
+}
+
+func getScheduledJobs(ctx isc.SandboxView) []*governance.ScheduledJob {
+	state := governance.NewStateReaderFromSandbox(ctx)
+	return state.GetScheduledJobs()
+}
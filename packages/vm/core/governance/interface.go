@@ -52,6 +52,24 @@ var (
 	ViewGetGasLimits = coreutil.NewViewEP01(Contract, "getGasLimits",
 		coreutil.Field[*gas.Limits]("gasLimits"),
 	)
+	FuncSetDynamicGasPricePolicy = coreutil.NewEP1(Contract, "setDynamicGasPricePolicy",
+		coreutil.FieldOptional[*gas.DynamicGasPricePolicy]("dynamicGasPricePolicy"),
+	)
+	ViewGetDynamicGasPrice = coreutil.NewViewEP02(Contract, "getDynamicGasPrice",
+		coreutil.FieldOptional[*gas.DynamicGasPricePolicy]("dynamicGasPricePolicy"),
+		coreutil.Field[uint32]("gasPriceMultiplier"),
+	)
+	FuncSetFeeMultiplier = coreutil.NewEP2(Contract, "setFeeMultiplier",
+		coreutil.Field[isc.AgentID]("agentID"),
+		coreutil.Field[uint32]("feeMultiplier"),
+	)
+	ViewGetFeeMultiplier = coreutil.NewViewEP11(Contract, "getFeeMultiplier",
+		coreutil.Field[isc.AgentID]("agentID"),
+		coreutil.Field[uint32]("feeMultiplier"),
+	)
+	ViewGetFeeMultipliers = coreutil.NewViewEP01(Contract, "getFeeMultipliers",
+		coreutil.Field[[]*FeeMultiplier]("feeMultipliers"),
+	)
 
 	// evm fees
 	FuncSetEVMGasRatio = coreutil.NewEP1(Contract, "setEVMGasRatio",
@@ -92,6 +110,14 @@ var (
 		coreutil.Field[bool]("isMaintenance"),
 	)
 
+	// state pruning
+	FuncSetBlockKeepAmount = coreutil.NewEP1(Contract, "setBlockKeepAmount",
+		coreutil.Field[int32]("blockKeepAmount"),
+	)
+	ViewGetBlockKeepAmount = coreutil.NewViewEP01(Contract, "getBlockKeepAmount",
+		coreutil.Field[int32]("blockKeepAmount"),
+	)
+
 	// public chain metadata
 	FuncSetMetadata = coreutil.NewEP2(Contract, "setMetadata",
 		coreutil.FieldOptional[string]("publicURL"),
@@ -101,6 +127,21 @@ var (
 		coreutil.Field[string]("publicURL"),
 		coreutil.Field[*isc.PublicChainMetadata]("metadata"),
 	)
+
+	// scheduled jobs
+	FuncScheduleJob = coreutil.NewEP41(Contract, "scheduleJob",
+		coreutil.Field[isc.Message]("message"),
+		coreutil.Field[uint32]("blockIndex"),
+		coreutil.Field[uint32]("interval"),
+		coreutil.Field[uint64]("gasBudget"),
+		coreutil.Field[uint32]("jobID"),
+	)
+	FuncCancelJob = coreutil.NewEP1(Contract, "cancelJob",
+		coreutil.Field[uint32]("jobID"),
+	)
+	ViewGetScheduledJobs = coreutil.NewViewEP01(Contract, "getScheduledJobs",
+		coreutil.Field[[]*ScheduledJob]("jobs"),
+	)
 )
 
 // state variables
@@ -121,6 +162,12 @@ const (
 	varGasFeePolicyBytes = "g" // covered in: TestMetadata
 	// varGasLimitsBytes :: gas.Limits
 	varGasLimitsBytes = "l" // covered in: TestMetadata
+	// varDynamicGasPricePolicy :: gas.DynamicGasPricePolicy
+	varDynamicGasPricePolicy = "dg" // covered in: TestGovernanceDynamicGasPrice
+	// varGasPriceMultiplier :: uint32
+	varGasPriceMultiplier = "gm" // covered in: TestGovernanceDynamicGasPrice
+	// varFeeMultipliers :: map[AgentID]uint32
+	varFeeMultipliers = "fm" // covered in: TestGovernanceFeeMultipliers
 
 	// access nodes
 	// varAccessNodes :: map[PublicKey]bool
@@ -143,10 +190,31 @@ const (
 	// state pruning
 	// varBlockKeepAmount :: int32
 	varBlockKeepAmount = "b" // covered in: TestMetadata
+
+	// scheduled jobs
+	// varScheduledJobs :: map[uint32]ScheduledJob
+	varScheduledJobs = "sj" // covered in: TestGovernanceScheduledJobs
+	// varNextScheduledJobID :: uint32
+	varNextScheduledJobID = "si" // covered in: TestGovernanceScheduledJobs
 )
 
 // contract constants
 const (
 	BlockKeepAll           = -1
 	DefaultBlockKeepAmount = 10_000
+	// MinBlockKeepAmount is the minimum value accepted by FuncSetBlockKeepAmount,
+	// other than BlockKeepAll
+	MinBlockKeepAmount = 100
+
+	// MaxScheduledJobs is the maximum amount of jobs scheduled at the same time
+	MaxScheduledJobs = 100
+	// MaxScheduledJobsPerBlock is the maximum amount of jobs executed in a
+	// single block; the rest are postponed to the next block
+	MaxScheduledJobsPerBlock = 10
+	// MaxScheduledJobFailures is the amount of consecutive failed executions
+	// after which a recurring job is cancelled
+	MaxScheduledJobFailures = 3
+
+	// MaxFeeMultipliers is the maximum amount of agents with a fee multiplier
+	MaxFeeMultipliers = 100
 )
@@ -5,6 +5,7 @@ package governance
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/samber/lo"
 
@@ -78,7 +79,19 @@ func (s *StateWriter) SetPayoutAgentID(a isc.AgentID) {
 	s.state.Set(varPayoutAgentID, codec.Encode(a))
 }
 
+// GetGasFeePolicy returns the fee policy in effect in the current block: if
+// the dynamic gas price is enabled, the gas price is adjusted by the current
+// multiplier
 func (s *StateReader) GetGasFeePolicy() *gas.FeePolicy {
+	fp := s.GetBaseGasFeePolicy()
+	if s.GetDynamicGasPricePolicy() == nil {
+		return fp
+	}
+	return fp.WithGasPriceMultiplier(s.GetGasPriceMultiplier())
+}
+
+// GetBaseGasFeePolicy returns the fee policy set by the chain admin
+func (s *StateReader) GetBaseGasFeePolicy() *gas.FeePolicy {
 	return lo.Must(gas.FeePolicyFromBytes(s.state.Get(varGasFeePolicyBytes)))
 }
 
@@ -90,6 +103,67 @@ func (s *StateReader) GetDefaultGasPrice() *big.Int {
 	return s.GetGasFeePolicy().DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals)
 }
 
+// GetDynamicGasPricePolicy returns the dynamic gas price policy, or nil if
+// the gas price is static
+func (s *StateReader) GetDynamicGasPricePolicy() *gas.DynamicGasPricePolicy {
+	data := s.state.Get(varDynamicGasPricePolicy)
+	if data == nil {
+		return nil
+	}
+	return lo.Must(gas.DynamicGasPricePolicyFromBytes(data))
+}
+
+// SetDynamicGasPricePolicy enables the dynamic gas price, or disables it if
+// p is nil. The multiplier is reset in both cases.
+func (s *StateWriter) SetDynamicGasPricePolicy(p *gas.DynamicGasPricePolicy) {
+	if p == nil {
+		s.state.Del(varDynamicGasPricePolicy)
+		s.state.Del(varGasPriceMultiplier)
+		return
+	}
+	s.state.Set(varDynamicGasPricePolicy, p.Bytes())
+	s.SetGasPriceMultiplier(p.ClampMultiplier(gas.GasPriceMultiplierBase))
+}
+
+// GetGasPriceMultiplier returns the multiplier applied to the gas price in
+// the current block, in basis points
+func (s *StateReader) GetGasPriceMultiplier() uint32 {
+	return lo.Must(codec.Decode[uint32](s.state.Get(varGasPriceMultiplier), gas.GasPriceMultiplierBase))
+}
+
+func (s *StateWriter) SetGasPriceMultiplier(m uint32) {
+	s.state.Set(varGasPriceMultiplier, codec.Encode(m))
+}
+
+func (s *StateReader) nextGasPriceMultiplier(p *gas.DynamicGasPricePolicy, gasBurned uint64, elapsed time.Duration) uint32 {
+	maxGasPerBlock := s.GetGasLimits().MaxGasPerBlock
+	next := p.NextMultiplier(s.GetGasPriceMultiplier(), gasBurned, maxGasPerBlock)
+	return p.IdleMultiplier(next, elapsed, maxGasPerBlock)
+}
+
+// GetNextGasFeePolicy returns the fee policy of the block following the
+// current one, given the gas burned in the current block and the time
+// elapsed since it was produced
+func (s *StateReader) GetNextGasFeePolicy(gasBurned uint64, elapsed time.Duration) *gas.FeePolicy {
+	fp := s.GetBaseGasFeePolicy()
+	p := s.GetDynamicGasPricePolicy()
+	if p == nil {
+		return fp
+	}
+	return fp.WithGasPriceMultiplier(s.nextGasPriceMultiplier(p, gasBurned, elapsed))
+}
+
+// UpdateGasPriceMultiplier adjusts the gas price multiplier, given the gas
+// burned in the previous block and the time elapsed since it was produced.
+// It does nothing if the gas price is static.
+func (s *StateWriter) UpdateGasPriceMultiplier(prevBlockGasBurned uint64, elapsed time.Duration) {
+	p := s.GetDynamicGasPricePolicy()
+	if p == nil {
+		return
+	}
+	s.SetGasPriceMultiplier(s.nextGasPriceMultiplier(p, prevBlockGasBurned, elapsed))
+}
+
 func (s *StateReader) GetGasLimits() *gas.Limits {
 	data := s.state.Get(varGasLimitsBytes)
 	if data == nil {
@@ -0,0 +1,125 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package governance
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kv/codec"
+	"github.com/iotaledger/wasp/v2/packages/kv/collections"
+)
+
+// ScheduledJob is a call executed by the VM at the start of a block, on
+// behalf of its owner. The gas is always paid from the owner's account.
+type ScheduledJob struct {
+	ID      uint32
+	Owner   isc.AgentID
+	Message isc.Message
+	// GasBudget is the gas budget of each execution
+	GasBudget uint64
+	// NextBlockIndex is the index of the block of the next execution. If no
+	// block is produced at that index, the job is executed in the next one.
+	NextBlockIndex uint32
+	// Interval is the amount of blocks between two executions, or 0 if the
+	// job is executed only once
+	Interval uint32
+	// Failures is the amount of consecutive failed executions
+	Failures uint32
+}
+
+func (s *StateWriter) scheduledJobsMap() *collections.Map {
+	return collections.NewMap(s.state, varScheduledJobs)
+}
+
+func (s *StateReader) scheduledJobsMap() *collections.ImmutableMap {
+	return collections.NewMapReadOnly(s.state, varScheduledJobs)
+}
+
+func (s *StateReader) GetScheduledJob(id uint32) *ScheduledJob {
+	return lo.Must(codec.Decode[*ScheduledJob](s.scheduledJobsMap().GetAt(codec.Encode(id)), nil))
+}
+
+func (s *StateReader) NumScheduledJobs() uint32 {
+	return s.scheduledJobsMap().Len()
+}
+
+// GetScheduledJobs returns all the scheduled jobs, ordered by ID
+func (s *StateReader) GetScheduledJobs() []*ScheduledJob {
+	jobs := []*ScheduledJob{}
+	s.scheduledJobsMap().Iterate(func(_ []byte, jobBytes []byte) bool {
+		jobs = append(jobs, codec.MustDecode[*ScheduledJob](jobBytes))
+		return true
+	})
+	slices.SortFunc(jobs, func(a, b *ScheduledJob) int { return cmp.Compare(a.ID, b.ID) })
+	return jobs
+}
+
+// GetDueScheduledJobs returns at most MaxScheduledJobsPerBlock jobs that must
+// be executed in the given block, the most overdue first
+func (s *StateReader) GetDueScheduledJobs(blockIndex uint32) []*ScheduledJob {
+	jobs := lo.Filter(s.GetScheduledJobs(), func(job *ScheduledJob, _ int) bool {
+		return job.NextBlockIndex <= blockIndex
+	})
+	slices.SortStableFunc(jobs, func(a, b *ScheduledJob) int { return cmp.Compare(a.NextBlockIndex, b.NextBlockIndex) })
+	if len(jobs) > MaxScheduledJobsPerBlock {
+		jobs = jobs[:MaxScheduledJobsPerBlock]
+	}
+	return jobs
+}
+
+// AddScheduledJob assigns a new ID to the job and saves it
+func (s *StateWriter) AddScheduledJob(job *ScheduledJob) uint32 {
+	job.ID = codec.MustDecode[uint32](s.state.Get(varNextScheduledJobID), 0)
+	s.state.Set(varNextScheduledJobID, codec.Encode(job.ID+1))
+	s.SetScheduledJob(job)
+	return job.ID
+}
+
+func (s *StateWriter) SetScheduledJob(job *ScheduledJob) {
+	s.scheduledJobsMap().SetAt(codec.Encode(job.ID), codec.Encode(job))
+}
+
+func (s *StateWriter) DeleteScheduledJob(id uint32) {
+	s.scheduledJobsMap().DelAt(codec.Encode(id))
+}
+
+// AdvanceScheduledJob reschedules the job after its execution in the given
+// block, or deletes it if it is not recurring
+func (s *StateWriter) AdvanceScheduledJob(job *ScheduledJob, blockIndex uint32) {
+	if job.Interval == 0 {
+		s.DeleteScheduledJob(job.ID)
+		return
+	}
+	job.NextBlockIndex = blockIndex + job.Interval
+	s.SetScheduledJob(job)
+}
+
+// RecordScheduledJobResult updates the consecutive failures of the job after
+// its execution, and deletes it after MaxScheduledJobFailures of them. It
+// returns true if the job was deleted.
+func (s *StateWriter) RecordScheduledJobResult(id uint32, failed bool) bool {
+	job := s.GetScheduledJob(id)
+	if job == nil {
+		// not recurring, or cancelled by the job itself
+		return false
+	}
+	switch {
+	case !failed && job.Failures == 0:
+		return false
+	case !failed:
+		job.Failures = 0
+	default:
+		job.Failures++
+		if job.Failures >= MaxScheduledJobFailures {
+			s.DeleteScheduledJob(id)
+			return true
+		}
+	}
+	s.SetScheduledJob(job)
+	return false
+}
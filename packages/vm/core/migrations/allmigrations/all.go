@@ -13,8 +13,19 @@ const (
 	// version 5 acts as a marker for migrated stardust blocks in case legacy behavior needs to be introduced.
 	SchemaVersionMigratedRebased = 5 + iota
 	SchemaVersionIotaRebased
+	// SchemaVersionGradualPruning enables the pruning cursors of blocklog and
+	// evm, needed to prune the blocks gradually when the block keep amount is
+	// reduced.
+	SchemaVersionGradualPruning
+	// SchemaVersionVersionedReceipts enables the versioned encoding of the
+	// request receipts, which includes the gas fee sponsor and the fee
+	// multiplier.
+	SchemaVersionVersionedReceipts
+	// SchemaVersionLockups enables the vesting lockups of the accounts
+	// contract. Before it, debiting an account does not read its lockups.
+	SchemaVersionLockups
 
-	LatestSchemaVersion = SchemaVersionIotaRebased
+	LatestSchemaVersion = SchemaVersionLockups
 )
 
 var DefaultScheme = &migrations.MigrationScheme{
@@ -36,5 +47,28 @@ var DefaultScheme = &migrations.MigrationScheme{
 			},
 			Contract: root.Contract,
 		},
+		// NOOP migration: the pruning cursors are created by the first block
+		// pruned with SchemaVersionGradualPruning.
+		{
+			Apply: func(contractState kv.KVStore, log log.Logger) error {
+				return nil
+			},
+			Contract: root.Contract,
+		},
+		// NOOP migration: the receipts written before
+		// SchemaVersionVersionedReceipts keep their legacy encoding.
+		{
+			Apply: func(contractState kv.KVStore, log log.Logger) error {
+				return nil
+			},
+			Contract: root.Contract,
+		},
+		// NOOP migration: there are no lockups before SchemaVersionLockups.
+		{
+			Apply: func(contractState kv.KVStore, log log.Logger) error {
+				return nil
+			},
+			Contract: root.Contract,
+		},
 	},
 }
@@ -3,6 +3,7 @@ package testcore
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -237,6 +238,216 @@ func TestAccounts_WithdrawDepositCoins(t *testing.T) {
 	})
 }
 
+func TestAccounts_WithdrawTo(t *testing.T) {
+	v := initWithdrawTest(t)
+	_, receiverAddr := v.env.NewKeyPair()
+
+	req := solo.NewCallParams(accounts.FuncWithdrawTo.Message(receiverAddr)).
+		AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 30)).
+		WithGasBudget(100_000)
+	_, err := v.ch.PostRequestSync(req, v.user)
+	require.NoError(t, err)
+
+	v.env.AssertL1Coins(receiverAddr, v.coinType, coin.Value(30))
+	v.ch.AssertL2Coins(v.userAgentID, v.coinType, coin.Value(70))
+
+	// empty allowance
+	req = solo.NewCallParams(accounts.FuncWithdrawTo.Message(receiverAddr)).
+		WithGasBudget(100_000)
+	_, err = v.ch.PostRequestSync(req, v.user)
+	testmisc.RequireErrorToBe(t, err, "not enough allowance")
+}
+
+func TestAccounts_WithdrawBatch(t *testing.T) {
+	v := initWithdrawTest(t)
+	v.ch.MustDepositBaseTokensToL2(5*isc.Million, v.user)
+	_, receiver1 := v.env.NewKeyPair()
+	_, receiver2 := v.env.NewKeyPair()
+
+	req := solo.NewCallParams(accounts.FuncWithdrawBatch.Message([]*accounts.Withdrawal{
+		{TargetAddress: receiver1, Assets: isc.NewEmptyAssets().AddCoin(v.coinType, 10)},
+		{TargetAddress: receiver2, Assets: isc.NewAssets(1*isc.Million).AddCoin(v.coinType, 20)},
+	})).
+		AddAllowance(isc.NewAssets(2*isc.Million).AddCoin(v.coinType, 50)).
+		WithGasBudget(100_000)
+	_, err := v.ch.PostRequestSync(req, v.user)
+	require.NoError(t, err)
+
+	v.env.AssertL1Coins(receiver1, v.coinType, coin.Value(10))
+	v.env.AssertL1Coins(receiver2, v.coinType, coin.Value(20))
+	require.EqualValues(t, 1*isc.Million, v.env.L1BaseTokens(receiver2))
+	// the rest of the allowance is left to the sender
+	v.ch.AssertL2Coins(v.userAgentID, v.coinType, coin.Value(70))
+
+	t.Run("not enough allowance", func(t *testing.T) {
+		req := solo.NewCallParams(accounts.FuncWithdrawBatch.Message([]*accounts.Withdrawal{
+			{TargetAddress: receiver1, Assets: isc.NewEmptyAssets().AddCoin(v.coinType, 10)},
+			{TargetAddress: receiver2, Assets: isc.NewEmptyAssets().AddCoin(v.coinType, 10)},
+		})).
+			AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 15)).
+			WithGasBudget(100_000)
+		_, err := v.ch.PostRequestSync(req, v.user)
+		testmisc.RequireErrorToBe(t, err, "not enough allowance")
+		v.ch.AssertL2Coins(v.userAgentID, v.coinType, coin.Value(70))
+	})
+
+	t.Run("empty withdrawal", func(t *testing.T) {
+		req := solo.NewCallParams(accounts.FuncWithdrawBatch.Message([]*accounts.Withdrawal{
+			{TargetAddress: receiver1, Assets: isc.NewEmptyAssets().AddCoin(v.coinType, 10)},
+			{TargetAddress: receiver2, Assets: isc.NewEmptyAssets()},
+		})).
+			AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 10)).
+			WithGasBudget(100_000)
+		_, err := v.ch.PostRequestSync(req, v.user)
+		testmisc.RequireErrorToBe(t, err, "invalid withdrawal #1")
+	})
+}
+
+func TestAccounts_Lockup(t *testing.T) {
+	v := initWithdrawTest(t)
+	beneficiary, beneficiaryAddr := v.env.NewKeyPairWithFunds()
+	beneficiaryAgentID := isc.NewAddressAgentID(beneficiaryAddr)
+	v.ch.MustDepositBaseTokensToL2(solo.BaseTokensForL2Gas, beneficiary)
+
+	lockedBalance := func() (coin.Value, coin.Value) {
+		ret, err := v.ch.CallView(accounts.ViewLockedBalance.Message(&beneficiaryAgentID))
+		require.NoError(t, err)
+		locked, releasable, err := accounts.ViewLockedBalance.DecodeOutput(ret)
+		require.NoError(t, err)
+		return locked.Get(v.coinType), releasable.Get(v.coinType)
+	}
+	withdraw := func(amount coin.Value) error {
+		req := solo.NewCallParams(accounts.FuncWithdraw.Message()).
+			AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, amount)).
+			WithGasBudget(100_000)
+		_, err := v.ch.PostRequestSync(req, beneficiary)
+		return err
+	}
+
+	// 40 coins, released linearly in 2 hours, with a cliff of 1 hour
+	now := v.env.GlobalTime()
+	req := solo.NewCallParams(accounts.FuncCreateLockup.Message(beneficiaryAgentID, now, now.Add(time.Hour), now.Add(2*time.Hour))).
+		AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 40)).
+		WithGasBudget(100_000)
+	_, err := v.ch.PostRequestSync(req, v.user)
+	testmisc.RequireErrorToBe(t, err, "lockup funder not approved by the beneficiary")
+	v.ch.AssertL2Coins(v.userAgentID, v.coinType, coin.Value(100))
+
+	// the beneficiary approves the funder
+	_, err = v.ch.PostRequestSync(solo.NewCallParams(accounts.FuncApproveLockupFunder.Message(v.userAgentID, true)).WithGasBudget(100_000), beneficiary)
+	require.NoError(t, err)
+	ret, err := v.ch.CallView(accounts.ViewIsLockupFunderApproved.Message(beneficiaryAgentID, v.userAgentID))
+	require.NoError(t, err)
+	approved, err := accounts.ViewIsLockupFunderApproved.DecodeOutput(ret)
+	require.NoError(t, err)
+	require.True(t, approved)
+
+	_, err = v.ch.PostRequestSync(req, v.user)
+	require.NoError(t, err)
+	v.ch.AssertL2Coins(v.userAgentID, v.coinType, coin.Value(60))
+	v.ch.AssertL2Coins(beneficiaryAgentID, v.coinType, coin.Value(40))
+
+	locked, releasable := lockedBalance()
+	require.EqualValues(t, 40, locked)
+	require.EqualValues(t, 0, releasable)
+	err = withdraw(1)
+	testmisc.RequireErrorToBe(t, err, vm.ErrNotEnoughFundsForAllowance)
+
+	// 3/4 of the coins are released after 90 minutes
+	v.env.AdvanceClockBy(90 * time.Minute)
+	err = withdraw(31)
+	testmisc.RequireErrorToBe(t, err, vm.ErrNotEnoughFundsForAllowance)
+	err = withdraw(30)
+	require.NoError(t, err)
+	v.env.AssertL1Coins(beneficiaryAddr, v.coinType, coin.Value(30))
+
+	locked, releasable = lockedBalance()
+	require.EqualValues(t, 10, locked)
+	require.EqualValues(t, 0, releasable)
+	ret, err = v.ch.CallView(accounts.ViewBalance.Message(&beneficiaryAgentID))
+	require.NoError(t, err)
+	balance, lockedBalances, err := accounts.ViewBalance.DecodeOutput(ret)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, balance.Get(v.coinType))
+	require.EqualValues(t, 10, lockedBalances.Get(v.coinType))
+
+	// the end time must be after the start time
+	req = solo.NewCallParams(accounts.FuncCreateLockup.Message(beneficiaryAgentID, now, now, now)).
+		AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 10)).
+		WithGasBudget(100_000)
+	_, err = v.ch.PostRequestSync(req, v.user)
+	testmisc.RequireErrorToBe(t, err, "invalid lockup schedule")
+
+	// a single funder cannot fill the lockups of the account
+	for i := range accounts.MaxLockupsPerFunder {
+		req = solo.NewCallParams(accounts.FuncCreateLockup.Message(beneficiaryAgentID, now, now, now.Add(3*time.Hour))).
+			AddAllowance(isc.NewEmptyAssets().AddCoin(v.coinType, 1)).
+			WithGasBudget(100_000)
+		_, err = v.ch.PostRequestSync(req, v.user)
+		if i < accounts.MaxLockupsPerFunder-1 {
+			require.NoError(t, err)
+		} else {
+			testmisc.RequireErrorToBe(t, err, "too many lockups from the same funder")
+		}
+	}
+}
+
+func TestAccounts_Sponsorship(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{})
+	ch := env.NewChain()
+	sponsor, sponsorAddr := env.NewKeyPairWithFunds(env.NewSeedFromTestNameAndTimestamp(t.Name()))
+	sponsorAgentID := isc.NewAddressAgentID(sponsorAddr)
+	ch.MustDepositBaseTokensToL2(solo.BaseTokensForL2Gas, sponsor)
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := isc.NewAddressAgentID(userAddr)
+
+	getSponsorship := func() coin.Value {
+		ret, err := ch.CallView(accounts.ViewGetSponsorship.Message(sponsorAgentID, userAgentID))
+		require.NoError(t, err)
+		approved, err := accounts.ViewGetSponsorship.DecodeOutput(ret)
+		require.NoError(t, err)
+		return approved
+	}
+	postSponsored := func() error {
+		req := solo.NewCallParams(accounts.FuncDeposit.Message()).
+			WithGasBudget(100_000).
+			NewRequestOffLedger(ch, user)
+		_, _, err := ch.RunOffLedgerRequest(isc.NewSponsoredOffLedgerRequest(req.(*isc.OffLedgerRequestData), sponsor))
+		return err
+	}
+
+	const maxGasFee = 1 * isc.Million
+	_, err := ch.PostRequestOffLedger(solo.NewCallParams(accounts.FuncSetSponsorship.Message(userAgentID, maxGasFee)), sponsor)
+	require.NoError(t, err)
+	require.EqualValues(t, maxGasFee, getSponsorship())
+
+	// the user has no funds on L2, the gas is paid by the sponsor
+	sponsorBalance := ch.L2BaseTokens(sponsorAgentID)
+	err = postSponsored()
+	require.NoError(t, err)
+	rec := ch.LastReceipt()
+	require.NotNil(t, rec.Sponsor)
+	require.True(t, rec.Sponsor.Equals(sponsorAgentID))
+	require.NotZero(t, rec.GasFeeCharged)
+	require.EqualValues(t, sponsorBalance-rec.GasFeeCharged, ch.L2BaseTokens(sponsorAgentID))
+	require.Zero(t, ch.L2BaseTokens(userAgentID))
+	require.EqualValues(t, maxGasFee-rec.GasFeeCharged, getSponsorship())
+
+	// once the approval is revoked, the gas is paid by the user
+	_, err = ch.PostRequestOffLedger(solo.NewCallParams(accounts.FuncSetSponsorship.Message(userAgentID, 0)), sponsor)
+	require.NoError(t, err)
+	require.Zero(t, getSponsorship())
+	ch.MustDepositBaseTokensToL2(solo.BaseTokensForL2Gas, user)
+	sponsorBalance = ch.L2BaseTokens(sponsorAgentID)
+	userBalance := ch.L2BaseTokens(userAgentID)
+	err = postSponsored()
+	require.NoError(t, err)
+	rec = ch.LastReceipt()
+	require.Nil(t, rec.Sponsor)
+	require.EqualValues(t, sponsorBalance, ch.L2BaseTokens(sponsorAgentID))
+	require.EqualValues(t, userBalance-rec.GasFeeCharged, ch.L2BaseTokens(userAgentID))
+}
+
 func TestAccounts_TransferAndCheckBaseTokens(t *testing.T) {
 	// initializes it all and prepares withdraw request, does not post it
 	v := initWithdrawTest(t)
@@ -174,6 +174,35 @@ func TestGetEvents(t *testing.T) {
 	events = getEventsForBlock(t, ch, bi+3)
 	require.Len(t, events, 1)
 	checkEventCounter(t, events[0], 3)
+
+	q := &blocklog.EventsForContractQuery{
+		Contract:   inccounter.Contract.Hname(),
+		BlockRange: &blocklog.BlockRange{From: bi + 1, To: bi + 3},
+		MaxEvents:  2,
+	}
+	res := getEventsForContract(t, ch, q)
+	require.Len(t, res.Events, 2)
+	checkEventCounter(t, res.Events[0], 1)
+	checkEventCounter(t, res.Events[1], 2)
+	require.NotNil(t, res.Next)
+
+	q.After = res.Next
+	res = getEventsForContract(t, ch, q)
+	require.Len(t, res.Events, 1)
+	checkEventCounter(t, res.Events[0], 3)
+	require.Nil(t, res.Next)
+
+	_, err = ch.CallView(blocklog.ViewGetEventsForContract.Message(&blocklog.EventsForContractQuery{
+		Contract:   inccounter.Contract.Hname(),
+		BlockRange: &blocklog.BlockRange{From: 0, To: blocklog.MaxBlocksPerContractQuery},
+	}))
+	require.ErrorContains(t, err, "the block range cannot exceed")
+}
+
+func getEventsForContract(t *testing.T, chain *solo.Chain, q *blocklog.EventsForContractQuery) *blocklog.EventsForContractResponse {
+	res, err := chain.CallView(blocklog.ViewGetEventsForContract.Message(q))
+	require.NoError(t, err)
+	return lo.Must(blocklog.ViewGetEventsForContract.DecodeOutput(res))
 }
 
 func checkEventCounter(t *testing.T, event *isc.Event, value uint64) {
@@ -519,6 +519,54 @@ func TestGovernanceGasCoinTargetValue(t *testing.T) {
 	require.EqualValues(t, gasCoinTargetValue, retMinCommonAccountBalance)
 }
 
+func TestGovernanceBlockKeepAmount(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{Debug: true, PrintStackTrace: true})
+	ch := env.NewChain()
+
+	retDict, err := ch.CallView(governance.ViewGetBlockKeepAmount.Message())
+	require.NoError(t, err)
+	require.EqualValues(t, governance.DefaultBlockKeepAmount, lo.Must(governance.ViewGetBlockKeepAmount.DecodeOutput(retDict)))
+
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetBlockKeepAmount.Message(governance.MinBlockKeepAmount)).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.NoError(t, err)
+	retDict, err = ch.CallView(governance.ViewGetBlockKeepAmount.Message())
+	require.NoError(t, err)
+	require.EqualValues(t, governance.MinBlockKeepAmount, lo.Must(governance.ViewGetBlockKeepAmount.DecodeOutput(retDict)))
+
+	// out of bounds
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetBlockKeepAmount.Message(governance.MinBlockKeepAmount-1)).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.ErrorContains(t, err, "invalid block keep amount")
+
+	// keep all blocks
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetBlockKeepAmount.Message(governance.BlockKeepAll)).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.NoError(t, err)
+
+	// only the chain admin can change it
+	user, _ := env.NewKeyPairWithFunds()
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetBlockKeepAmount.Message(governance.MinBlockKeepAmount)).
+			AddBaseTokens(1*isc.Million).
+			WithMaxAffordableGasBudget(),
+		user,
+	)
+	require.ErrorContains(t, err, "unauthorized access")
+	retDict, err = ch.CallView(governance.ViewGetBlockKeepAmount.Message())
+	require.NoError(t, err)
+	require.EqualValues(t, governance.BlockKeepAll, lo.Must(governance.ViewGetBlockKeepAmount.DecodeOutput(retDict)))
+}
+
 func TestGovernanceCallsNoBalance(t *testing.T) {
 	env := solo.New(t)
 	ch := env.NewChain(false)
@@ -611,3 +659,259 @@ func TestGovernanceGasPayout(t *testing.T) {
 	require.Equal(t, commonBal3.BaseTokens()+addedToCommonAccount3, commonBal4.BaseTokens())
 	require.Equal(t, user1Bal3.BaseTokens()+transferAmt-addedToCommonAccount3, user1Bal4.BaseTokens())
 }
+
+func TestGovernanceScheduledJobs(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{Debug: true, PrintStackTrace: true})
+	ch := env.NewChain()
+
+	scheduleJob := func(msg isc.Message, blockIndex, interval uint32, wallet *cryptolib.KeyPair) (uint32, error) {
+		ret, err := ch.PostRequestSync(
+			solo.NewCallParams(governance.FuncScheduleJob.Message(msg, blockIndex, interval, gas.LimitsDefault.MinGasPerRequest*10)).
+				AddBaseTokens(1*isc.Million).
+				WithMaxAffordableGasBudget(),
+			wallet,
+		)
+		if err != nil {
+			return 0, err
+		}
+		return lo.Must(governance.FuncScheduleJob.DecodeOutput(ret)), nil
+	}
+	getJobs := func() []*governance.ScheduledJob {
+		ret, err := ch.CallView(governance.ViewGetScheduledJobs.Message())
+		require.NoError(t, err)
+		return lo.Must(governance.ViewGetScheduledJobs.DecodeOutput(ret))
+	}
+	getCounter := func() int64 {
+		ret, err := ch.CallView(inccounter.ViewGetCounter.Message())
+		require.NoError(t, err)
+		return lo.Must(inccounter.ViewGetCounter.DecodeOutput(ret))
+	}
+	// scheduledJobIDs returns the IDs of the jobs executed in the block
+	scheduledJobIDs := func(blockIndex uint32) []uint32 {
+		ids := []uint32{}
+		for _, rec := range ch.GetRequestReceiptsForBlock(blockIndex) {
+			if req, ok := rec.Request.(*isc.ScheduledRequest); ok {
+				require.Nil(t, rec.Error)
+				require.EqualValues(t, blockIndex, req.BlockIndex())
+				ids = append(ids, req.JobID())
+			}
+		}
+		return ids
+	}
+	nextBlock := func() uint32 {
+		require.NoError(t, ch.DepositBaseTokensToL2(1*isc.Million, nil))
+		return ch.LatestBlockIndex()
+	}
+
+	// both jobs are due 3 blocks from now
+	blockIndex := ch.LatestBlockIndex() + 3
+	oneShotID, err := scheduleJob(inccounter.FuncIncCounter.Message(lo.ToPtr[int64](10)), blockIndex, 0, nil)
+	require.NoError(t, err)
+	recurringID, err := scheduleJob(inccounter.FuncIncCounter.Message(nil), blockIndex, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, getJobs(), 2)
+	require.Zero(t, getCounter())
+
+	require.Equal(t, blockIndex, nextBlock())
+	require.Equal(t, []uint32{oneShotID, recurringID}, scheduledJobIDs(blockIndex))
+	require.EqualValues(t, 11, getCounter())
+	jobs := getJobs()
+	require.Len(t, jobs, 1)
+	require.Equal(t, recurringID, jobs[0].ID)
+	require.Equal(t, ch.AdminAgentID(), jobs[0].Owner)
+	require.Equal(t, blockIndex+2, jobs[0].NextBlockIndex)
+
+	require.Empty(t, scheduledJobIDs(nextBlock()))
+	require.Equal(t, []uint32{recurringID}, scheduledJobIDs(nextBlock()))
+	require.EqualValues(t, 12, getCounter())
+
+	// the block index must be in the future
+	_, err = scheduleJob(inccounter.FuncIncCounter.Message(nil), ch.LatestBlockIndex()+1, 0, nil)
+	require.ErrorContains(t, err, "invalid block index")
+
+	// only contracts and the chain admin can schedule jobs
+	user, _ := env.NewKeyPairWithFunds()
+	_, err = scheduleJob(inccounter.FuncIncCounter.Message(nil), ch.LatestBlockIndex()+2, 0, user)
+	require.ErrorContains(t, err, "unauthorized access")
+
+	// only the owner and the chain admin can cancel a job
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncCancelJob.Message(recurringID)).
+			AddBaseTokens(1*isc.Million).
+			WithMaxAffordableGasBudget(),
+		user,
+	)
+	require.ErrorContains(t, err, "unauthorized access")
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncCancelJob.Message(recurringID)).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.NoError(t, err)
+	require.Empty(t, getJobs())
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncCancelJob.Message(recurringID)).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.ErrorContains(t, err, "scheduled job 1 not found")
+
+	// a recurring job is cancelled after too many consecutive failures
+	failingID, err := scheduleJob(isc.NewMessage(inccounter.Contract.Hname(), isc.Hn("nonExistent")), ch.LatestBlockIndex()+2, 1, nil)
+	require.NoError(t, err)
+	failures := 0
+	for range governance.MaxScheduledJobFailures + 2 {
+		for _, rec := range ch.GetRequestReceiptsForBlock(nextBlock()) {
+			if req, ok := rec.Request.(*isc.ScheduledRequest); ok {
+				require.Equal(t, failingID, req.JobID())
+				require.NotNil(t, rec.Error)
+				failures++
+			}
+		}
+	}
+	require.Equal(t, governance.MaxScheduledJobFailures, failures)
+	require.Empty(t, getJobs())
+}
+
+func TestGovernanceDynamicGasPrice(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+
+	getDynamicGasPrice := func() (*gas.DynamicGasPricePolicy, uint32) {
+		ret, err := ch.CallView(governance.ViewGetDynamicGasPrice.Message())
+		require.NoError(t, err)
+		policy, multiplier, err := governance.ViewGetDynamicGasPrice.DecodeOutput(ret)
+		require.NoError(t, err)
+		if policy == nil {
+			return nil, multiplier
+		}
+		return *policy, multiplier
+	}
+	getEffectiveFeePolicy := func() *gas.FeePolicy {
+		ret, err := ch.CallView(governance.ViewGetChainInfo.Message())
+		require.NoError(t, err)
+		return lo.Must(governance.ViewGetChainInfo.DecodeOutput(ret)).GasFeePolicy
+	}
+
+	policy, multiplier := getDynamicGasPrice()
+	require.Nil(t, policy)
+	require.EqualValues(t, gas.GasPriceMultiplierBase, multiplier)
+
+	invalidPolicy := &gas.DynamicGasPricePolicy{}
+	_, err := ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetDynamicGasPricePolicy.Message(&invalidPolicy)),
+		nil,
+	)
+	require.ErrorContains(t, err, "invalid dynamic gas price policy")
+
+	dynamicPolicy := &gas.DynamicGasPricePolicy{
+		TargetBlockUtilization: 100,
+		MaxChangePerBlock:      50,
+		MinMultiplier:          gas.GasPriceMultiplierBase / 2,
+		MaxMultiplier:          2 * gas.GasPriceMultiplierBase,
+		IdleBlockInterval:      10,
+	}
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetDynamicGasPricePolicy.Message(&dynamicPolicy)),
+		nil,
+	)
+	require.NoError(t, err)
+	policy, multiplier = getDynamicGasPrice()
+	require.Equal(t, dynamicPolicy, policy)
+	require.EqualValues(t, gas.GasPriceMultiplierBase, multiplier)
+
+	// the blocks are almost empty, so the price decreases
+	baseFeePolicy := ch.GetGasFeePolicy()
+	ch.MustDepositBaseTokensToL2(10*isc.Million, nil)
+	_, multiplier = getDynamicGasPrice()
+	require.Less(t, multiplier, uint32(gas.GasPriceMultiplierBase))
+	require.GreaterOrEqual(t, multiplier, dynamicPolicy.MinMultiplier)
+	require.Equal(t, baseFeePolicy.WithGasPriceMultiplier(multiplier), ch.GetGasFeePolicy())
+	require.Equal(t, baseFeePolicy.WithGasPriceMultiplier(multiplier), getEffectiveFeePolicy())
+
+	ch.MustDepositBaseTokensToL2(10*isc.Million, nil)
+	_, multiplier = getDynamicGasPrice()
+	require.EqualValues(t, dynamicPolicy.MinMultiplier, multiplier)
+
+	// disable the dynamic gas price
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(governance.FuncSetDynamicGasPricePolicy.Message(nil)),
+		nil,
+	)
+	require.NoError(t, err)
+	policy, multiplier = getDynamicGasPrice()
+	require.Nil(t, policy)
+	require.EqualValues(t, gas.GasPriceMultiplierBase, multiplier)
+	require.Equal(t, baseFeePolicy, getEffectiveFeePolicy())
+}
+
+func TestGovernanceFeeMultipliers(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+	user, userAddr := env.NewKeyPairWithFunds(env.NewSeedFromTestNameAndTimestamp(t.Name()))
+	userAgentID := isc.NewAddressAgentID(userAddr)
+	ch.MustDepositBaseTokensToL2(solo.BaseTokensForL2Gas, user)
+
+	setFeeMultiplier := func(multiplier uint32, keyPair *cryptolib.KeyPair) error {
+		_, err := ch.PostRequestSync(
+			solo.NewCallParams(governance.FuncSetFeeMultiplier.Message(userAgentID, multiplier)),
+			keyPair,
+		)
+		return err
+	}
+	getFeeMultiplier := func() uint32 {
+		ret, err := ch.CallView(governance.ViewGetFeeMultiplier.Message(userAgentID))
+		require.NoError(t, err)
+		return lo.Must(governance.ViewGetFeeMultiplier.DecodeOutput(ret))
+	}
+	getFeeMultipliers := func() []*governance.FeeMultiplier {
+		ret, err := ch.CallView(governance.ViewGetFeeMultipliers.Message())
+		require.NoError(t, err)
+		return lo.Must(governance.ViewGetFeeMultipliers.DecodeOutput(ret))
+	}
+	postRequest := func() *isc.Receipt {
+		balance := ch.L2BaseTokens(userAgentID)
+		_, err := ch.PostRequestOffLedger(solo.NewCallParams(accounts.FuncDeposit.Message()), user)
+		require.NoError(t, err)
+		rec := ch.LastReceipt()
+		require.EqualValues(t, balance-rec.GasFeeCharged, ch.L2BaseTokens(userAgentID))
+		return rec
+	}
+
+	require.EqualValues(t, gas.GasPriceMultiplierBase, getFeeMultiplier())
+	require.Empty(t, getFeeMultipliers())
+	rec := postRequest()
+	require.Nil(t, rec.FeeMultiplier)
+	fullFee := rec.GasFeeCharged
+	require.NotZero(t, fullFee)
+
+	require.ErrorContains(t, setFeeMultiplier(gas.GasPriceMultiplierBase+1, nil), "invalid fee multiplier")
+	require.ErrorContains(t, setFeeMultiplier(0, user), "unauthorized")
+
+	// the user is exempt from fees
+	require.NoError(t, setFeeMultiplier(0, nil))
+	require.Zero(t, getFeeMultiplier())
+	feeMultipliers := getFeeMultipliers()
+	require.Len(t, feeMultipliers, 1)
+	require.True(t, feeMultipliers[0].AgentID.Equals(userAgentID))
+	require.Zero(t, feeMultipliers[0].Multiplier)
+	rec = postRequest()
+	require.Zero(t, rec.GasFeeCharged)
+	require.NotZero(t, rec.GasBurned)
+	require.NotNil(t, rec.FeeMultiplier)
+	require.Zero(t, *rec.FeeMultiplier)
+
+	// the user pays half of the fee
+	require.NoError(t, setFeeMultiplier(gas.GasPriceMultiplierBase/2, nil))
+	rec = postRequest()
+	require.EqualValues(t, gas.GasPriceMultiplierBase/2, *rec.FeeMultiplier)
+	require.InDelta(t, fullFee/2, rec.GasFeeCharged, 1)
+
+	// remove the discount
+	require.NoError(t, setFeeMultiplier(gas.GasPriceMultiplierBase, nil))
+	require.EqualValues(t, gas.GasPriceMultiplierBase, getFeeMultiplier())
+	require.Empty(t, getFeeMultipliers())
+	rec = postRequest()
+	require.Nil(t, rec.FeeMultiplier)
+	require.EqualValues(t, fullFee, rec.GasFeeCharged)
+}
@@ -0,0 +1,161 @@
+package gas
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/iotaledger/wasp/v2/packages/util"
+)
+
+// GasPriceMultiplierBase is the gas price multiplier that leaves the price
+// set in FeePolicy.GasPerToken unchanged. Multipliers are expressed in basis
+// points.
+const GasPriceMultiplierBase = 10_000
+
+// DynamicGasPricePolicy enables a mode similar to EIP-1559, where the gas
+// price of each block is adjusted based on the gas burned in the previous
+// block. The price is FeePolicy.GasPerToken multiplied by a multiplier that
+// increases when the previous block burned more than the target, and
+// decreases when it burned less. While the chain is idle, the price decreases
+// as if empty blocks were produced.
+type DynamicGasPricePolicy struct {
+	// TargetBlockUtilization is the percentage of Limits.MaxGasPerBlock that
+	// leaves the gas price unchanged
+	TargetBlockUtilization uint8 `json:"targetBlockUtilization" swagger:"desc(The percentage of the max gas per block that leaves the gas price unchanged),required"`
+
+	// MaxChangePerBlock is the maximum change of the gas price between two
+	// consecutive blocks, in percent
+	MaxChangePerBlock uint8 `json:"maxChangePerBlock" swagger:"desc(The maximum change of the gas price between two blocks, in percent),required"`
+
+	// MinMultiplier and MaxMultiplier are the bounds of the gas price
+	// multiplier, in basis points
+	MinMultiplier uint32 `json:"minMultiplier" swagger:"desc(The minimum gas price multiplier, in basis points),required"`
+	MaxMultiplier uint32 `json:"maxMultiplier" swagger:"desc(The maximum gas price multiplier, in basis points),required"`
+
+	// IdleBlockInterval is the expected time between two blocks, in seconds.
+	// Each interval elapsed without blocks counts as an empty block.
+	IdleBlockInterval uint32 `json:"idleBlockInterval" swagger:"desc(The time without blocks that counts as an empty block, in seconds),required"`
+}
+
+func DefaultDynamicGasPricePolicy() *DynamicGasPricePolicy {
+	return &DynamicGasPricePolicy{
+		TargetBlockUtilization: 50,
+		MaxChangePerBlock:      12,
+		MinMultiplier:          GasPriceMultiplierBase,
+		MaxMultiplier:          10 * GasPriceMultiplierBase,
+		IdleBlockInterval:      10,
+	}
+}
+
+func (p *DynamicGasPricePolicy) IsValid() bool {
+	return p.TargetBlockUtilization > 0 && p.TargetBlockUtilization <= 100 &&
+		p.MaxChangePerBlock > 0 && p.MaxChangePerBlock <= 100 &&
+		p.MinMultiplier > 0 && p.MinMultiplier <= p.MaxMultiplier &&
+		p.IdleBlockInterval > 0
+}
+
+// ClampMultiplier returns the multiplier within the bounds of the policy
+func (p *DynamicGasPricePolicy) ClampMultiplier(multiplier uint32) uint32 {
+	return min(max(multiplier, p.MinMultiplier), p.MaxMultiplier)
+}
+
+// NextMultiplier returns the gas price multiplier of the next block, given
+// the multiplier and the gas burned in the previous block
+func (p *DynamicGasPricePolicy) NextMultiplier(multiplier uint32, gasBurned uint64, maxGasPerBlock uint64) uint32 {
+	target := maxGasPerBlock / 100 * uint64(p.TargetBlockUtilization)
+	if target == 0 {
+		return p.ClampMultiplier(multiplier)
+	}
+	// delta = multiplier * (gasBurned - target) / target * maxChange / 100
+	delta := new(big.Int).SetUint64(gasBurned)
+	delta.Sub(delta, new(big.Int).SetUint64(target))
+	delta.Mul(delta, big.NewInt(int64(multiplier)))
+	delta.Mul(delta, big.NewInt(int64(p.MaxChangePerBlock)))
+	delta.Quo(delta, new(big.Int).SetUint64(target))
+	delta.Quo(delta, big.NewInt(100))
+
+	maxDelta := int64(multiplier) * int64(p.MaxChangePerBlock) / 100
+	if delta.Cmp(big.NewInt(maxDelta)) > 0 {
+		delta.SetInt64(maxDelta)
+	}
+	next := int64(multiplier) + delta.Int64()
+	if next < 0 {
+		next = 0
+	}
+	return p.ClampMultiplier(uint32(min(next, math.MaxUint32)))
+}
+
+// IdleMultiplier returns the gas price multiplier after the chain has been
+// idle for the given time since the previous block: every IdleBlockInterval
+// after the first one counts as an empty block
+func (p *DynamicGasPricePolicy) IdleMultiplier(multiplier uint32, elapsed time.Duration, maxGasPerBlock uint64) uint32 {
+	interval := time.Duration(p.IdleBlockInterval) * time.Second
+	if interval == 0 {
+		return multiplier
+	}
+	for emptyBlocks := elapsed/interval - 1; emptyBlocks > 0; emptyBlocks-- {
+		next := p.NextMultiplier(multiplier, 0, maxGasPerBlock)
+		if next == multiplier {
+			break
+		}
+		multiplier = next
+	}
+	return multiplier
+}
+
+func DynamicGasPricePolicyFromBytes(data []byte) (*DynamicGasPricePolicy, error) {
+	return bcs.Unmarshal[*DynamicGasPricePolicy](data)
+}
+
+func (p *DynamicGasPricePolicy) Bytes() []byte {
+	return bcs.MustMarshal(p)
+}
+
+func (p *DynamicGasPricePolicy) String() string {
+	return fmt.Sprintf(`
+	TargetBlockUtilization %d%%
+	MaxChangePerBlock %d%%
+	MinMultiplier %d
+	MaxMultiplier %d
+	IdleBlockInterval %ds
+	`,
+		p.TargetBlockUtilization,
+		p.MaxChangePerBlock,
+		p.MinMultiplier,
+		p.MaxMultiplier,
+		p.IdleBlockInterval,
+	)
+}
+
+// WithGasPriceMultiplier returns a copy of the fee policy where the price of
+// the gas is multiplied by the given multiplier, in basis points
+func (p *FeePolicy) WithGasPriceMultiplier(multiplier uint32) *FeePolicy {
+	ret := *p
+	if p.GasPerToken.IsEmpty() || multiplier == GasPriceMultiplierBase {
+		return &ret
+	}
+	// the price of 1 gas unit is B/A tokens
+	a := uint64(p.GasPerToken.A) * GasPriceMultiplierBase
+	b := uint64(p.GasPerToken.B) * uint64(multiplier)
+	ret.GasPerToken = reduceRatio(a, b)
+	return &ret
+}
+
+// reduceRatio returns the ratio a:b, approximated so that both terms fit in
+// 32 bits
+func reduceRatio(a, b uint64) util.Ratio32 {
+	gcd := new(big.Int).GCD(nil, nil, new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)).Uint64()
+	if gcd > 1 {
+		a /= gcd
+		b /= gcd
+	}
+	for a > math.MaxUint32 || b > math.MaxUint32 {
+		a = max(a>>1, 1)
+		b = max(b>>1, 1)
+	}
+	return util.Ratio32{A: uint32(a), B: uint32(b)}
+}
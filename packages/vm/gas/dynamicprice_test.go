@@ -0,0 +1,82 @@
+package gas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/v2/packages/parameters"
+	"github.com/iotaledger/wasp/v2/packages/util"
+)
+
+func TestDynamicGasPriceNextMultiplier(t *testing.T) {
+	p := &DynamicGasPricePolicy{
+		TargetBlockUtilization: 50,
+		MaxChangePerBlock:      10,
+		MinMultiplier:          GasPriceMultiplierBase,
+		MaxMultiplier:          2 * GasPriceMultiplierBase,
+		IdleBlockInterval:      10,
+	}
+	require.True(t, p.IsValid())
+	const maxGas = 1_000_000
+
+	// at target: unchanged
+	require.EqualValues(t, 12_000, p.NextMultiplier(12_000, maxGas/2, maxGas))
+	// full block: +10%
+	require.EqualValues(t, 13_200, p.NextMultiplier(12_000, maxGas, maxGas))
+	// 75%: +5%
+	require.EqualValues(t, 12_600, p.NextMultiplier(12_000, maxGas*3/4, maxGas))
+	// empty block: -10%
+	require.EqualValues(t, 10_800, p.NextMultiplier(12_000, 0, maxGas))
+	// bounds
+	require.EqualValues(t, GasPriceMultiplierBase, p.NextMultiplier(GasPriceMultiplierBase, 0, maxGas))
+	require.EqualValues(t, 2*GasPriceMultiplierBase, p.NextMultiplier(2*GasPriceMultiplierBase, maxGas, maxGas))
+	// the gas burned can exceed the target by more than 100%
+	require.EqualValues(t, 2*GasPriceMultiplierBase, p.NextMultiplier(19_000, 5*maxGas, maxGas))
+
+	require.False(t, (&DynamicGasPricePolicy{TargetBlockUtilization: 50, MaxChangePerBlock: 10, MinMultiplier: 2, MaxMultiplier: 1, IdleBlockInterval: 10}).IsValid())
+	require.False(t, (&DynamicGasPricePolicy{TargetBlockUtilization: 0, MaxChangePerBlock: 10, MinMultiplier: 1, MaxMultiplier: 1, IdleBlockInterval: 10}).IsValid())
+	require.False(t, (&DynamicGasPricePolicy{TargetBlockUtilization: 50, MaxChangePerBlock: 10, MinMultiplier: 1, MaxMultiplier: 1}).IsValid())
+}
+
+func TestDynamicGasPriceIdleMultiplier(t *testing.T) {
+	p := &DynamicGasPricePolicy{
+		TargetBlockUtilization: 50,
+		MaxChangePerBlock:      10,
+		MinMultiplier:          GasPriceMultiplierBase,
+		MaxMultiplier:          2 * GasPriceMultiplierBase,
+		IdleBlockInterval:      10,
+	}
+	const maxGas = 1_000_000
+
+	// the first interval is the block itself
+	require.EqualValues(t, 12_000, p.IdleMultiplier(12_000, 5*time.Second, maxGas))
+	require.EqualValues(t, 12_000, p.IdleMultiplier(12_000, 19*time.Second, maxGas))
+	// one empty block: -10%
+	require.EqualValues(t, 10_800, p.IdleMultiplier(12_000, 20*time.Second, maxGas))
+	// two empty blocks
+	require.EqualValues(t, 10_000, p.IdleMultiplier(12_000, 30*time.Second, maxGas))
+	// long idle periods reach the minimum
+	require.EqualValues(t, GasPriceMultiplierBase, p.IdleMultiplier(2*GasPriceMultiplierBase, 1000*time.Hour, maxGas))
+}
+
+func TestFeePolicyWithGasPriceMultiplier(t *testing.T) {
+	p := DefaultFeePolicy()
+	require.Equal(t, p.GasPerToken, p.WithGasPriceMultiplier(GasPriceMultiplierBase).GasPerToken)
+
+	doubled := p.WithGasPriceMultiplier(2 * GasPriceMultiplierBase)
+	require.Equal(t, util.Ratio32{A: 1, B: 20}, doubled.GasPerToken)
+	require.EqualValues(t, 2*p.FeeFromGas(1000, nil, parameters.BaseTokenDecimals), doubled.FeeFromGas(1000, nil, parameters.BaseTokenDecimals))
+	require.EqualValues(t,
+		p.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals).Uint64()*2,
+		doubled.DefaultGasPriceFullDecimals(parameters.BaseTokenDecimals).Uint64(),
+	)
+	require.Equal(t, util.Ratio32{A: 2, B: 25}, p.WithGasPriceMultiplier(12_500).GasPerToken)
+
+	free := &FeePolicy{EVMGasRatio: DefaultEVMGasRatio}
+	require.True(t, free.WithGasPriceMultiplier(2*GasPriceMultiplierBase).GasPerToken.IsEmpty())
+
+	// the original policy is not modified
+	require.Equal(t, DefaultGasPerToken, p.GasPerToken)
+}
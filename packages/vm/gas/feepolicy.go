@@ -78,6 +78,39 @@ func (p *FeePolicy) FeeFromGas(gasUnits uint64, evmGasPrice *big.Int, l1BaseToke
 	return FeeFromGasWithGasPrice(gasUnits, evmGasPrice, l1BaseTokenDecimals)
 }
 
+// ApplyFeeMultiplier returns the fee multiplied by the given multiplier, in
+// basis points (see GasPriceMultiplierBase)
+func ApplyFeeMultiplier(fee coin.Value, multiplier uint32) coin.Value {
+	if multiplier == GasPriceMultiplierBase {
+		return fee
+	}
+	ret := new(big.Int).SetUint64(uint64(fee))
+	ret.Mul(ret, big.NewInt(int64(multiplier)))
+	ret.Quo(ret, big.NewInt(GasPriceMultiplierBase))
+	if !ret.IsUint64() {
+		return coin.Value(math.MaxUint64)
+	}
+	return coin.Value(ret.Uint64())
+}
+
+// RemoveFeeMultiplier is the inverse of ApplyFeeMultiplier: it returns the
+// fee that, once multiplied, is equal to the given amount of tokens
+func RemoveFeeMultiplier(tokens coin.Value, multiplier uint32) coin.Value {
+	if multiplier == GasPriceMultiplierBase {
+		return tokens
+	}
+	if multiplier == 0 {
+		return coin.Value(math.MaxUint64)
+	}
+	ret := new(big.Int).SetUint64(uint64(tokens))
+	ret.Mul(ret, big.NewInt(GasPriceMultiplierBase))
+	ret.Quo(ret, big.NewInt(int64(multiplier)))
+	if !ret.IsUint64() {
+		return coin.Value(math.MaxUint64)
+	}
+	return coin.Value(ret.Uint64())
+}
+
 func (p *FeePolicy) MinFee(evmGasPrice *big.Int, l1BaseTokenDecimals uint8) coin.Value {
 	return p.FeeFromGas(BurnCodeMinimumGasPerRequest1P.Cost(), evmGasPrice, l1BaseTokenDecimals)
 }
@@ -1,6 +1,7 @@
 package gas
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -60,3 +61,13 @@ func TestFeePolicyAffordableGas(t *testing.T) {
 		))
 	}
 }
+
+func TestFeeMultiplier(t *testing.T) {
+	require.EqualValues(t, 1000, ApplyFeeMultiplier(1000, GasPriceMultiplierBase))
+	require.EqualValues(t, 250, ApplyFeeMultiplier(1000, GasPriceMultiplierBase/4))
+	require.EqualValues(t, 0, ApplyFeeMultiplier(1000, 0))
+	require.EqualValues(t, 4000, RemoveFeeMultiplier(1000, GasPriceMultiplierBase/4))
+	require.EqualValues(t, 1000, ApplyFeeMultiplier(RemoveFeeMultiplier(1000, GasPriceMultiplierBase/4), GasPriceMultiplierBase/4))
+	require.EqualValues(t, uint64(math.MaxUint64), RemoveFeeMultiplier(1000, 0))
+	require.EqualValues(t, uint64(math.MaxUint64), RemoveFeeMultiplier(math.MaxUint64, 1))
+}
@@ -30,6 +30,8 @@ const (
 	BurnCodeMinimumGasPerRequest1P
 
 	BurnCodeEVM1P
+
+	BurnCodeDecodeLockups1P
 )
 
 // burnTable contains all possible burn codes with their burn value computing functions
@@ -56,6 +58,7 @@ var burnTable = BurnTable{
 	BurnCodeUtilsBLSAggregateBLS1P:     {"bls aggregate", linear(CoefBLSAggregate)},
 	BurnCodeMinimumGasPerRequest1P:     {"minimum gas per request", minBurn(10000)},
 	BurnCodeEVM1P:                      {"evm", linear(1)},
+	BurnCodeDecodeLockups1P:            {"lockups", linear(50)}, // 50 gas per lockup
 }
 
 const (
@@ -1,6 +1,8 @@
 package vmimpl
 
 import (
+	"time"
+
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/isc/coreutil"
 	"github.com/iotaledger/wasp/v2/packages/kv"
@@ -74,16 +76,24 @@ func (vmctx *vmContext) accountsStateWriterFromChainState(chainState kv.KVStore)
 }
 
 func (vmctx *vmContext) accountsStateWriter(contractState kv.KVStore) *accounts.StateWriter {
-	return accounts.NewStateWriter(vmctx.schemaVersion, contractState)
+	s := accounts.NewStateWriter(vmctx.schemaVersion, contractState)
+	s.SetTimeSource(func() time.Time { return vmctx.task.Timestamp })
+	return s
 }
 
 func (reqctx *requestContext) accountsStateWriter(gasBurn bool) *accounts.StateWriter {
-	return reqctx.vm.accountsStateWriter(accounts.Contract.StateSubrealm(reqctx.chainState(gasBurn)))
+	s := reqctx.vm.accountsStateWriter(accounts.Contract.StateSubrealm(reqctx.chainState(gasBurn)))
+	if gasBurn {
+		s.SetGasBurn(reqctx.GasBurn)
+	}
+	return s
 }
 
 func (reqctx *requestContext) callAccounts(f func(*accounts.StateWriter)) {
 	reqctx.callCore(accounts.Contract, func(contractState kv.KVStore) {
-		f(reqctx.vm.accountsStateWriter(contractState))
+		s := reqctx.vm.accountsStateWriter(contractState)
+		s.SetGasBurn(reqctx.GasBurn)
+		f(s)
 	})
 }
 
@@ -0,0 +1,22 @@
+package vmimpl
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/kv"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+)
+
+// updateGasPrice adjusts the dynamic gas price of the current block, based
+// on the gas burned in the previous block and the time elapsed since then
+func (vmctx *vmContext) updateGasPrice(chainState kv.KVStore) {
+	blockIndex := vmctx.stateDraft.BlockIndex()
+	if blockIndex == 0 {
+		return
+	}
+	prevBlock, ok := blocklog.NewStateReaderFromChainState(chainState).GetBlockInfo(blockIndex - 1)
+	if !ok {
+		return
+	}
+	state := governance.NewStateWriter(governance.Contract.StateSubrealm(chainState))
+	state.UpdateGasPriceMultiplier(prevBlock.GasBurned, vmctx.task.Timestamp.Sub(prevBlock.Timestamp))
+}
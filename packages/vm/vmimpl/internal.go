@@ -16,6 +16,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/vm/core/corecontracts"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/root"
+	"github.com/iotaledger/wasp/v2/packages/vm/gas"
 )
 
 // creditToAccount credits assets to the chain ledger
@@ -59,6 +60,15 @@ func (reqctx *requestContext) GetBaseTokensBalanceDiscardRemainder(agentID isc.A
 	return bal
 }
 
+// GetReleasableBaseTokens returns the base tokens of the account that are not
+// locked, see accounts.Lockup
+func (reqctx *requestContext) GetReleasableBaseTokens(agentID isc.AgentID) (bts coin.Value) {
+	reqctx.callAccounts(func(s *accounts.StateWriter) {
+		bts = s.GetReleasableBaseTokens(agentID)
+	})
+	return bts
+}
+
 func (reqctx *requestContext) HasEnoughForAllowance(agentID isc.AgentID, allowance *isc.Assets) bool {
 	var ret bool
 	reqctx.callAccounts(func(s *accounts.StateWriter) {
@@ -110,7 +120,44 @@ func (reqctx *requestContext) GetSenderTokenBalanceForFees() coin.Value {
 	if sender == nil {
 		return 0
 	}
-	return reqctx.GetBaseTokensBalanceDiscardRemainder(sender)
+	if reqctx.gas.sponsor != nil {
+		return reqctx.GetSponsoredGasFeeBalance(reqctx.gas.sponsor, sender)
+	}
+	return reqctx.GetReleasableBaseTokens(sender)
+}
+
+func (reqctx *requestContext) GetSponsoredGasFeeBalance(sponsor, sender isc.AgentID) coin.Value {
+	var ret coin.Value
+	reqctx.callAccounts(func(s *accounts.StateWriter) {
+		ret = s.GetSponsoredGasFeeBalance(sponsor, sender)
+	})
+	return ret
+}
+
+// approvedSponsor returns the sponsor of the request if it has approved the
+// sender, or nil if the gas fee must be charged to the sender
+func (reqctx *requestContext) approvedSponsor() isc.AgentID {
+	sponsor := isc.RequestSponsor(reqctx.req)
+	sender := reqctx.req.SenderAccount()
+	if sponsor == nil || sender == nil || sponsor.Equals(sender) {
+		return nil
+	}
+	var approved coin.Value
+	reqctx.callAccounts(func(s *accounts.StateWriter) {
+		approved = s.GetSponsorship(sponsor, sender)
+	})
+	if approved == 0 {
+		return nil
+	}
+	return sponsor
+}
+
+// gasFeePayer returns the account charged for the gas fee of the request
+func (reqctx *requestContext) gasFeePayer() isc.AgentID {
+	if reqctx.gas.sponsor != nil {
+		return reqctx.gas.sponsor
+	}
+	return reqctx.req.SenderAccount()
 }
 
 func (reqctx *requestContext) requestLookupKey() blocklog.RequestLookupKey {
@@ -123,15 +170,21 @@ func (reqctx *requestContext) eventLookupKey() *blocklog.EventLookupKey {
 
 func (reqctx *requestContext) writeReceiptToBlockLog(vmError *isc.VMError) *blocklog.RequestReceipt {
 	receipt := &blocklog.RequestReceipt{
+		SchemaVersion: blocklog.RequestReceiptSchemaVersion(reqctx.vm.schemaVersion),
 		Request:       reqctx.req,
 		GasBudget:     reqctx.gas.budgetAdjusted,
 		GasBurned:     reqctx.gas.burned,
 		GasFeeCharged: reqctx.gas.feeCharged,
 		GasBurnLog:    reqctx.gas.burnLog,
+		Sponsor:       reqctx.gas.sponsor,
 		BlockIndex:    reqctx.vm.stateDraft.BlockIndex(),
 		RequestIndex:  reqctx.requestEventIndex,
 	}
 
+	if reqctx.gas.feeMultiplier != gas.GasPriceMultiplierBase {
+		receipt.FeeMultiplier = &reqctx.gas.feeMultiplier
+	}
+
 	if vmError != nil {
 		b := vmError.Bytes()
 		if len(b) > isc.VMErrorMessageLimit {
@@ -112,11 +112,13 @@ func (reqctx *requestContext) consumeRequest() {
 		return
 	}
 
-	senderBaseTokens := req.Assets().BaseTokens() + reqctx.GetBaseTokensBalanceDiscardRemainder(sender)
+	senderBaseTokens := req.Assets().BaseTokens() + reqctx.GetReleasableBaseTokens(sender)
 
 	// check if the sender has enough balance to cover the minimum gas fee
 	if reqctx.shouldChargeGasFee() {
 		minReqCost := reqctx.ChainInfo().GasFeePolicy.MinFee(isc.RequestGasPrice(reqctx.req), parameters.BaseTokenDecimals)
+		// the sender may have a discount on the gas fee, as in the mempool check
+		minReqCost = gas.ApplyFeeMultiplier(minReqCost, reqctx.senderFeeMultiplier())
 		if senderBaseTokens < minReqCost {
 			// TODO: this should probably not skip the request, and also the check
 			// should be done in L1 so the request is rejected before it reaches the mempool
@@ -161,10 +163,26 @@ func (reqctx *requestContext) shouldChargeGasFee() bool {
 	return true
 }
 
+// senderFeeMultiplier returns the multiplier applied to the gas fee of the
+// sender, set by the chain admin
+func (reqctx *requestContext) senderFeeMultiplier() uint32 {
+	sender := reqctx.req.SenderAccount()
+	if sender == nil {
+		return gas.GasPriceMultiplierBase
+	}
+	var ret uint32
+	reqctx.callCore(governance.Contract, func(s kv.KVStore) {
+		ret = governance.NewStateReader(s).GetFeeMultiplier(sender)
+	})
+	return ret
+}
+
 func (reqctx *requestContext) prepareGasBudget() {
+	reqctx.gas.feeMultiplier = reqctx.senderFeeMultiplier()
 	if !reqctx.shouldChargeGasFee() {
 		return
 	}
+	reqctx.gas.sponsor = reqctx.approvedSponsor()
 	reqctx.gasSetBudget(reqctx.calculateAffordableGasBudget())
 }
 
@@ -343,8 +361,15 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget uint64, max
 
 	// calculate how many tokens for gas fee can be guaranteed after taking into account the allowance
 	guaranteedFeeTokens := reqctx.calcGuaranteedFeeTokens()
-	// calculate how many tokens maximum will be charged taking into account the budget
 	gasPrice := isc.RequestGasPrice(reqctx.req)
+	if reqctx.gas.feeMultiplier != gas.GasPriceMultiplierBase {
+		// the sender pays a discounted fee, so the same tokens are worth more gas
+		guaranteedFeeTokens = min(
+			gas.RemoveFeeMultiplier(guaranteedFeeTokens, reqctx.gas.feeMultiplier),
+			reqctx.vm.chainInfo.GasFeePolicy.FeeFromGas(gasBudget, gasPrice, parameters.BaseTokenDecimals),
+		)
+	}
+	// calculate how many tokens maximum will be charged taking into account the budget
 	f1, f2 := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(
 		gasBudget,
 		guaranteedFeeTokens,
@@ -372,7 +397,11 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget uint64, max
 // calcGuaranteedFeeTokens return the maximum tokens (base tokens or native) can be guaranteed for the fee,
 // taking into account allowance (which must be 'reserved')
 func (reqctx *requestContext) calcGuaranteedFeeTokens() coin.Value {
-	tokensGuaranteed := reqctx.GetBaseTokensBalanceDiscardRemainder(reqctx.req.SenderAccount())
+	if reqctx.gas.sponsor != nil {
+		// the allowance is debited from the sender, not from the sponsor
+		return reqctx.GetSenderTokenBalanceForFees()
+	}
+	tokensGuaranteed := reqctx.GetReleasableBaseTokens(reqctx.req.SenderAccount())
 
 	// safely subtract the allowed from the sender to the target
 	allowance, err := reqctx.req.Allowance()
@@ -392,7 +421,8 @@ func (reqctx *requestContext) calcGuaranteedFeeTokens() coin.Value {
 	return tokensGuaranteed
 }
 
-// chargeGasFee takes burned tokens from the sender's account
+// chargeGasFee takes burned tokens from the account of the sender, or of the
+// sponsor if the request is sponsored
 // It should always be enough because gas budget is set affordable
 func (reqctx *requestContext) chargeGasFee() {
 	defer func() {
@@ -418,7 +448,7 @@ func (reqctx *requestContext) chargeGasFee() {
 		parameters.BaseTokenDecimals,
 	) {
 		// user didn't specify enough base tokens to cover the minimum request fee, charge whatever is present in the user's account
-		availableToPayFee = reqctx.GetSenderTokenBalanceForFees()
+		availableToPayFee = gas.RemoveFeeMultiplier(reqctx.GetSenderTokenBalanceForFees(), reqctx.gas.feeMultiplier)
 	}
 
 	// total fees to charge
@@ -428,6 +458,9 @@ func (reqctx *requestContext) chargeGasFee() {
 		gasPrice,
 		parameters.BaseTokenDecimals,
 	)
+	// apply the discount of the sender, if any
+	sendToPayout = gas.ApplyFeeMultiplier(sendToPayout, reqctx.gas.feeMultiplier)
+	sendToValidator = gas.ApplyFeeMultiplier(sendToValidator, reqctx.gas.feeMultiplier)
 	reqctx.gas.feeCharged = sendToPayout + sendToValidator
 
 	// calc gas totals
@@ -438,7 +471,12 @@ func (reqctx *requestContext) chargeGasFee() {
 		return
 	}
 
-	sender := reqctx.req.SenderAccount()
+	sender := reqctx.gasFeePayer()
+	if reqctx.gas.sponsor != nil {
+		reqctx.callAccounts(func(s *accounts.StateWriter) {
+			s.ConsumeSponsorship(reqctx.gas.sponsor, reqctx.req.SenderAccount(), reqctx.gas.feeCharged)
+		})
+	}
 	if sendToValidator != 0 {
 		reqctx.mustMoveBetweenAccounts(
 			sender,
@@ -38,9 +38,10 @@ func newVMContext(
 	txbuilder vmtxbuilder.TransactionBuilder,
 ) *vmContext {
 	return &vmContext{
-		task:       task,
-		stateDraft: stateDraft,
-		txbuilder:  txbuilder,
+		task:                    task,
+		stateDraft:              stateDraft,
+		txbuilder:               txbuilder,
+		issuedScheduledRequests: map[isc.RequestID]bool{},
 	}
 }
 
@@ -85,10 +86,17 @@ func runTask(task *vm.VMTask) *vm.VMTaskResult {
 	vmctx := newVMContext(task, stateDraft, txbuilder)
 	vmctx.init()
 
+	maintenanceMode := governance.NewStateReaderFromChainState(stateDraft).GetMaintenanceStatus()
+	reqs := vmctx.task.Requests
+	if vmctx.task.WillProduceBlock() && !maintenanceMode {
+		// the scheduled jobs are executed at the start of the block
+		reqs = append(vmctx.scheduledRequests(), reqs...)
+	}
+
 	// run the batch of requests
 	requestResults, numSuccess, numOffLedger := vmctx.runRequests(
-		vmctx.task.Requests,
-		governance.NewStateReaderFromChainState(stateDraft).GetMaintenanceStatus(),
+		reqs,
+		maintenanceMode,
 		vmctx.task.Log,
 	)
 	numProcessed, err := safecast.Convert[uint16](len(requestResults))
@@ -144,6 +152,7 @@ func (vmctx *vmContext) init() {
 	vmctx.withStateUpdate(func(chainState kv.KVStore) {
 		vmctx.runMigrations(chainState, vmctx.task.Migrations)
 		vmctx.schemaVersion = root.NewStateReaderFromChainState(chainState).GetSchemaVersion()
+		vmctx.updateGasPrice(chainState)
 
 		// TODO: save the ObjectID of the newly created tokens, foundries and objects in the previous block
 		/*
@@ -152,6 +161,8 @@ func (vmctx *vmContext) init() {
 				UpdateLatestOutputID(vmctx.task.AnchorOutputID, vmctx.task.AnchorOutput.StateIndex)
 		*/
 	})
+	// the gas price may have changed
+	vmctx.loadChainConfig()
 }
 
 func (vmctx *vmContext) runRequests(
@@ -182,6 +193,9 @@ func (vmctx *vmContext) runRequests(
 		if req.IsOffLedger() {
 			numOffLedger++
 		}
+		if scheduledReq, ok := req.(*isc.ScheduledRequest); ok {
+			vmctx.recordScheduledResult(scheduledReq, result.Receipt.Error != nil)
+		}
 
 		if result.Receipt.Error != nil {
 			log.LogDebugf("runTask, ERROR running request: %s, error: %v", req.ID().String(), result.Receipt.Error)
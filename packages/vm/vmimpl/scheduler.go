@@ -0,0 +1,50 @@
+package vmimpl
+
+import (
+	"errors"
+
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/kv"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+)
+
+// scheduledRequests returns the requests of the jobs due in the current
+// block, and reschedules the jobs. The requests are executed before the
+// ones of the batch.
+func (vmctx *vmContext) scheduledRequests() []isc.Request {
+	blockIndex := vmctx.stateDraft.BlockIndex()
+	reqs := []isc.Request{}
+	vmctx.withStateUpdate(func(chainState kv.KVStore) {
+		state := governance.NewStateWriter(governance.Contract.StateSubrealm(chainState))
+		for _, job := range state.GetDueScheduledJobs(blockIndex) {
+			req := isc.NewScheduledRequest(vmctx.ChainID(), job.ID, blockIndex, job.Owner, job.Message, job.GasBudget)
+			vmctx.task.Log.LogDebugf("scheduled job %d due: %s", job.ID, req.ID())
+			reqs = append(reqs, req)
+			vmctx.issuedScheduledRequests[req.ID()] = true
+			state.AdvanceScheduledJob(job, blockIndex)
+		}
+	})
+	return reqs
+}
+
+// recordScheduledResult records the outcome of the request of a scheduled
+// job, so that a job that keeps failing is cancelled
+func (vmctx *vmContext) recordScheduledResult(req *isc.ScheduledRequest, failed bool) {
+	vmctx.withStateUpdate(func(chainState kv.KVStore) {
+		state := governance.NewStateWriter(governance.Contract.StateSubrealm(chainState))
+		if state.RecordScheduledJobResult(req.JobID(), failed) {
+			vmctx.task.Log.LogInfof("scheduled job %d cancelled after %d consecutive failures", req.JobID(), governance.MaxScheduledJobFailures)
+		}
+	})
+}
+
+// checkReasonToSkipScheduled checks reasons to skip a scheduled request.
+// When producing a block, only the requests issued by the VM itself are
+// accepted. Otherwise (e.g. when tracing a past block) the scheduled requests
+// found in the batch are executed as they are.
+func (reqctx *requestContext) checkReasonToSkipScheduled() error {
+	if reqctx.vm.task.WillProduceBlock() && !reqctx.vm.issuedScheduledRequests[reqctx.req.ID()] {
+		return errors.New("scheduled request not issued by the VM")
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ func (reqctx *requestContext) earlyCheckReasonToSkip(maintenanceMode bool) error
 		return errors.New("skipped due to maintenance mode")
 	}
 
+	if _, ok := reqctx.req.(*isc.ScheduledRequest); ok {
+		return reqctx.checkReasonToSkipScheduled()
+	}
 	if reqctx.req.IsOffLedger() {
 		return reqctx.checkReasonToSkipOffLedger()
 	}
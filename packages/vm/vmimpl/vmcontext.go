@@ -33,6 +33,10 @@ type vmContext struct {
 
 	onBlockCloseCallbacks []blockCloseCallback
 
+	// issuedScheduledRequests contains the IDs of the requests of the
+	// scheduled jobs executed in this block
+	issuedScheduledRequests map[isc.RequestID]bool
+
 	schemaVersion isc.SchemaVersion
 }
 type blockCloseCallback func(requestIndex uint16)
@@ -74,6 +78,10 @@ type requestGas struct {
 	burned uint64
 	// tokens charged
 	feeCharged coin.Value
+	// sponsor paying the gas fee instead of the sender, if any
+	sponsor isc.AgentID
+	// multiplier applied to the gas fee of the sender, in basis points
+	feeMultiplier uint32
 	// burn history. If disabled, it is nil
 	burnLog *gas.BurnLog
 	// used to allow tracing stardust requests
@@ -109,7 +117,7 @@ func (vmctx *vmContext) extractBlock(
 ) (uint32, *state.L1Commitment, time.Time) {
 	vmctx.withStateUpdate(func(chainState kv.KVStore) {
 		vmctx.saveBlockInfo(chainState, numRequests, numSuccess, numOffLedger, gasCoinTopUp)
-		evmimpl.MintBlock(evm.Contract.StateSubrealm(chainState), vmctx.chainInfo, vmctx.task.Timestamp)
+		evmimpl.MintBlock(evm.Contract.StateSubrealm(chainState), vmctx.chainInfo, vmctx.schemaVersion, vmctx.task.Timestamp)
 	})
 
 	block, err := vmctx.task.Store.ExtractBlock(vmctx.stateDraft)
@@ -148,7 +156,7 @@ func (vmctx *vmContext) saveBlockInfo(
 
 	blocklogState := blocklog.NewStateWriter(blocklog.Contract.StateSubrealm(chainState))
 	blocklogState.SaveNextBlockInfo(blockInfo)
-	blocklogState.Prune(blockInfo.BlockIndex, vmctx.chainInfo.BlockKeepAmount)
+	blocklogState.Prune(vmctx.schemaVersion, blockInfo.BlockIndex, vmctx.chainInfo.BlockKeepAmount)
 	vmctx.task.Log.LogDebugf("saved blockinfo:\n%s", blockInfo)
 }
 
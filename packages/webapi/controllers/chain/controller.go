@@ -178,8 +178,41 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetSummary("Get the contents of the mempool.").
 		SetOperationId("getMempoolContents")
 
+	adminAPI.GET("chain/mempool/requests", c.getMempoolRequests, authentication.ValidatePermissions([]string{permissions.Read})).
+		AddResponse(http.StatusOK, "The requests in the mempool", mocker.Get([]models.MempoolRequestJSON{}), nil).
+		SetSummary("Get the requests in the mempool.").
+		SetOperationId("getMempoolRequests")
+
 	adminAPI.POST("chain/dump-accounts", c.dumpAccounts, authentication.ValidatePermissions([]string{permissions.Write})).
 		AddResponse(http.StatusOK, "Accounts dump will be produced", nil, nil).
 		SetOperationId("dump-accounts").
 		SetSummary("dump accounts information into a humanly-readable format")
+
+	adminAPI.POST("chain/snapshots", c.createSnapshot, authentication.ValidatePermissions([]string{permissions.Write})).
+		AddParamBody(mocker.Get(models.CreateSnapshotRequest{}), "CreateSnapshotRequest", "The block to make the snapshot of", false).
+		AddResponse(http.StatusAccepted, "Snapshot will be created in the local snapshot folder", mocker.Get(models.CreateSnapshotResponse{}), nil).
+		SetOperationId("createSnapshot").
+		SetSummary("Create a snapshot of a retained block state")
+
+	adminAPI.GET("chain/snapshots", c.getSnapshots, authentication.ValidatePermissions([]string{permissions.Read})).
+		AddResponse(http.StatusOK, "A list of the snapshots in the local snapshot folder", mocker.Get([]models.SnapshotResponse{}), nil).
+		SetOperationId("getSnapshots").
+		SetSummary("Get the local snapshots")
+
+	adminAPI.DELETE("chain/snapshots/:"+paramSnapshotFileName, c.deleteSnapshot, authentication.ValidatePermissions([]string{permissions.Write})).
+		AddParamPath("", paramSnapshotFileName, "The name of the snapshot file").
+		AddResponse(http.StatusOK, "Snapshot was successfully deleted", nil, nil).
+		SetOperationId("deleteSnapshot").
+		SetSummary("Delete a local snapshot")
+
+	type exportSnapshotQuery struct {
+		blockIndex uint32 `swagger:"min(0),desc(The index of the block to export the snapshot of; the latest block if omitted)"`
+	}
+
+	adminAPI.GET("chain/snapshots/export", c.exportSnapshot, authentication.ValidatePermissions([]string{permissions.Write})).
+		AddParamQueryNested(exportSnapshotQuery{}).
+		SetResponseContentType("application/octet-stream").
+		AddResponse(http.StatusOK, "The snapshot of the block state", []byte{}, nil).
+		SetOperationId("exportSnapshot").
+		SetSummary("Export a snapshot of a retained block state")
 }
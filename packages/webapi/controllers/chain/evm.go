@@ -3,12 +3,15 @@ package chain
 import (
 	"github.com/labstack/echo/v4"
 
+	"github.com/iotaledger/wasp/v2/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/v2/packages/webapi/controllers/controllerutils"
 )
 
 func (c *Controller) handleJSONRPC(e echo.Context) error {
 	controllerutils.SetOperation(e, "evm_json_rpc")
-	return c.evmService.HandleJSONRPC(e.Request(), e.Response())
+	request := e.Request()
+	request = request.WithContext(jsonrpc.ContextWithClientID(request.Context(), e.RealIP()))
+	return c.evmService.HandleJSONRPC(request, e.Response())
 }
 
 func (c *Controller) handleWebsocket(e echo.Context) error {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"fortio.org/safecast"
 	"github.com/labstack/echo/v4"
@@ -23,11 +24,10 @@ func (c *Controller) getMempoolContents(e echo.Context) error {
 
 	pr, pw := io.Pipe()
 
-	// TODO: This makes unprotected concurrent access to the MP state.
 	go func() {
 		defer pw.Close()
 
-		ch.IterateMempool(func(req isc.Request) bool {
+		ch.IterateMempool(func(req isc.Request, _ time.Time) bool {
 			jsonData, err := json.Marshal(models.RequestToJSONObject(req))
 			if err != nil {
 				return false
@@ -52,3 +52,20 @@ func (c *Controller) getMempoolContents(e echo.Context) error {
 
 	return e.Stream(http.StatusOK, "application/octet-stream", pr)
 }
+
+func (c *Controller) getMempoolRequests(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_mempool_requests")
+	ch, err := c.chainService.GetChain()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	requests := []models.MempoolRequestJSON{}
+	ch.IterateMempool(func(req isc.Request, ts time.Time) bool {
+		requests = append(requests, models.MempoolRequestToJSONObject(req, now.Sub(ts)))
+		return true
+	})
+
+	return e.JSON(http.StatusOK, requests)
+}
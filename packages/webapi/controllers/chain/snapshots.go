@@ -0,0 +1,89 @@
+package chain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
+	"github.com/iotaledger/wasp/v2/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/v2/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/v2/packages/webapi/models"
+	"github.com/iotaledger/wasp/v2/packages/webapi/params"
+)
+
+const paramSnapshotFileName = "fileName"
+
+func (c *Controller) createSnapshot(e echo.Context) error {
+	controllerutils.SetOperation(e, "create_snapshot")
+
+	var request models.CreateSnapshotRequest
+	if err := e.Bind(&request); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	fileName, err := c.chainService.CreateSnapshot(request.BlockIndex)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusAccepted, models.CreateSnapshotResponse{FileName: fileName})
+}
+
+func (c *Controller) getSnapshots(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_snapshots")
+	localSnapshots, err := c.chainService.ListSnapshots()
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, lo.Map(localSnapshots, func(ls *snapshots.LocalSnapshot, _ int) models.SnapshotResponse {
+		return models.MapSnapshotResponse(ls)
+	}))
+}
+
+func (c *Controller) deleteSnapshot(e echo.Context) error {
+	controllerutils.SetOperation(e, "delete_snapshot")
+	if err := c.chainService.DeleteSnapshot(e.Param(paramSnapshotFileName)); err != nil {
+		return apierrors.InvalidPropertyError(paramSnapshotFileName, err)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+
+func (c *Controller) exportSnapshot(e echo.Context) error {
+	controllerutils.SetOperation(e, "export_snapshot")
+
+	var blockIndex *uint32
+	if blockIndexStr := e.QueryParam(params.ParamBlockIndex); blockIndexStr != "" {
+		index, err := strconv.ParseUint(blockIndexStr, 10, 32)
+		if err != nil {
+			return apierrors.InvalidPropertyError(params.ParamBlockIndex, err)
+		}
+		blockIndex = lo.ToPtr(uint32(index))
+	}
+
+	pr, pw := io.Pipe()
+	// closing the reader unblocks the export, if the client disconnects
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(c.chainService.ExportSnapshot(blockIndex, pw))
+	}()
+	// Errors, which occur before any data is written, are reported as such
+	r := bufio.NewReader(pr)
+	if _, err := r.Peek(1); err != nil {
+		return err
+	}
+
+	fileName := "snapshot.snap"
+	if blockIndex != nil {
+		fileName = fmt.Sprintf("snapshot-%d.snap", *blockIndex)
+	}
+	e.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	return e.Stream(http.StatusOK, "application/octet-stream", r)
+}
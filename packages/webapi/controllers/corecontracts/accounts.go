@@ -42,15 +42,16 @@ func (c *Controller) getAccountBalance(e echo.Context) error {
 		return err
 	}
 
-	assets, err := corecontracts.GetAccountBalance(ch, agentID, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	assets, lockedCoins, err := corecontracts.GetAccountBalance(ch, agentID, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
 	if err != nil {
 		return c.handleViewCallError(err)
 	}
 
 	assetsResponse := &models.AssetsResponse{
-		BaseTokens: assets.BaseTokens().String(),
-		Coins:      models.ToCoinBalancesJSON(assets.Coins),
-		Objects:    models.ToIotaObjectsJSON(&assets.Objects),
+		BaseTokens:  assets.BaseTokens().String(),
+		Coins:       models.ToCoinBalancesJSON(assets.Coins),
+		Objects:     models.ToIotaObjectsJSON(&assets.Objects),
+		LockedCoins: models.ToCoinBalancesJSON(lockedCoins),
 	}
 
 	return e.JSON(http.StatusOK, assetsResponse)
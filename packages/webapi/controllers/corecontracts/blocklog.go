@@ -2,11 +2,12 @@ package corecontracts
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"fortio.org/safecast"
-
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/labstack/echo/v4"
 
 	"github.com/iotaledger/wasp/v2/packages/isc"
@@ -273,3 +274,69 @@ func (c *Controller) getRequestEvents(e echo.Context) error {
 	}
 	return eventsResponse(e, events)
 }
+
+func (c *Controller) getContractEvents(e echo.Context) error {
+	ch, err := c.chainService.GetChain()
+	if err != nil {
+		return err
+	}
+	contractHname, err := params.DecodeHNameFromHNameHexString(e, params.ParamContractHName)
+	if err != nil {
+		return err
+	}
+
+	q := &blocklog.EventsForContractQuery{
+		Contract:   contractHname,
+		BlockRange: &blocklog.BlockRange{},
+	}
+	err = echo.QueryParamsBinder(e).
+		Uint32("fromBlock", &q.BlockRange.From).
+		Uint32("toBlock", &q.BlockRange.To).
+		Uint32("limit", &q.MaxEvents).
+		BindError()
+	if err != nil {
+		return apierrors.InvalidPropertyError("query", err)
+	}
+	if topic := e.QueryParam("topic"); topic != "" {
+		q.Topic = &topic
+	}
+	if after := e.QueryParam("after"); after != "" {
+		b, err := hexutil.Decode(after)
+		if err != nil || len(b) != blocklog.EventLookupKeyLength {
+			return apierrors.InvalidPropertyError("after", errors.New("invalid event lookup key"))
+		}
+		q.After = (*blocklog.EventLookupKey)(b)
+	}
+
+	if e.QueryParam("toBlock") == "" {
+		_, blockInfo, err := corecontracts.GetLatestBlockInfo(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+		if err != nil {
+			return c.handleViewCallError(err)
+		}
+		q.BlockRange.To = blockInfo.BlockIndex
+	}
+	if e.QueryParam("fromBlock") == "" && q.BlockRange.To >= blocklog.MaxBlocksPerContractQuery {
+		q.BlockRange.From = q.BlockRange.To - blocklog.MaxBlocksPerContractQuery + 1
+	}
+	if q.BlockRange.From > q.BlockRange.To {
+		return apierrors.InvalidPropertyError("fromBlock", errors.New("fromBlock must not be greater than toBlock"))
+	}
+	if q.BlockRange.To-q.BlockRange.From >= blocklog.MaxBlocksPerContractQuery {
+		return apierrors.InvalidPropertyError("toBlock", fmt.Errorf("the block range cannot exceed %d blocks", blocklog.MaxBlocksPerContractQuery))
+	}
+
+	res, err := corecontracts.GetEventsForContract(ch, q, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if err != nil {
+		return c.handleViewCallError(err)
+	}
+
+	eventsJSON := make([]*models.EventJSON, len(res.Events))
+	for i, ev := range res.Events {
+		eventsJSON[i] = models.ToJSONStruct(ev)
+	}
+	ret := models.ContractEventsResponse{Events: eventsJSON}
+	if res.Next != nil {
+		ret.Next = hexutil.Encode(res.Next[:])
+	}
+	return e.JSON(http.StatusOK, ret)
+}
@@ -112,6 +112,22 @@ func (c *Controller) addGovernanceContractRoutes(api echoswagger.ApiGroup, mocke
 		SetOperationId("governanceGetChainAdmin").
 		SetDescription("Returns the chain admin").
 		SetSummary("Get the chain admin")
+
+	api.GET("chain/core/governance/blockkeepamount", c.getBlockKeepAmount).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", authentication.ValidationError{}, nil).
+		AddResponse(http.StatusOK, "The block keep amount", mocker.Get(models.GovBlockKeepAmountResponse{}), nil).
+		SetOperationId("governanceGetBlockKeepAmount").
+		SetDescription("Returns the amount of blocks kept in the chain state").
+		SetSummary("Get the block keep amount")
+
+	api.GET("chain/core/governance/feemultipliers", c.getFeeMultipliers).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", authentication.ValidationError{}, nil).
+		AddResponse(http.StatusOK, "The fee multipliers", mocker.Get(models.GovFeeMultipliersResponse{}), nil).
+		SetOperationId("governanceGetFeeMultipliers").
+		SetDescription("Returns the senders that pay a discounted gas fee, and their multipliers").
+		SetSummary("Get the fee multipliers")
 }
 
 func (c *Controller) addBlockLogContractRoutes(api echoswagger.ApiGroup, mocker interfaces.Mocker) {
@@ -210,6 +226,24 @@ func (c *Controller) addBlockLogContractRoutes(api echoswagger.ApiGroup, mocker
 		AddResponse(http.StatusOK, "The events", mocker.Get(models.EventsResponse{}), nil).
 		SetOperationId("blocklogGetEventsOfRequest").
 		SetSummary("Get events of a request")
+
+	//nolint:unused
+	type contractEventsRange struct {
+		fromBlock uint32 `swagger:"min(0),desc(The first block of the range (default: toBlock - 999))"`
+		toBlock   uint32 `swagger:"min(0),desc(The last block of the range (default: latest block). The range cannot exceed 1000 blocks)"`
+		limit     uint32 `swagger:"min(1),desc(The maximum amount of events returned (default and maximum: 1000))"`
+	}
+
+	api.GET("chain/core/blocklog/events/contract/:contractHname", c.getContractEvents).
+		AddParamPath("", params.ParamContractHName, params.DescriptionContractHName).
+		AddParamQueryNested(contractEventsRange{}).
+		AddParamQuery("", "topic", "Only return the events with this topic", false).
+		AddParamQuery("", "after", "Only return the events after this one (Hex), used for pagination", false).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", authentication.ValidationError{}, nil).
+		AddResponse(http.StatusOK, "The events", mocker.Get(models.ContractEventsResponse{}), nil).
+		SetOperationId("blocklogGetEventsOfContract").
+		SetSummary("Get events emitted by a contract in a range of blocks")
 }
 
 func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
@@ -61,3 +61,42 @@ func (c *Controller) getChainAdmin(e echo.Context) error {
 	}
 	return e.JSON(http.StatusOK, chainAdminResponse)
 }
+
+func (c *Controller) getBlockKeepAmount(e echo.Context) error {
+	ch, err := c.chainService.GetChain()
+	if err != nil {
+		return c.handleViewCallError(err)
+	}
+
+	blockKeepAmount, err := corecontracts.GetBlockKeepAmount(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if err != nil {
+		return c.handleViewCallError(err)
+	}
+
+	return e.JSON(http.StatusOK, models.GovBlockKeepAmountResponse{
+		BlockKeepAmount: blockKeepAmount,
+	})
+}
+
+func (c *Controller) getFeeMultipliers(e echo.Context) error {
+	ch, err := c.chainService.GetChain()
+	if err != nil {
+		return c.handleViewCallError(err)
+	}
+
+	feeMultipliers, err := corecontracts.GetFeeMultipliers(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if err != nil {
+		return c.handleViewCallError(err)
+	}
+
+	response := models.GovFeeMultipliersResponse{
+		FeeMultipliers: make([]models.GovFeeMultiplier, len(feeMultipliers)),
+	}
+	for i, m := range feeMultipliers {
+		response.FeeMultipliers[i] = models.GovFeeMultiplier{
+			AgentID:    m.AgentID.String(),
+			Multiplier: m.Multiplier,
+		}
+	}
+	return e.JSON(http.StatusOK, response)
+}
@@ -30,15 +30,17 @@ func GetTotalAssets(ch chain.Chain, blockIndexOrTrieRoot string) (*isc.Assets, e
 	return assets, nil
 }
 
-func GetAccountBalance(ch chain.Chain, agentID isc.AgentID, blockIndexOrTrieRoot string) (*isc.Assets, error) {
+// GetAccountBalance returns the assets of the account, and the coins among
+// them that are locked by vesting lockups
+func GetAccountBalance(ch chain.Chain, agentID isc.AgentID, blockIndexOrTrieRoot string) (*isc.Assets, isc.CoinBalances, error) {
 	ret, err := common.CallView(ch, accounts.ViewBalance.Message(&agentID), blockIndexOrTrieRoot)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	coinBalances, err := accounts.ViewBalance.DecodeOutput(ret)
+	coinBalances, lockedCoins, err := accounts.ViewBalance.DecodeOutput(ret)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	assets := isc.NewEmptyAssets()
@@ -49,7 +51,7 @@ func GetAccountBalance(ch chain.Chain, agentID isc.AgentID, blockIndexOrTrieRoot
 	// Objects currently unsupported
 	// TODO: Create some kind of ViewObjects function and map both.
 
-	return assets, nil
+	return assets, lockedCoins, nil
 }
 
 func GetAccountObjects(ch chain.Chain, agentID isc.AgentID, blockIndexOrTrieRoot string) ([]isc.IotaObject, error) {
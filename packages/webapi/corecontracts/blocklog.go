@@ -100,3 +100,11 @@ func GetEventsForBlock(ch chain.Chain, blockIndex uint32, blockIndexOrTrieRoot s
 	}
 	return blocklog.ViewGetEventsForBlock.DecodeOutput(ret)
 }
+
+func GetEventsForContract(ch chain.Chain, q *blocklog.EventsForContractQuery, blockIndexOrTrieRoot string) (*blocklog.EventsForContractResponse, error) {
+	ret, err := common.CallView(ch, blocklog.ViewGetEventsForContract.Message(q), blockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+	return blocklog.ViewGetEventsForContract.DecodeOutput(ret)
+}
@@ -23,3 +23,19 @@ func GetChainInfo(ch chain.Chain, blockIndexOrTrieRoot string) (*isc.ChainInfo,
 	}
 	return governance.ViewGetChainInfo.DecodeOutput(ret)
 }
+
+func GetBlockKeepAmount(ch chain.Chain, blockIndexOrTrieRoot string) (int32, error) {
+	ret, err := common.CallView(ch, governance.ViewGetBlockKeepAmount.Message(), blockIndexOrTrieRoot)
+	if err != nil {
+		return 0, err
+	}
+	return governance.ViewGetBlockKeepAmount.DecodeOutput(ret)
+}
+
+func GetFeeMultipliers(ch chain.Chain, blockIndexOrTrieRoot string) ([]*governance.FeeMultiplier, error) {
+	ret, err := common.CallView(ch, governance.ViewGetFeeMultipliers.Message(), blockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+	return governance.ViewGetFeeMultipliers.DecodeOutput(ret)
+}
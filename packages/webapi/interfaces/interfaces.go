@@ -4,6 +4,7 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/iotaledger/wasp/v2/clients/iota-go/iotago"
 	"github.com/iotaledger/wasp/v2/packages/chain"
+	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/parameters"
@@ -45,6 +47,10 @@ type ChainService interface {
 	GetState(stateKey []byte) (state []byte, err error)
 	WaitForRequestProcessed(ctx context.Context, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error)
 	RotateTo(ctx context.Context, rotateToAddress *iotago.Address) error
+	CreateSnapshot(blockIndex *uint32) (string, error)
+	ListSnapshots() ([]*snapshots.LocalSnapshot, error)
+	DeleteSnapshot(fileName string) error
+	ExportSnapshot(blockIndex *uint32, w io.Writer) error
 }
 
 type EVMService interface {
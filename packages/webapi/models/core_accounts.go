@@ -9,9 +9,10 @@ type AccountListResponse struct {
 }
 
 type AssetsResponse struct {
-	BaseTokens string           `json:"baseTokens" swagger:"required,desc(The base tokens (uint64 as string))"`
-	Coins      []CoinJSON       `json:"coins" swagger:"required"`
-	Objects    []IotaObjectJSON `json:"objects" swagger:"required"`
+	BaseTokens  string           `json:"baseTokens" swagger:"required,desc(The base tokens (uint64 as string))"`
+	Coins       []CoinJSON       `json:"coins" swagger:"required"`
+	Objects     []IotaObjectJSON `json:"objects" swagger:"required"`
+	LockedCoins []CoinJSON       `json:"lockedCoins,omitempty" swagger:"desc(The coins of the balance locked by vesting lockups)"`
 }
 
 type AccountNonceResponse struct {
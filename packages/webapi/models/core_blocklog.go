@@ -48,3 +48,8 @@ type RequestProcessedResponse struct {
 type EventsResponse struct {
 	Events []*EventJSON `json:"events" swagger:"required"`
 }
+
+type ContractEventsResponse struct {
+	Events []*EventJSON `json:"events" swagger:"required"`
+	Next   string       `json:"next" swagger:"desc(The value of the 'after' parameter to fetch the next page of events (Hex). Empty if there are no more events.),required"`
+}
@@ -28,3 +28,16 @@ type GovChainInfoResponse struct {
 type GovChainAdminResponse struct {
 	ChainAdmin string `json:"chainAdmin" swagger:"desc(The chain admin (Hex Address))"`
 }
+
+type GovBlockKeepAmountResponse struct {
+	BlockKeepAmount int32 `json:"blockKeepAmount" swagger:"desc(The amount of blocks kept in the state (-1 means all blocks are kept)),required"`
+}
+
+type GovFeeMultiplier struct {
+	AgentID    string `json:"agentId" swagger:"desc(The agent ID of the sender),required"`
+	Multiplier uint32 `json:"multiplier" swagger:"desc(The multiplier applied to the gas fee of the sender in basis points),required"`
+}
+
+type GovFeeMultipliersResponse struct {
+	FeeMultipliers []GovFeeMultiplier `json:"feeMultipliers" swagger:"desc(The senders with a fee multiplier),required"`
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
@@ -50,6 +51,35 @@ func RequestToJSONObject(request isc.Request) RequestJSON {
 
 // ----------------------------------------------------------------------------
 
+type MempoolRequestJSON struct {
+	RequestID     string `json:"requestId" swagger:"required"`
+	SenderAccount string `json:"senderAccount" swagger:"required"`
+	IsOffLedger   bool   `json:"isOffLedger" swagger:"required"`
+	IsEVM         bool   `json:"isEVM" swagger:"required"`
+	Nonce         string `json:"nonce" swagger:"desc(The nonce of the off-ledger requests (uint64 as string)),required"`
+	GasBudget     string `json:"gasBudget" swagger:"desc(The gas budget (uint64 as string)),required"`
+	Age           int64  `json:"age" swagger:"desc(Time since the request was received by the mempool (milliseconds)),required"`
+}
+
+func MempoolRequestToJSONObject(request isc.Request, age time.Duration) MempoolRequestJSON {
+	gasBudget, isEVM := request.GasBudget()
+	var nonce uint64
+	if offLedgerReq, ok := request.(isc.OffLedgerRequest); ok {
+		nonce = offLedgerReq.Nonce()
+	}
+	return MempoolRequestJSON{
+		RequestID:     request.ID().String(),
+		SenderAccount: request.SenderAccount().String(),
+		IsOffLedger:   request.IsOffLedger(),
+		IsEVM:         isEVM,
+		Nonce:         strconv.FormatUint(nonce, 10),
+		GasBudget:     strconv.FormatUint(gasBudget, 10),
+		Age:           age.Milliseconds(),
+	}
+}
+
+// ----------------------------------------------------------------------------
+
 type CallTargetJSON struct {
 	ContractHName string `json:"contractHName" swagger:"desc(The contract name as HName (Hex)),required"`
 	FunctionHName string `json:"functionHName" swagger:"desc(The function name as HName (Hex)),required"`
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
+)
+
+type CreateSnapshotRequest struct {
+	BlockIndex *uint32 `json:"blockIndex,omitempty" swagger:"desc(The index of the block to make the snapshot of; the latest block if omitted),min(0)"`
+}
+
+type CreateSnapshotResponse struct {
+	FileName string `json:"fileName" swagger:"desc(The name of the snapshot file being created),required"`
+}
+
+type SnapshotResponse struct {
+	FileName       string  `json:"fileName" swagger:"desc(The name of the snapshot file),required"`
+	BlockIndex     uint32  `json:"blockIndex" swagger:"desc(The index of the block of the snapshot),required,min(0)"`
+	BlockHash      string  `json:"blockHash" swagger:"desc(The hash of the block of the snapshot),required"`
+	TrieRoot       string  `json:"trieRoot" swagger:"desc(The trie root of the state of the snapshot),required"`
+	BaseBlockIndex *uint32 `json:"baseBlockIndex,omitempty" swagger:"desc(The index of the block of the base snapshot (if it is a delta snapshot)),min(0)"`
+	Size           int64   `json:"size" swagger:"desc(The size of the snapshot file in bytes),required"`
+}
+
+func MapSnapshotResponse(localSnapshot *snapshots.LocalSnapshot) SnapshotResponse {
+	ret := SnapshotResponse{
+		FileName:   localSnapshot.FileName,
+		BlockIndex: localSnapshot.Info.StateIndex(),
+		BlockHash:  localSnapshot.Info.BlockHash().String(),
+		TrieRoot:   localSnapshot.Info.TrieRoot().String(),
+		Size:       localSnapshot.Size,
+	}
+	if localSnapshot.Base != nil {
+		baseBlockIndex := localSnapshot.Base.StateIndex()
+		ret.BaseBlockIndex = &baseBlockIndex
+	}
+	return ret
+}
@@ -23,6 +23,8 @@ type ReceiptResponse struct {
 	BlockIndex    uint32                 `json:"blockIndex" swagger:"required,min(1)"`
 	RequestIndex  uint16                 `json:"requestIndex" swagger:"required,min(1)"`
 	GasBurnLog    []gas.BurnRecord       `json:"gasBurnLog" swagger:"required"`
+	Sponsor       string                 `json:"sponsor,omitempty" swagger:"desc(The account that paid the gas fee instead of the sender (if any))"`
+	FeeMultiplier *uint32                `json:"feeMultiplier,omitempty" swagger:"desc(The discount applied to the gas fee of the sender in basis points (if any))"`
 }
 
 func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
@@ -37,6 +39,11 @@ func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
 		panic(err)
 	}
 
+	var sponsor string
+	if receipt.Sponsor != nil {
+		sponsor = receipt.Sponsor.String()
+	}
+
 	return &ReceiptResponse{
 		Request:       RequestToJSONObject(req),
 		RawError:      ToUnresolvedVMErrorJSON(receipt.Error),
@@ -48,6 +55,8 @@ func MapReceiptResponse(receipt *isc.Receipt) *ReceiptResponse {
 		GasFeeCharged: receipt.GasFeeCharged.String(),
 		SDCharged:     receipt.SDCharged.String(),
 		GasBurnLog:    burnRecords,
+		Sponsor:       sponsor,
+		FeeMultiplier: receipt.FeeMultiplier,
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 type chainServer struct {
 	backend *WaspEVMBackend
 	rpc     *rpc.Server
+	filters *jsonrpc.FilterManager
 }
 
 type EVMService struct {
@@ -88,18 +89,21 @@ func (e *EVMService) getEVMBackend() (*chainServer, error) {
 	nodePubKey := e.networkProvider.Self().PubKey()
 	backend := NewWaspEVMBackend(chain, nodePubKey)
 
+	evmChain := jsonrpc.NewEVMChain(
+		backend,
+		e.publisher,
+		e.chainsProvider().IsArchiveNode(),
+		hivedb.EngineRocksDB,
+		e.indexDBPath,
+		e.log.NewChildLogger("EVMChain"),
+	)
+	filters := jsonrpc.NewFilterManager(evmChain, e.jsonrpcParams)
 	srv, err := jsonrpc.NewServer(
-		jsonrpc.NewEVMChain(
-			backend,
-			e.publisher,
-			e.chainsProvider().IsArchiveNode(),
-			hivedb.EngineRocksDB,
-			e.indexDBPath,
-			e.log.NewChildLogger("EVMChain"),
-		),
+		evmChain,
 		jsonrpc.NewAccountManager(nil),
 		e.metrics.GetChainMetrics(ch.ID()).WebAPI,
 		e.jsonrpcParams,
+		filters,
 	)
 	if err != nil {
 		return nil, err
@@ -108,6 +112,7 @@ func (e *EVMService) getEVMBackend() (*chainServer, error) {
 	e.evmChainServers[ch.ID()] = &chainServer{
 		backend: backend,
 		rpc:     srv,
+		filters: filters,
 	}
 
 	return e.evmChainServers[ch.ID()], nil
@@ -276,6 +276,8 @@ func websocketHandler(server *chainServer, wsContext *websocketContext, realIP s
 			return nil
 		})
 
+		// ServeCodec returns when the connection is closed
 		server.rpc.ServeCodec(codec, 0)
+		server.filters.UninstallWebsocketFilters(conn.RemoteAddr().String())
 	})
 }
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"io"
+
+	chainpkg "github.com/iotaledger/wasp/v2/packages/chain"
+	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
+	"github.com/iotaledger/wasp/v2/packages/webapi/apierrors"
+)
+
+func (c *ChainService) CreateSnapshot(blockIndex *uint32) (string, error) {
+	snapshotAdmin, snapshotInfo, err := c.snapshotOfBlock(blockIndex)
+	if err != nil {
+		return "", err
+	}
+	fileName, err := snapshotAdmin.CreateSnapshot(snapshotInfo)
+	if errors.Is(err, snapshots.ErrStateNotAvailable) {
+		return "", apierrors.NoRecordFoundError(err)
+	}
+	return fileName, err
+}
+
+func (c *ChainService) ListSnapshots() ([]*snapshots.LocalSnapshot, error) {
+	snapshotAdmin, err := c.snapshotAdmin()
+	if err != nil {
+		return nil, err
+	}
+	return snapshotAdmin.ListSnapshots()
+}
+
+func (c *ChainService) DeleteSnapshot(fileName string) error {
+	snapshotAdmin, err := c.snapshotAdmin()
+	if err != nil {
+		return err
+	}
+	return snapshotAdmin.DeleteSnapshot(fileName)
+}
+
+func (c *ChainService) ExportSnapshot(blockIndex *uint32, w io.Writer) error {
+	snapshotAdmin, snapshotInfo, err := c.snapshotOfBlock(blockIndex)
+	if err != nil {
+		return err
+	}
+	err = snapshotAdmin.ExportSnapshot(snapshotInfo, w)
+	if errors.Is(err, snapshots.ErrStateNotAvailable) {
+		return apierrors.NoRecordFoundError(err)
+	}
+	return err
+}
+
+func (c *ChainService) snapshotAdmin() (snapshots.SnapshotAdmin, error) {
+	ch, err := c.GetChain()
+	if err != nil {
+		return nil, err
+	}
+	return c.chainsProvider().SnapshotAdmin(ch.ID())
+}
+
+// snapshotOfBlock returns the snapshot info of the block with the given index,
+// or of the latest block if the index is nil
+func (c *ChainService) snapshotOfBlock(blockIndex *uint32) (snapshots.SnapshotAdmin, snapshots.SnapshotInfo, error) {
+	snapshotAdmin, err := c.snapshotAdmin()
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, err := c.GetChain()
+	if err != nil {
+		return nil, nil, err
+	}
+	var index uint32
+	if blockIndex != nil {
+		index = *blockIndex
+	} else {
+		latestState, err := ch.LatestState(chainpkg.ActiveOrCommittedState)
+		if err != nil {
+			return nil, nil, err
+		}
+		index = latestState.BlockIndex()
+	}
+	block, err := ch.Store().BlockByIndex(index)
+	if err != nil {
+		return nil, nil, apierrors.NoRecordFoundError(err)
+	}
+	return snapshotAdmin, snapshots.NewSnapshotInfo(block.StateIndex(), block.L1Commitment()), nil
+}
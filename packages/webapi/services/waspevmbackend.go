@@ -17,6 +17,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/chain"
 	"github.com/iotaledger/wasp/v2/packages/chainutil"
 	"github.com/iotaledger/wasp/v2/packages/cryptolib"
+	"github.com/iotaledger/wasp/v2/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/v2/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/v2/packages/hashing"
 	"github.com/iotaledger/wasp/v2/packages/isc"
@@ -77,7 +78,7 @@ func (b *WaspEVMBackend) EVMSendTransaction(tx *types.Transaction) error {
 	return nil
 }
 
-func (b *WaspEVMBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) ([]byte, error) {
+func (b *WaspEVMBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) ([]byte, error) {
 	return chainutil.EVMCall(
 		anchor,
 		l1Params,
@@ -85,10 +86,11 @@ func (b *WaspEVMBackend) EVMCall(anchor *isc.StateAnchor, callMsg ethereum.CallM
 		b.chain.Processors(),
 		b.chain.Log(),
 		callMsg,
+		stateOverride,
 	)
 }
 
-func (b *WaspEVMBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (uint64, error) {
+func (b *WaspEVMBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, stateOverride evmutil.StateOverride, l1Params *parameters.L1Params) (uint64, error) {
 	return chainutil.EVMEstimateGas(
 		anchor,
 		l1Params,
@@ -96,6 +98,29 @@ func (b *WaspEVMBackend) EVMEstimateGas(anchor *isc.StateAnchor, callMsg ethereu
 		b.chain.Processors(),
 		b.chain.Log(),
 		callMsg,
+		stateOverride,
+	)
+}
+
+func (b *WaspEVMBackend) EVMCreateAccessList(anchor *isc.StateAnchor, callMsg ethereum.CallMsg, l1Params *parameters.L1Params) (types.AccessList, uint64, *isc.VMError, error) {
+	return chainutil.EVMCreateAccessList(
+		anchor,
+		l1Params,
+		b.chain.Store(),
+		b.chain.Processors(),
+		b.chain.Log(),
+		callMsg,
+	)
+}
+
+func (b *WaspEVMBackend) EVMSimulate(anchor *isc.StateAnchor, blocks []*evmutil.SimulateBlock, l1Params *parameters.L1Params) ([]*evmutil.SimulatedBlock, error) {
+	return chainutil.EVMSimulate(
+		anchor,
+		l1Params,
+		b.chain.Store(),
+		b.chain.Processors(),
+		b.chain.Log(),
+		blocks,
 	)
 }
 
@@ -122,6 +147,17 @@ func (b *WaspEVMBackend) EVMTrace(
 	)
 }
 
+func (b *WaspEVMBackend) EVMPendingTransactions() []*types.Transaction {
+	var txs []*types.Transaction
+	b.chain.IterateMempool(func(req isc.Request, _ time.Time) bool {
+		if tx := isc.EVMTransaction(req); tx != nil {
+			txs = append(txs, tx)
+		}
+		return true
+	})
+	return txs
+}
+
 func (b *WaspEVMBackend) ISCCallView(chainState state.State, msg isc.Message) (isc.CallArguments, error) {
 	latestAnchor, err := b.chain.LatestAnchor(chain.ActiveOrCommittedState)
 	if err != nil {
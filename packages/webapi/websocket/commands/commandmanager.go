@@ -40,7 +40,7 @@ type CommandManager struct {
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 }
 
-func NewCommandHandler(log log.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *CommandManager {
+func NewCommandHandler(log log.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string], eventFilters *EventFilters, eventReplayer EventReplayer) *CommandManager {
 	return &CommandManager{
 		log: log,
 		commands: []CommandHandler{
@@ -48,6 +48,8 @@ func NewCommandHandler(log log.Logger, subscriptionManager *subscriptionmanager.
 			&SubscriptionCommandHandler{
 				log:                 log,
 				subscriptionManager: subscriptionManager,
+				eventFilters:        eventFilters,
+				eventReplayer:       eventReplayer,
 			},
 		},
 		subscriptionManager: subscriptionManager,
@@ -28,7 +28,7 @@ package commands
 // 	subscriptionManager := subscriptionmanager.New[websockethub.ClientID, string]()
 // 	subscriptionManager.Connect(1)
 
-// 	manager := NewCommandHandler(log, subscriptionManager)
+// 	manager := NewCommandHandler(log, subscriptionManager, NewEventFilters(), nil)
 // 	hub := websockethub.NewHub(log.NewChildLogger("Hub"), &websocketserver.AcceptOptions{InsecureSkipVerify: true}, 500, 500, 500)
 
 // 	ok := make(chan struct{})
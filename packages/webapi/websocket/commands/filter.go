@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/publisher"
+)
+
+// EventFilterSpec restricts the events sent to the client for a subscription.
+// All fields are optional, an event is sent only if it matches all of the set
+// ones.
+type EventFilterSpec struct {
+	// Contract is the hname of the contract that emitted the event (Hex), or
+	// the target contract of the request for receipts
+	Contract string `json:"contract,omitempty"`
+	// Topic is the topic of the event, only supported for block events
+	Topic string `json:"topic,omitempty"`
+	// Issuer is the AgentID of the sender of the request
+	Issuer string `json:"issuer,omitempty"`
+}
+
+// EventFilter is a validated EventFilterSpec
+type EventFilter struct {
+	Contract *isc.Hname
+	Topic    *string
+	Issuer   isc.AgentID
+}
+
+// Validate checks that the filter can be applied to the events of the given
+// topic, and parses it.
+func (s *EventFilterSpec) Validate(topic string) (*EventFilter, error) {
+	switch publisher.ISCEventType(topic) {
+	case publisher.ISCEventKindBlockEvents:
+	case publisher.ISCEventKindReceipt:
+		if s.Topic != "" {
+			return nil, errors.Errorf("topic filter is not supported for %s", topic)
+		}
+	default:
+		return nil, errors.Errorf("filters are not supported for %s", topic)
+	}
+
+	ret := &EventFilter{}
+	if s.Contract != "" {
+		hname, err := isc.HnameFromString(s.Contract)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid contract hname")
+		}
+		ret.Contract = &hname
+	}
+	if s.Topic != "" {
+		ret.Topic = &s.Topic
+	}
+	if s.Issuer != "" {
+		issuer, err := isc.AgentIDFromString(s.Issuer)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid issuer")
+		}
+		ret.Issuer = issuer
+	}
+	return ret, nil
+}
+
+// EventFilters keeps the filters of the subscriptions of each client
+type EventFilters struct {
+	mutex   sync.RWMutex
+	filters map[websockethub.ClientID]map[string]*EventFilter
+}
+
+func NewEventFilters() *EventFilters {
+	return &EventFilters{
+		filters: map[websockethub.ClientID]map[string]*EventFilter{},
+	}
+}
+
+// Get returns the filter of the subscription of the client to the topic, or
+// nil if the subscription is not filtered
+func (f *EventFilters) Get(clientID websockethub.ClientID, topic string) *EventFilter {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.filters[clientID][topic]
+}
+
+// Set sets the filter of the subscription of the client to the topic.
+// A nil filter removes it.
+func (f *EventFilters) Set(clientID websockethub.ClientID, topic string, filter *EventFilter) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if filter == nil {
+		delete(f.filters[clientID], topic)
+		if len(f.filters[clientID]) == 0 {
+			delete(f.filters, clientID)
+		}
+		return
+	}
+	if f.filters[clientID] == nil {
+		f.filters[clientID] = map[string]*EventFilter{}
+	}
+	f.filters[clientID][topic] = filter
+}
+
+// RemoveClient removes all the filters of the client
+func (f *EventFilters) RemoveClient(clientID websockethub.ClientID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.filters, clientID)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/log"
 	"github.com/iotaledger/hive.go/web/subscriptionmanager"
 	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/iotaledger/wasp/v2/packages/publisher"
 )
 
 const (
@@ -19,11 +20,18 @@ const (
 type SubscriptionCommand struct {
 	BaseCommand
 	Topic string `json:"topic"`
+	// Filter is only considered when subscribing
+	Filter *EventFilterSpec `json:"filter,omitempty"`
+	// FromBlockIndex, if set when subscribing, replays the events published
+	// since that block before the live ones
+	FromBlockIndex *uint32 `json:"fromBlockIndex,omitempty"`
 }
 
 const (
 	EventClientWasSubscribed   EventType = "subscribed"
 	EventClientWasUnsubscribed EventType = "unsubscribed"
+	EventReplayGap             EventType = "gap"
+	EventReplayCompleted       EventType = "replayed"
 )
 
 type SubscriptionEvent struct {
@@ -31,9 +39,41 @@ type SubscriptionEvent struct {
 	Topic string `json:"topic"`
 }
 
+// ReplayGapEvent is sent when some of the requested events cannot be replayed
+// because the blocks were pruned
+type ReplayGapEvent struct {
+	BaseEvent
+	Topic                    string `json:"topic"`
+	FromBlockIndex           uint32 `json:"fromBlockIndex"`
+	FirstAvailableBlockIndex uint32 `json:"firstAvailableBlockIndex"`
+}
+
+// ReplayCompletedEvent is sent after the replayed events, the following
+// events are live
+type ReplayCompletedEvent struct {
+	BaseEvent
+	Topic          string `json:"topic"`
+	LastBlockIndex uint32 `json:"lastBlockIndex"`
+}
+
+// EventReplayer replays the events published in the past
+type EventReplayer interface {
+	// Pause queues the live events of the topic sent to the client, until
+	// Replay is called
+	Pause(clientID websockethub.ClientID, topic string)
+	// Replay starts sending, in the background, the events of the topic
+	// published since the given block to the client, followed by the queued
+	// live events
+	Replay(client *websockethub.Client, topic string, fromBlockIndex uint32)
+	// Reset forgets the replay state of the topic for the client
+	Reset(clientID websockethub.ClientID, topic string)
+}
+
 type SubscriptionCommandHandler struct {
 	log                 log.Logger
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+	eventFilters        *EventFilters
+	eventReplayer       EventReplayer
 }
 
 func (s *SubscriptionCommandHandler) SupportsCommand(commandType CommandType) bool {
@@ -54,16 +94,38 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 
 	switch command.Command {
 	case CommandSubscribe:
+		if command.FromBlockIndex != nil && !isReplayableTopic(command.Topic) {
+			return errors.Wrapf(ErrFailedToValidateCommand, "events of %s cannot be replayed", command.Topic)
+		}
+		var filter *EventFilter
+		if command.Filter != nil {
+			if filter, err = command.Filter.Validate(command.Topic); err != nil {
+				return errors.Wrap(ErrFailedToValidateCommand, err.Error())
+			}
+		}
+		if command.FromBlockIndex != nil {
+			s.eventReplayer.Pause(client.ID(), command.Topic)
+		} else {
+			s.eventReplayer.Reset(client.ID(), command.Topic)
+		}
 		s.subscriptionManager.Subscribe(client.ID(), command.Topic)
+		s.eventFilters.Set(client.ID(), command.Topic, filter)
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
 				Event: EventClientWasSubscribed,
 			},
 			Topic: command.Topic,
 		})
+		if command.FromBlockIndex != nil {
+			// the replay must be completed even if the client could not be
+			// notified, to resume the live events
+			s.eventReplayer.Replay(client, command.Topic, *command.FromBlockIndex)
+		}
 
 	case CommandUnsubscribe:
 		s.subscriptionManager.Unsubscribe(client.ID(), command.Topic)
+		s.eventFilters.Set(client.ID(), command.Topic, nil)
+		s.eventReplayer.Reset(client.ID(), command.Topic)
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
 				Event: EventClientWasUnsubscribed,
@@ -78,3 +140,12 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 
 	return nil
 }
+
+func isReplayableTopic(topic string) bool {
+	switch publisher.ISCEventType(topic) {
+	case publisher.ISCEventKindNewBlock, publisher.ISCEventKindReceipt, publisher.ISCEventKindBlockEvents:
+		return true
+	default:
+		return false
+	}
+}
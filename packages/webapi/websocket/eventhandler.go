@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/publisher"
 	"github.com/iotaledger/wasp/v2/packages/webapi/models"
+	"github.com/iotaledger/wasp/v2/packages/webapi/websocket/commands"
 )
 
 type ISCEvent struct {
@@ -15,6 +16,15 @@ type ISCEvent struct {
 	RequestID string                 `json:"requestID"` // (isc.RequestID)
 	ChainID   string                 `json:"chainID"`   // (isc.ChainID)
 	Payload   any                    `json:"payload"`
+
+	// blockIndex is used to discard the live events that were already
+	// replayed, see eventReplayer
+	blockIndex uint32
+	// used to apply the subscription filters, see filterISCEvent
+	issuer       isc.AgentID
+	contract     *isc.Hname
+	events       []*isc.Event
+	eventSenders []isc.AgentID
 }
 
 func MapISCEvent[T any](iscEvent *publisher.ISCEvent[T], mappedPayload any) *ISCEvent {
@@ -31,6 +41,55 @@ func MapISCEvent[T any](iscEvent *publisher.ISCEvent[T], mappedPayload any) *ISC
 		RequestID: iscEvent.RequestID.String(),
 		Issuer:    issuer,
 		Payload:   mappedPayload,
+		issuer:    iscEvent.Issuer,
+	}
+}
+
+// filterISCEvent applies the filter of a client subscription to an event.
+// It returns nil if the event must not be sent to the client.
+func filterISCEvent(iscEvent *ISCEvent, filter *commands.EventFilter) *ISCEvent {
+	if filter == nil {
+		return iscEvent
+	}
+
+	switch iscEvent.Kind {
+	case publisher.ISCEventKindReceipt:
+		if filter.Contract != nil && (iscEvent.contract == nil || *iscEvent.contract != *filter.Contract) {
+			return nil
+		}
+		if filter.Issuer != nil && (iscEvent.issuer == nil || !iscEvent.issuer.Equals(filter.Issuer)) {
+			return nil
+		}
+		return iscEvent
+
+	case publisher.ISCEventKindBlockEvents:
+		var events []*isc.Event
+		for i, ev := range iscEvent.events {
+			if filter.Contract != nil && ev.ContractID != *filter.Contract {
+				continue
+			}
+			if filter.Topic != nil && ev.Topic != *filter.Topic {
+				continue
+			}
+			if filter.Issuer != nil {
+				sender := iscEvent.eventSenders[i]
+				if sender == nil || !sender.Equals(filter.Issuer) {
+					continue
+				}
+			}
+			events = append(events, ev)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		ret := *iscEvent
+		ret.Payload = events
+		ret.events = events
+		ret.eventSenders = nil
+		return &ret
+
+	default:
+		return iscEvent
 	}
 }
 
@@ -65,9 +124,7 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			blockInfo := models.MapBlockInfoResponse(block.Payload.BlockInfo)
-			iscEvent := MapISCEvent(block, blockInfo)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapNewBlockEvent(block))
 		}).Unhook,
 
 		p.publisher.Events.RequestReceipt.Hook(func(block *publisher.ISCEvent[*publisher.ReceiptWithError]) {
@@ -75,18 +132,39 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			receipt := models.MapReceiptResponse(block.Payload.RequestReceipt)
-			iscEvent := MapISCEvent(block, receipt)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapReceiptEvent(block))
 		}).Unhook,
 
-		p.publisher.Events.BlockEvents.Hook(func(block *publisher.ISCEvent[[]*isc.Event]) {
+		p.publisher.Events.BlockEvents.Hook(func(block *publisher.ISCEvent[*publisher.BlockEvents]) {
 			if !p.subscriptionValidator.shouldProcessEvent(block.ChainID.String(), block.Kind) {
 				return
 			}
 
-			iscEvent := MapISCEvent(block, block.Payload)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapBlockEventsEvent(block))
 		}).Unhook,
 	)
 }
+
+func mapNewBlockEvent(block *publisher.ISCEvent[*publisher.BlockWithTrieRoot]) *ISCEvent {
+	iscEvent := MapISCEvent(block, models.MapBlockInfoResponse(block.Payload.BlockInfo))
+	iscEvent.blockIndex = block.Payload.BlockInfo.BlockIndex
+	return iscEvent
+}
+
+func mapReceiptEvent(receipt *publisher.ISCEvent[*publisher.ReceiptWithError]) *ISCEvent {
+	iscEvent := MapISCEvent(receipt, models.MapReceiptResponse(receipt.Payload.RequestReceipt))
+	iscEvent.blockIndex = receipt.Payload.RequestReceipt.BlockIndex
+	if req, err := isc.RequestFromBytes(receipt.Payload.RequestReceipt.Request); err == nil {
+		contract := req.Message().Target.Contract
+		iscEvent.contract = &contract
+	}
+	return iscEvent
+}
+
+func mapBlockEventsEvent(block *publisher.ISCEvent[*publisher.BlockEvents]) *ISCEvent {
+	iscEvent := MapISCEvent(block, block.Payload.Events)
+	iscEvent.blockIndex = block.Payload.BlockIndex
+	iscEvent.events = block.Payload.Events
+	iscEvent.eventSenders = block.Payload.Senders
+	return iscEvent
+}
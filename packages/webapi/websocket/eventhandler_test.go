@@ -14,10 +14,12 @@ import (
 	"github.com/iotaledger/hive.go/web/subscriptionmanager"
 	"github.com/iotaledger/hive.go/web/websockethub"
 
+	"github.com/iotaledger/wasp/v2/packages/isc"
 	"github.com/iotaledger/wasp/v2/packages/isc/isctest"
 	"github.com/iotaledger/wasp/v2/packages/publisher"
 	"github.com/iotaledger/wasp/v2/packages/trie"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/v2/packages/webapi/websocket/commands"
 )
 
 func initTest(ctx context.Context) (*publisher.Publisher, *EventHandler, *event.Event1[*ISCEvent], *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) {
@@ -72,3 +74,63 @@ func TestSuccessfulEventHandling(t *testing.T) {
 	require.ErrorIs(t, ctx.Err(), context.Canceled, "The context was not correctly canceled by the event receiver and timed out. "+
 		"This means no event was sent and this needs to fail the test")
 }
+
+func TestFilterISCEvent(t *testing.T) {
+	sender1 := isctest.NewRandomAgentID()
+	sender2 := isctest.NewRandomAgentID()
+	events := []*isc.Event{
+		{ContractID: isc.Hn("a"), Topic: "foo"},
+		{ContractID: isc.Hn("a"), Topic: "bar"},
+		{ContractID: isc.Hn("b"), Topic: "foo"},
+	}
+	blockEvents := &ISCEvent{
+		Kind:         publisher.ISCEventKindBlockEvents,
+		Payload:      events,
+		events:       events,
+		eventSenders: []isc.AgentID{sender1, sender2, sender1},
+	}
+
+	filter := func(spec commands.EventFilterSpec) *commands.EventFilter {
+		f, err := spec.Validate(string(publisher.ISCEventKindBlockEvents))
+		require.NoError(t, err)
+		return f
+	}
+
+	require.Equal(t, blockEvents, filterISCEvent(blockEvents, nil))
+
+	ret := filterISCEvent(blockEvents, filter(commands.EventFilterSpec{Contract: isc.Hn("a").String()}))
+	require.Equal(t, events[:2], ret.Payload)
+
+	ret = filterISCEvent(blockEvents, filter(commands.EventFilterSpec{Topic: "foo"}))
+	require.Equal(t, []*isc.Event{events[0], events[2]}, ret.Payload)
+
+	ret = filterISCEvent(blockEvents, filter(commands.EventFilterSpec{Topic: "foo", Issuer: sender1.String()}))
+	require.Equal(t, []*isc.Event{events[0], events[2]}, ret.Payload)
+
+	ret = filterISCEvent(blockEvents, filter(commands.EventFilterSpec{Contract: isc.Hn("b").String(), Issuer: sender2.String()}))
+	require.Nil(t, ret)
+
+	// the original event is not modified
+	require.Equal(t, events, blockEvents.Payload)
+
+	contract := isc.Hn("a")
+	receipt := &ISCEvent{
+		Kind:     publisher.ISCEventKindReceipt,
+		issuer:   sender1,
+		contract: &contract,
+	}
+	f, err := (&commands.EventFilterSpec{Issuer: sender1.String()}).Validate(string(publisher.ISCEventKindReceipt))
+	require.NoError(t, err)
+	require.Equal(t, receipt, filterISCEvent(receipt, f))
+	f, err = (&commands.EventFilterSpec{Contract: isc.Hn("b").String()}).Validate(string(publisher.ISCEventKindReceipt))
+	require.NoError(t, err)
+	require.Nil(t, filterISCEvent(receipt, f))
+
+	// invalid filters
+	_, err = (&commands.EventFilterSpec{Topic: "foo"}).Validate(string(publisher.ISCEventKindReceipt))
+	require.Error(t, err)
+	_, err = (&commands.EventFilterSpec{Topic: "foo"}).Validate(string(publisher.ISCEventKindNewBlock))
+	require.Error(t, err)
+	_, err = (&commands.EventFilterSpec{Contract: "xyz"}).Validate(string(publisher.ISCEventKindBlockEvents))
+	require.Error(t, err)
+}
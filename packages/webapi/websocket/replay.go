@@ -0,0 +1,333 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/web/websockethub"
+
+	"github.com/iotaledger/wasp/v2/packages/chain"
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/publisher"
+	"github.com/iotaledger/wasp/v2/packages/state"
+	"github.com/iotaledger/wasp/v2/packages/trie"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/blocklog"
+	vmerrors "github.com/iotaledger/wasp/v2/packages/vm/core/errors"
+	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/v2/packages/webapi/websocket/commands"
+)
+
+const (
+	// maxReplayedBlocks is the maximum amount of blocks replayed for a single
+	// subscription, if the chain keeps all blocks. Otherwise the replay is
+	// limited to the block keep amount of the chain. Older blocks are
+	// reported as a gap, as if they were pruned.
+	maxReplayedBlocks = governance.DefaultBlockKeepAmount
+	// maxPendingEvents is the maximum amount of live events queued during a
+	// replay. When it is exceeded, the queue is dropped, and the blocks of
+	// the dropped events are replayed once the current replay is done.
+	maxPendingEvents = 1000
+)
+
+var errReplayNotSupported = errors.New("event replay is not supported")
+
+// eventReplayer implements commands.EventReplayer, reading the past events
+// from the blocklog state.
+//
+// The past events are replayed in the background. Meanwhile, the live events of
+// the same topic are queued, and sent after the replay. The live events of the
+// replayed blocks are discarded, so that each event is sent only once.
+type eventReplayer struct {
+	log                   log.Logger
+	chainProvider         func() (chain.Chain, error)
+	subscriptionValidator *SubscriptionValidator
+	eventFilters          *commands.EventFilters
+
+	mutex   sync.Mutex
+	cursors map[websockethub.ClientID]map[publisher.ISCEventType]*replayCursor
+}
+
+type replayCursor struct {
+	// replaying is true until the past events are sent; the live events
+	// received meanwhile are queued in pending
+	replaying bool
+	pending   []*ISCEvent
+	// overflowed is true if pending was dropped because it was full
+	overflowed bool
+	// lastReplayedBlockIndex is the index of the last replayed block
+	lastReplayedBlockIndex uint32
+}
+
+var _ commands.EventReplayer = &eventReplayer{}
+
+func newEventReplayer(
+	log log.Logger,
+	chainProvider func() (chain.Chain, error),
+	subscriptionValidator *SubscriptionValidator,
+	eventFilters *commands.EventFilters,
+) *eventReplayer {
+	return &eventReplayer{
+		log:                   log,
+		chainProvider:         chainProvider,
+		subscriptionValidator: subscriptionValidator,
+		eventFilters:          eventFilters,
+		cursors:               map[websockethub.ClientID]map[publisher.ISCEventType]*replayCursor{},
+	}
+}
+
+func (r *eventReplayer) Pause(clientID websockethub.ClientID, topic string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.cursors[clientID] == nil {
+		r.cursors[clientID] = map[publisher.ISCEventType]*replayCursor{}
+	}
+	r.cursors[clientID][publisher.ISCEventType(topic)] = &replayCursor{replaying: true}
+}
+
+func (r *eventReplayer) Reset(clientID websockethub.ClientID, topic string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.cursors[clientID], publisher.ISCEventType(topic))
+	if len(r.cursors[clientID]) == 0 {
+		delete(r.cursors, clientID)
+	}
+}
+
+func (r *eventReplayer) removeClient(clientID websockethub.ClientID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.cursors, clientID)
+}
+
+func (r *eventReplayer) Replay(client *websockethub.Client, topic string, fromBlockIndex uint32) {
+	kind := publisher.ISCEventType(topic)
+	cursor := r.cursor(client.ID(), kind)
+	if cursor == nil {
+		// unsubscribed meanwhile
+		return
+	}
+	go r.run(client, kind, cursor, fromBlockIndex)
+}
+
+func (r *eventReplayer) cursor(clientID websockethub.ClientID, kind publisher.ISCEventType) *replayCursor {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.cursors[clientID][kind]
+}
+
+// run replays the past events, until the live events queued meanwhile can be
+// sent to the client
+func (r *eventReplayer) run(client *websockethub.Client, kind publisher.ISCEventType, cursor *replayCursor, fromBlockIndex uint32) {
+	for {
+		lastBlockIndex, err := r.replay(client, kind, fromBlockIndex)
+		pending, again := r.resume(client.ID(), kind, cursor, lastBlockIndex, err != nil)
+		if again {
+			// the queue overflowed, replay the blocks of the dropped events
+			fromBlockIndex = lastBlockIndex + 1
+			continue
+		}
+		// the queued live events are sent even if the replay failed, so that
+		// no event is lost after the gap
+		for _, iscEvent := range pending {
+			if sendErr := client.Send(client.Context(), iscEvent); sendErr != nil && err == nil {
+				err = sendErr
+			}
+		}
+		if err == nil {
+			err = client.Send(client.Context(), commands.ReplayCompletedEvent{
+				BaseEvent:      commands.BaseEvent{Event: commands.EventReplayCompleted},
+				Topic:          string(kind),
+				LastBlockIndex: lastBlockIndex,
+			})
+		}
+		if err != nil {
+			r.log.LogWarnf("failed to replay %s events to client %d: %v", kind, client.ID(), err)
+		}
+		return
+	}
+}
+
+// replay sends the past events, and returns the index of the last replayed
+// block
+func (r *eventReplayer) replay(client *websockethub.Client, kind publisher.ISCEventType, fromBlockIndex uint32) (uint32, error) {
+	if r.chainProvider == nil {
+		return 0, errReplayNotSupported
+	}
+	ch, err := r.chainProvider()
+	if err != nil {
+		return 0, err
+	}
+	chainState, err := ch.LatestState(chain.ActiveOrCommittedState)
+	if err != nil {
+		return 0, err
+	}
+	lastBlockIndex := chainState.BlockIndex()
+	if fromBlockIndex > lastBlockIndex {
+		return lastBlockIndex, nil
+	}
+
+	limit := replayLimit(governance.NewStateReaderFromChainState(chainState))
+	firstBlockIndex := firstAvailableBlockIndex(blocklog.NewStateReaderFromChainState(chainState), fromBlockIndex, lastBlockIndex, limit)
+	if firstBlockIndex != fromBlockIndex {
+		err = client.Send(client.Context(), commands.ReplayGapEvent{
+			BaseEvent:                commands.BaseEvent{Event: commands.EventReplayGap},
+			Topic:                    string(kind),
+			FromBlockIndex:           fromBlockIndex,
+			FirstAvailableBlockIndex: firstBlockIndex,
+		})
+		if err != nil {
+			return lastBlockIndex, err
+		}
+	}
+
+	chainID := ch.ID()
+	if !r.subscriptionValidator.isClientAllowed(client, chainID.String(), kind) {
+		return lastBlockIndex, nil
+	}
+	filter := r.eventFilters.Get(client.ID(), string(kind))
+
+	for blockIndex := firstBlockIndex; blockIndex <= lastBlockIndex; blockIndex++ {
+		if err := client.Context().Err(); err != nil {
+			return lastBlockIndex, err
+		}
+		blockEvents, err := r.blockEvents(chainID, chainState, blockIndex)
+		if err != nil {
+			return lastBlockIndex, fmt.Errorf("cannot replay block %d: %w", blockIndex, err)
+		}
+		var iscEvents []*ISCEvent
+		switch kind {
+		case publisher.ISCEventKindNewBlock:
+			iscEvents = append(iscEvents, mapNewBlockEvent(blockEvents.NewBlock))
+		case publisher.ISCEventKindReceipt:
+			for _, receipt := range blockEvents.Receipts {
+				iscEvents = append(iscEvents, mapReceiptEvent(receipt))
+			}
+		case publisher.ISCEventKindBlockEvents:
+			iscEvents = append(iscEvents, mapBlockEventsEvent(blockEvents.BlockEvents))
+		}
+		for _, iscEvent := range iscEvents {
+			if iscEvent = filterISCEvent(iscEvent, filter); iscEvent == nil {
+				continue
+			}
+			if err := client.Send(client.Context(), iscEvent); err != nil {
+				return lastBlockIndex, err
+			}
+		}
+	}
+	return lastBlockIndex, nil
+}
+
+// blockEvents builds the events published for a block, as they were published
+// when the block was produced
+func (r *eventReplayer) blockEvents(chainID isc.ChainID, chainState state.State, blockIndex uint32) (*publisher.BlockISCEvents, error) {
+	blocklogState := blocklog.NewStateReaderFromChainState(chainState)
+	trieRoot, err := blockTrieRoot(chainState, blocklogState, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	return publisher.ISCEventsForBlock(chainID, blockIndex, trieRoot, blocklogState, vmerrors.NewStateReaderFromChainState(chainState), r.log)
+}
+
+// blockTrieRoot returns the trie root of the state produced by a block. For
+// the latest block it is the root of the latest state, otherwise it is the one
+// committed to by the anchor of the next block.
+func blockTrieRoot(chainState state.State, blocklogState *blocklog.StateReader, blockIndex uint32) (trie.Hash, error) {
+	if blockIndex == chainState.BlockIndex() {
+		return chainState.TrieRoot(), nil
+	}
+	nextBlockInfo, ok := blocklogState.GetBlockInfo(blockIndex + 1)
+	if !ok || nextBlockInfo.PreviousL1Commitment() == nil {
+		return trie.Hash{}, fmt.Errorf("unable to get the trie root of block %d", blockIndex)
+	}
+	return nextBlockInfo.PreviousL1Commitment().TrieRoot(), nil
+}
+
+// replayLimit returns the maximum amount of blocks that can be replayed
+func replayLimit(governanceState *governance.StateReader) uint32 {
+	blockKeepAmount := governanceState.GetBlockKeepAmount()
+	if blockKeepAmount <= 0 || blockKeepAmount > maxReplayedBlocks {
+		return maxReplayedBlocks
+	}
+	return uint32(blockKeepAmount)
+}
+
+// firstAvailableBlockIndex returns the index of the first block not pruned
+// from the blocklog, starting from fromBlockIndex. The pruned blocks are
+// always the oldest ones, so a binary search can be used. At most limit blocks
+// are considered available.
+func firstAvailableBlockIndex(blocklogState *blocklog.StateReader, fromBlockIndex, lastBlockIndex, limit uint32) uint32 {
+	if lastBlockIndex-fromBlockIndex >= limit {
+		fromBlockIndex = lastBlockIndex - limit + 1
+	}
+	n := sort.Search(int(lastBlockIndex-fromBlockIndex+1), func(i int) bool {
+		_, ok := blocklogState.GetBlockInfo(fromBlockIndex + uint32(i))
+		return ok
+	})
+	return fromBlockIndex + uint32(n)
+}
+
+// resume switches to live delivery, and returns the live events queued during
+// the replay that must be sent to the client. If the queue overflowed and the
+// replay did not fail, it returns true instead, and the replay must continue
+// after lastBlockIndex.
+func (r *eventReplayer) resume(clientID websockethub.ClientID, kind publisher.ISCEventType, cursor *replayCursor, lastBlockIndex uint32, failed bool) ([]*ISCEvent, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.cursors[clientID][kind] != cursor {
+		// unsubscribed or subscribed again meanwhile
+		return nil, false
+	}
+	if cursor.overflowed && !failed {
+		cursor.overflowed = false
+		return nil, true
+	}
+	cursor.overflowed = false
+	cursor.replaying = false
+	cursor.lastReplayedBlockIndex = lastBlockIndex
+	pending := lo.Filter(cursor.pending, func(iscEvent *ISCEvent, _ int) bool {
+		return iscEvent.blockIndex > lastBlockIndex
+	})
+	cursor.pending = nil
+	return pending, false
+}
+
+// enqueue returns true if the live event must be sent to the client right
+// away. Otherwise the event is either queued until the end of the replay, or
+// discarded because it was already replayed.
+func (r *eventReplayer) enqueue(clientID websockethub.ClientID, iscEvent *ISCEvent) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cursor := r.cursors[clientID][iscEvent.Kind]
+	if cursor == nil {
+		return true
+	}
+	if cursor.replaying {
+		if len(cursor.pending) == maxPendingEvents {
+			// the blocks of the dropped events are replayed later
+			cursor.pending = nil
+			cursor.overflowed = true
+		}
+		cursor.pending = append(cursor.pending, iscEvent)
+		return false
+	}
+	return iscEvent.blockIndex > cursor.lastReplayedBlockIndex
+}
+
+// deliver sends a live event to the client, see enqueue
+func (r *eventReplayer) deliver(client *websockethub.Client, iscEvent *ISCEvent) error {
+	if !r.enqueue(client.ID(), iscEvent) {
+		return nil
+	}
+	return client.Send(client.Context(), iscEvent)
+}
@@ -0,0 +1,130 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	appLogger "github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/web/websockethub"
+
+	"github.com/iotaledger/wasp/v2/packages/isc"
+	"github.com/iotaledger/wasp/v2/packages/publisher"
+	"github.com/iotaledger/wasp/v2/packages/solo"
+	"github.com/iotaledger/wasp/v2/packages/testutil/l1starter"
+	"github.com/iotaledger/wasp/v2/packages/webapi/websocket/commands"
+)
+
+func TestEventReplayerQueue(t *testing.T) {
+	log := appLogger.NewLogger(appLogger.WithName("Test"))
+	r := newEventReplayer(log, nil, nil, commands.NewEventFilters())
+
+	const clientID = websockethub.ClientID(1)
+	topic := string(publisher.ISCEventKindNewBlock)
+	newBlock := func(blockIndex uint32) *ISCEvent {
+		return &ISCEvent{Kind: publisher.ISCEventKindNewBlock, blockIndex: blockIndex}
+	}
+
+	// no replay: the live events are sent right away
+	require.True(t, r.enqueue(clientID, newBlock(1)))
+
+	// while replaying, the live events are queued
+	r.Pause(clientID, topic)
+	require.False(t, r.enqueue(clientID, newBlock(5)))
+	require.False(t, r.enqueue(clientID, newBlock(6)))
+	// other topics are not affected
+	require.True(t, r.enqueue(clientID, &ISCEvent{Kind: publisher.ISCEventKindReceipt, blockIndex: 5}))
+
+	// block 5 was replayed, only block 6 is left
+	cursor := r.cursor(clientID, publisher.ISCEventKindNewBlock)
+	pending, again := r.resume(clientID, publisher.ISCEventKindNewBlock, cursor, 5, false)
+	require.False(t, again)
+	require.Equal(t, []*ISCEvent{newBlock(6)}, pending)
+
+	// late live events of the replayed blocks are discarded
+	require.False(t, r.enqueue(clientID, newBlock(5)))
+	require.True(t, r.enqueue(clientID, newBlock(7)))
+
+	// after unsubscribing, nothing is queued nor discarded
+	r.Reset(clientID, topic)
+	require.True(t, r.enqueue(clientID, newBlock(5)))
+	pending, again = r.resume(clientID, publisher.ISCEventKindNewBlock, cursor, 5, false)
+	require.False(t, again)
+	require.Nil(t, pending)
+
+	r.Pause(clientID, topic)
+	r.removeClient(clientID)
+	require.True(t, r.enqueue(clientID, newBlock(5)))
+}
+
+func TestEventReplayerQueueOverflow(t *testing.T) {
+	log := appLogger.NewLogger(appLogger.WithName("Test"))
+	r := newEventReplayer(log, nil, nil, commands.NewEventFilters())
+
+	const clientID = websockethub.ClientID(1)
+	topic := string(publisher.ISCEventKindNewBlock)
+	newBlock := func(blockIndex uint32) *ISCEvent {
+		return &ISCEvent{Kind: publisher.ISCEventKindNewBlock, blockIndex: blockIndex}
+	}
+
+	r.Pause(clientID, topic)
+	cursor := r.cursor(clientID, publisher.ISCEventKindNewBlock)
+	for i := range uint32(maxPendingEvents + 1) {
+		require.False(t, r.enqueue(clientID, newBlock(10+i)))
+	}
+
+	// the queue was dropped, the replay must continue after block 5
+	pending, again := r.resume(clientID, publisher.ISCEventKindNewBlock, cursor, 5, false)
+	require.True(t, again)
+	require.Nil(t, pending)
+
+	// the dropped blocks were replayed, only the event queued after the drop
+	// is left
+	require.False(t, r.enqueue(clientID, newBlock(maxPendingEvents+11)))
+	pending, again = r.resume(clientID, publisher.ISCEventKindNewBlock, cursor, maxPendingEvents+10, false)
+	require.False(t, again)
+	require.Equal(t, []*ISCEvent{newBlock(maxPendingEvents + 11)}, pending)
+
+	// a stale replay does not resume a new subscription
+	r.Pause(clientID, topic)
+	pending, again = r.resume(clientID, publisher.ISCEventKindNewBlock, cursor, 5, false)
+	require.False(t, again)
+	require.Nil(t, pending)
+	require.False(t, r.enqueue(clientID, newBlock(6)))
+}
+
+func TestEventReplayerBlockEvents(t *testing.T) {
+	if l1starter.IsLocalConfigured() {
+		t.Skip("Skipping WebSocket test, as the local node does not support WebSockets")
+	}
+
+	log := appLogger.NewLogger(appLogger.WithName("Test"))
+	env := solo.New(t, &solo.InitOptions{Log: log})
+	ch := env.NewChain()
+	for range 3 {
+		require.NoError(t, ch.DepositBaseTokensToL2(1*isc.Million, nil))
+	}
+
+	r := newEventReplayer(log, nil, nil, commands.NewEventFilters())
+	chainState, err := ch.LatestState()
+	require.NoError(t, err)
+	require.Greater(t, chainState.BlockIndex(), uint32(2))
+
+	for blockIndex := uint32(1); blockIndex <= chainState.BlockIndex(); blockIndex++ {
+		blockEvents, err := r.blockEvents(ch.ID(), chainState, blockIndex)
+		require.NoError(t, err)
+
+		// the replayed payload is the one published when the block was produced
+		block, err := ch.Store().BlockByIndex(blockIndex)
+		require.NoError(t, err)
+		require.Equal(t, block.TrieRoot(), blockEvents.NewBlock.Payload.TrieRoot)
+		blockInfo, err := ch.GetBlockInfo(blockIndex)
+		require.NoError(t, err)
+		require.Equal(t, blockInfo.Bytes(), blockEvents.NewBlock.Payload.BlockInfo.Bytes())
+		require.Len(t, blockEvents.Receipts, len(ch.GetRequestReceiptsForBlock(blockIndex)))
+
+		iscEvent := mapNewBlockEvent(blockEvents.NewBlock)
+		require.Equal(t, blockIndex, iscEvent.blockIndex)
+		require.Equal(t, ch.ChainID.String(), iscEvent.ChainID)
+	}
+}
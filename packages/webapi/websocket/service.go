@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/hive.go/web/subscriptionmanager"
 	"github.com/iotaledger/hive.go/web/websockethub"
 
+	"github.com/iotaledger/wasp/v2/packages/chain"
 	"github.com/iotaledger/wasp/v2/packages/publisher"
 	"github.com/iotaledger/wasp/v2/packages/webapi/websocket/commands"
 )
@@ -26,8 +27,11 @@ type Service struct {
 	publisherEvent        *event.Event1[*ISCEvent]
 	subscriptionManager   *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 	subscriptionValidator *SubscriptionValidator
+	eventFilters          *commands.EventFilters
+	eventReplayer         *eventReplayer
 
 	maxTopicSubscriptionsPerClient int
+	chainProvider                  func() (chain.Chain, error)
 }
 
 func WithMaxTopicSubscriptionsPerClient(maxTopicSubscriptionsPerClient int) options.Option[Service] {
@@ -36,6 +40,14 @@ func WithMaxTopicSubscriptionsPerClient(maxTopicSubscriptionsPerClient int) opti
 	}
 }
 
+// WithChainProvider sets the chain used to replay the past events to the
+// clients subscribing with a block index cursor.
+func WithChainProvider(chainProvider func() (chain.Chain, error)) options.Option[Service] {
+	return func(d *Service) {
+		d.chainProvider = chainProvider
+	}
+}
+
 func NewWebsocketService(log log.Logger, hub *websockethub.Hub, msgTypes []publisher.ISCEventType, pub *publisher.Publisher, opts ...options.Option[Service]) *Service {
 	serviceOptions := options.Apply(&Service{
 		maxTopicSubscriptionsPerClient: 0,
@@ -54,7 +66,9 @@ func NewWebsocketService(log log.Logger, hub *websockethub.Hub, msgTypes []publi
 
 	subscriptionValidator := NewSubscriptionValidator(msgTypesMap, subscriptionManager)
 	eventHandler := NewEventHandler(pub, publishEvent, subscriptionValidator)
-	commandHandler := commands.NewCommandHandler(log, subscriptionManager)
+	eventFilters := commands.NewEventFilters()
+	eventReplayer := newEventReplayer(log, serviceOptions.chainProvider, subscriptionValidator, eventFilters)
+	commandHandler := commands.NewCommandHandler(log, subscriptionManager, eventFilters, eventReplayer)
 
 	return &Service{
 		log:                   log.NewChildLogger("Websocket Service"),
@@ -64,6 +78,8 @@ func NewWebsocketService(log log.Logger, hub *websockethub.Hub, msgTypes []publi
 		publisherEvent:        publishEvent,
 		subscriptionManager:   subscriptionManager,
 		subscriptionValidator: subscriptionValidator,
+		eventFilters:          eventFilters,
+		eventReplayer:         eventReplayer,
 	}
 }
 
@@ -76,7 +92,12 @@ func (p *Service) onClientCreated(client *websockethub.Client) {
 				return
 			}
 
-			if err := client.Send(client.Context(), iscEvent); err != nil {
+			iscEvent = filterISCEvent(iscEvent, p.eventFilters.Get(client.ID(), string(iscEvent.Kind)))
+			if iscEvent == nil {
+				return
+			}
+
+			if err := p.eventReplayer.deliver(client, iscEvent); err != nil {
 				p.log.LogWarnf("error sending message to client:[%d], err:[%v]", client.ID(), err)
 			}
 		}).Unhook
@@ -116,6 +137,8 @@ func (p *Service) onConnect(client *websockethub.Client, request *http.Request)
 
 func (p *Service) onDisconnect(client *websockethub.Client, request *http.Request) {
 	p.subscriptionManager.Disconnect(client.ID())
+	p.eventFilters.RemoveClient(client.ID())
+	p.eventReplayer.removeClient(client.ID())
 	p.log.LogInfof("closed websocket connection for client:[%d], from:[%s]", client.ID(), request.RemoteAddr)
 }
 
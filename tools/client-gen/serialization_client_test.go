@@ -114,12 +114,21 @@ func TestDumpJson(t *testing.T) {
 		constructCoreContractFunction(&accounts.FuncDeposit),
 		constructCoreContractFunction(&accounts.FuncTransferAllowanceTo),
 		constructCoreContractFunction(&accounts.FuncWithdraw),
+		constructCoreContractFunction(&accounts.FuncWithdrawTo),
+		constructCoreContractFunction(&accounts.FuncWithdrawBatch),
+		constructCoreContractFunction(&accounts.FuncCreateLockup),
+		constructCoreContractFunction(&accounts.FuncSetSponsorship),
+		constructCoreContractFunction(&accounts.FuncApproveLockupFunder),
 		constructCoreContractFunction(&accounts.ViewAccountObjects),
 		constructCoreContractFunction(&accounts.ViewBalance),
 		constructCoreContractFunction(&accounts.ViewBalanceBaseToken),
 		constructCoreContractFunction(&accounts.ViewBalanceBaseTokenEVM),
 		constructCoreContractFunction(&accounts.ViewBalanceCoin),
 		constructCoreContractFunction(&accounts.ViewGetAccountNonce),
+		constructCoreContractFunction(&accounts.ViewLockedBalance),
+		constructCoreContractFunction(&accounts.ViewGetLockups),
+		constructCoreContractFunction(&accounts.ViewIsLockupFunderApproved),
+		constructCoreContractFunction(&accounts.ViewGetSponsorship),
 		constructCoreContractFunction(&accounts.ViewTotalAssets),
 		constructCoreContractFunction(&blocklog.ViewGetBlockInfo),
 		constructCoreContractFunction(&blocklog.ViewGetRequestIDsForBlock),
@@ -144,6 +153,11 @@ func TestDumpJson(t *testing.T) {
 		constructCoreContractFunction(&governance.FuncSetGasLimits),
 		constructCoreContractFunction(&governance.ViewGetFeePolicy),
 		constructCoreContractFunction(&governance.ViewGetGasLimits),
+		constructCoreContractFunction(&governance.FuncSetDynamicGasPricePolicy),
+		constructCoreContractFunction(&governance.ViewGetDynamicGasPrice),
+		constructCoreContractFunction(&governance.FuncSetFeeMultiplier),
+		constructCoreContractFunction(&governance.ViewGetFeeMultiplier),
+		constructCoreContractFunction(&governance.ViewGetFeeMultipliers),
 		constructCoreContractFunction(&governance.FuncSetEVMGasRatio),
 		constructCoreContractFunction(&governance.ViewGetEVMGasRatio),
 		constructCoreContractFunction(&governance.ViewGetChainInfo),
@@ -156,6 +170,9 @@ func TestDumpJson(t *testing.T) {
 		constructCoreContractFunction(&governance.ViewGetMaintenanceStatus),
 		constructCoreContractFunction(&governance.FuncSetMetadata),
 		constructCoreContractFunction(&governance.ViewGetMetadata),
+		constructCoreContractFunction(&governance.FuncScheduleJob),
+		constructCoreContractFunction(&governance.FuncCancelJob),
+		constructCoreContractFunction(&governance.ViewGetScheduledJobs),
 		constructCoreContractFunction(&root.ViewFindContract),
 		constructCoreContractFunction(&root.ViewGetContractRecords),
 	}
@@ -180,12 +197,21 @@ func TestA(t *testing.T) {
 		constructCoreContractFunction(&accounts.FuncDeposit),
 		constructCoreContractFunction(&accounts.FuncTransferAllowanceTo),
 		constructCoreContractFunction(&accounts.FuncWithdraw),
+		constructCoreContractFunction(&accounts.FuncWithdrawTo),
+		constructCoreContractFunction(&accounts.FuncWithdrawBatch),
+		constructCoreContractFunction(&accounts.FuncCreateLockup),
+		constructCoreContractFunction(&accounts.FuncSetSponsorship),
+		constructCoreContractFunction(&accounts.FuncApproveLockupFunder),
 		constructCoreContractFunction(&accounts.ViewAccountObjects),
 		constructCoreContractFunction(&accounts.ViewBalance),
 		constructCoreContractFunction(&accounts.ViewBalanceBaseToken),
 		constructCoreContractFunction(&accounts.ViewBalanceBaseTokenEVM),
 		constructCoreContractFunction(&accounts.ViewBalanceCoin),
 		constructCoreContractFunction(&accounts.ViewGetAccountNonce),
+		constructCoreContractFunction(&accounts.ViewLockedBalance),
+		constructCoreContractFunction(&accounts.ViewGetLockups),
+		constructCoreContractFunction(&accounts.ViewIsLockupFunderApproved),
+		constructCoreContractFunction(&accounts.ViewGetSponsorship),
 		constructCoreContractFunction(&accounts.ViewTotalAssets),
 		constructCoreContractFunction(&blocklog.ViewGetBlockInfo),
 		constructCoreContractFunction(&blocklog.ViewGetRequestIDsForBlock),
@@ -212,6 +238,11 @@ func TestA(t *testing.T) {
 		constructCoreContractFunction(&governance.FuncSetGasLimits),
 		constructCoreContractFunction(&governance.ViewGetFeePolicy),
 		constructCoreContractFunction(&governance.ViewGetGasLimits),
+		constructCoreContractFunction(&governance.FuncSetDynamicGasPricePolicy),
+		constructCoreContractFunction(&governance.ViewGetDynamicGasPrice),
+		constructCoreContractFunction(&governance.FuncSetFeeMultiplier),
+		constructCoreContractFunction(&governance.ViewGetFeeMultiplier),
+		constructCoreContractFunction(&governance.ViewGetFeeMultipliers),
 		constructCoreContractFunction(&governance.FuncSetEVMGasRatio),
 		constructCoreContractFunction(&governance.ViewGetEVMGasRatio),
 		constructCoreContractFunction(&governance.ViewGetChainInfo),
@@ -224,6 +255,9 @@ func TestA(t *testing.T) {
 		constructCoreContractFunction(&governance.ViewGetMaintenanceStatus),
 		constructCoreContractFunction(&governance.FuncSetMetadata),
 		constructCoreContractFunction(&governance.ViewGetMetadata),
+		constructCoreContractFunction(&governance.FuncScheduleJob),
+		constructCoreContractFunction(&governance.FuncCancelJob),
+		constructCoreContractFunction(&governance.ViewGetScheduledJobs),
 		constructCoreContractFunction(&root.ViewFindContract),
 		constructCoreContractFunction(&root.ViewGetContractRecords),
 		constructCoreContractFunction(&inccounter.FuncIncCounter),
@@ -26,13 +26,17 @@ import (
 type processFunc func(context.Context, kvstore.KVStore)
 
 var (
-	blockIndex  int64
-	blockIndex2 int64
+	blockIndex   int64
+	blockIndex2  int64
+	repair       bool
+	snapshotFile string
 )
 
 func main() {
 	flag.Int64Var(&blockIndex, "b", -1, "Block index")
 	flag.Int64Var(&blockIndex2, "B", -1, "Block index 2")
+	flag.BoolVar(&repair, "repair", false, "Repair the refcounts (verify only)")
+	flag.StringVar(&snapshotFile, "snapshot", "", "Snapshot file to restore the missing blocks from (verify only)")
 	flag.Parse()
 
 	if flag.NArg() != 2 {
@@ -50,11 +54,13 @@ func main() {
 		f = trieStats
 	case "trie-diff":
 		f = trieDiff
+	case "verify":
+		f = verify
 	default:
 		log.Fatalf("unknown command: %s", args[0])
 	}
 
-	process(args[1], f)
+	process(args[0], args[1], f)
 }
 
 func getState(kvs kvstore.KVStore, index int64) state.State {
@@ -71,8 +77,16 @@ func getState(kvs kvstore.KVStore, index int64) state.State {
 	return state
 }
 
-func process(dbDir string, f processFunc) {
-	rocksDatabase, err := rocksdb.OpenDBReadOnly(dbDir,
+func process(command, dbDir string, f processFunc) {
+	if (repair || snapshotFile != "") && command != "verify" {
+		log.Fatalf("-repair and -snapshot are supported by the verify command only")
+	}
+	openDBFun := rocksdb.OpenDBReadOnly
+	if repair || snapshotFile != "" {
+		// the DB is modified only if asked to repair it
+		openDBFun = rocksdb.CreateDB
+	}
+	rocksDatabase, err := openDBFun(dbDir,
 		rocksdb.IncreaseParallelism(runtime.NumCPU()-1),
 		rocksdb.Custom([]string{
 			"periodic_compaction_seconds=43200",
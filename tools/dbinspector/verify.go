@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/wasp/v2/packages/chain/statemanager/snapshots"
+	"github.com/iotaledger/wasp/v2/packages/chaindb"
+	"github.com/iotaledger/wasp/v2/packages/kvstore"
+	"github.com/iotaledger/wasp/v2/packages/state"
+	"github.com/iotaledger/wasp/v2/packages/trie"
+)
+
+// verify walks all retained trie roots and reports the problems found. With
+// the -snapshot flag, the snapshot is restored first, to re-fetch the blocks
+// missing from the DB. With the -repair flag, the wrong refcounts are
+// overwritten with the ones expected from the trie structure, unless the trie
+// has missing or corrupt nodes.
+func verify(ctx context.Context, kvs kvstore.KVStore) {
+	store := verifyStore(kvs)
+
+	if snapshotFile != "" {
+		fmt.Printf("Restoring snapshot %s...\n", snapshotFile)
+		snapshotInfo, err := snapshots.LoadSnapshotFile(store, snapshotFile, nil)
+		mustNoError(err)
+		fmt.Printf("Snapshot of block %d %s loaded\n", snapshotInfo.StateIndex(), snapshotInfo.TrieRoot())
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	start := time.Now()
+	report := store.VerifyIntegrity()
+	showIntegrityReport(report)
+	fmt.Printf("Elapsed: %s\n", time.Since(start))
+
+	if !repair || len(report.Trie.RefcountMismatches) == 0 || ctx.Err() != nil {
+		return
+	}
+	if len(report.Trie.MissingNodes) > 0 || len(report.Trie.CorruptNodes) > 0 {
+		// the expected refcounts of the subtries below the missing or corrupt
+		// nodes are unknown
+		fmt.Println("Refusing to repair the refcounts: the trie has missing or corrupt nodes")
+		return
+	}
+	fmt.Printf("Repairing %d refcounts...\n", len(report.Trie.RefcountMismatches))
+	mustNoError(store.RepairRefcounts(report))
+	mustNoError(kvs.Flush())
+	fmt.Println("Refcounts repaired")
+}
+
+func verifyStore(kvs kvstore.KVStore) state.Store {
+	if !repair && snapshotFile == "" {
+		store, err := state.NewStoreReadonly(kvs)
+		mustNoError(err)
+		return store
+	}
+	refcountsEnabled := trie.NewTrieR(trie.NewHiveKVStoreAdapter(kvs, []byte{chaindb.PrefixTrie})).IsRefcountsEnabled()
+	store, err := state.NewStore(kvs, refcountsEnabled, new(sync.Mutex))
+	mustNoError(err)
+	return store
+}
+
+func showIntegrityReport(report *state.IntegrityReport) {
+	fmt.Printf("Blocks: %d\n", report.Blocks)
+	for _, err := range report.BlockErrors {
+		fmt.Printf("  block error: %v\n", err)
+	}
+	for _, missing := range report.MissingBlocks {
+		fmt.Printf("  missing block: %d %s\n", missing.StateIndex, missing.Commitment)
+	}
+	fmt.Printf("Trie nodes: %d\n", len(report.Trie.Refcounts.Nodes))
+	for _, commitment := range report.Trie.MissingNodes {
+		fmt.Printf("  missing node: %s\n", commitment)
+	}
+	for _, commitment := range report.Trie.CorruptNodes {
+		fmt.Printf("  corrupt node: %s\n", commitment)
+	}
+	fmt.Printf("Trie values: %d\n", len(report.Trie.Refcounts.Values))
+	for _, terminal := range report.Trie.MissingValues {
+		fmt.Printf("  missing value: %s\n", terminal)
+	}
+	for _, m := range report.Trie.RefcountMismatches {
+		if m.Node != nil {
+			fmt.Printf("  node refcount mismatch: %s expected %d, stored %d\n", m.Node, m.Expected, m.Stored)
+		} else {
+			fmt.Printf("  value refcount mismatch: %x expected %d, stored %d\n", m.Value.Data, m.Expected, m.Stored)
+		}
+	}
+	if report.IsOK() {
+		fmt.Println("OK")
+	} else {
+		fmt.Println("PROBLEMS FOUND")
+	}
+}
@@ -110,6 +110,7 @@ func start(cmd *cobra.Command, args []string) {
 		jsonrpc.NewAccountManager(accounts),
 		metrics.NewChainWebAPIMetricsProvider().CreateForChain(chain.ChainID),
 		jsonrpc.ParametersDefault(),
+		nil,
 	)
 	log.Check(err)
 	log.Printf("starting JSON-RPC server on %s...\n", cli.ListenAddress)
@@ -38,10 +38,14 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(initChangeGovControllerCmd())
 	chainCmd.AddCommand(initChangeAccessNodesCmd())
 	chainCmd.AddCommand(initDisableFeePolicyCmd())
+	chainCmd.AddCommand(initSetBlockKeepAmountCmd())
+	chainCmd.AddCommand(initSetFeeMultiplierCmd())
+	chainCmd.AddCommand(initListFeeMultipliersCmd())
 	chainCmd.AddCommand(initPermissionlessAccessNodesCmd())
 	chainCmd.AddCommand(initAddChainCmd())
 	chainCmd.AddCommand(initRegisterERC20NativeTokenCmd())
 	chainCmd.AddCommand(initSetCoinMetadataCmd())
 	chainCmd.AddCommand(initMetadataCmd())
 	chainCmd.AddCommand(initBuildIndex())
+	chainCmd.AddCommand(initSnapshotCmd())
 }
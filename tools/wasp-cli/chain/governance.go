@@ -6,6 +6,7 @@ package chain
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/samber/lo"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/iotaledger/wasp/v2/packages/util"
 	"github.com/iotaledger/wasp/v2/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/v2/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/v2/tools/wasp-cli/log"
 	"github.com/iotaledger/wasp/v2/tools/wasp-cli/waspcmd"
 )
 
@@ -161,3 +163,152 @@ func initDisableFeePolicyCmd() *cobra.Command {
 
 	return cmd
 }
+
+func initSetBlockKeepAmountCmd() *cobra.Command {
+	var offLedger bool
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "gov-set-block-keep-amount <amount>",
+		Short: "Sets the amount of blocks kept in the chain state (-1 to keep all blocks).",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			chain = defaultChainFallback(chain)
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			blockKeepAmount, err := strconv.ParseInt(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid block keep amount: %w", err)
+			}
+
+			postRequest(
+				ctx,
+				client,
+				chain,
+				governance.FuncSetBlockKeepAmount.Message(int32(blockKeepAmount)),
+				chainclient.PostRequestParams{
+					GasBudget: iotaclient.DefaultGasBudget,
+				},
+				offLedger,
+			)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
+		"post an off-ledger request",
+	)
+
+	return cmd
+}
+
+func initSetFeeMultiplierCmd() *cobra.Command {
+	var offLedger bool
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "gov-set-fee-multiplier <agentID> <multiplier>",
+		Short: "Sets the multiplier applied to the gas fee of a sender, in basis points (0 = exempt, 10000 = full fee).",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			chain = defaultChainFallback(chain)
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			agentID, err := isc.AgentIDFromString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid agent ID: %w", err)
+			}
+			multiplier, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid fee multiplier: %w", err)
+			}
+
+			postRequest(
+				ctx,
+				client,
+				chain,
+				governance.FuncSetFeeMultiplier.Message(agentID, uint32(multiplier)),
+				chainclient.PostRequestParams{
+					GasBudget: iotaclient.DefaultGasBudget,
+				},
+				offLedger,
+			)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
+		"post an off-ledger request",
+	)
+
+	return cmd
+}
+
+func initListFeeMultipliersCmd() *cobra.Command {
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "gov-list-fee-multipliers",
+		Short: "Lists the senders that pay a discounted gas fee.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			chain = defaultChainFallback(chain)
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			apiResult, _, err := client.ChainsAPI.CallView(ctx).
+				ContractCallViewRequest(apiclient.ContractCallViewRequest{
+					ContractName: governance.Contract.Name,
+					FunctionName: governance.ViewGetFeeMultipliers.Name,
+				}).Execute() //nolint:bodyclose // false positive
+			if err != nil {
+				return err
+			}
+			result, err := apiextensions.APIResultToCallArgs(apiResult)
+			if err != nil {
+				return err
+			}
+			feeMultipliers, err := governance.ViewGetFeeMultipliers.DecodeOutput(result)
+			if err != nil {
+				return err
+			}
+
+			header := []string{"AgentID", "Multiplier"}
+			rows := make([][]string, len(feeMultipliers))
+			for i, m := range feeMultipliers {
+				rows[i] = []string{m.AgentID.String(), fmt.Sprintf("%d", m.Multiplier)}
+			}
+			log.PrintTable(header, rows)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+
+	return cmd
+}
@@ -0,0 +1,216 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/v2/clients/apiclient"
+	"github.com/iotaledger/wasp/v2/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/v2/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/v2/tools/wasp-cli/waspcmd"
+)
+
+func initSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot <command>",
+		Short: "Manage the state snapshots of the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(initCreateSnapshotCmd())
+	cmd.AddCommand(initListSnapshotsCmd())
+	cmd.AddCommand(initDeleteSnapshotCmd())
+	cmd.AddCommand(initExportSnapshotCmd())
+	return cmd
+}
+
+func parseSnapshotBlockIndex(args []string) (*uint32, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	index, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block index: %w", err)
+	}
+	ret := uint32(index)
+	return &ret, nil
+}
+
+func initCreateSnapshotCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "create [<block index>]",
+		Short: "Create a snapshot of the given block (default: latest block) in the snapshot folder of the node",
+		Long:  "The state of the block must still be retained by the node.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			blockIndex, err := parseSnapshotBlockIndex(args)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			res, _, err := client.ChainsAPI.
+				CreateSnapshot(ctx).
+				CreateSnapshotRequest(apiclient.CreateSnapshotRequest{BlockIndex: blockIndex}).
+				Execute() //nolint:bodyclose // false positive
+			if err != nil {
+				return err
+			}
+			log.Printf("Snapshot %s is being created\n", res.FileName)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initListSnapshotsCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots in the snapshot folder of the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			snapshots, _, err := client.ChainsAPI.GetSnapshots(ctx).Execute() //nolint:bodyclose // false positive
+			if err != nil {
+				return err
+			}
+
+			header := []string{"file", "block index", "block hash", "trie root", "base block index", "size"}
+			rows := make([][]string, len(snapshots))
+			for i, snapshot := range snapshots {
+				base := "-"
+				if snapshot.BaseBlockIndex != nil {
+					base = strconv.FormatUint(uint64(*snapshot.BaseBlockIndex), 10)
+				}
+				rows[i] = []string{
+					snapshot.FileName,
+					strconv.FormatUint(uint64(snapshot.BlockIndex), 10),
+					snapshot.BlockHash,
+					snapshot.TrieRoot,
+					base,
+					strconv.FormatInt(snapshot.Size, 10),
+				}
+			}
+			log.PrintTable(header, rows)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initDeleteSnapshotCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "delete <file name>",
+		Short: "Delete a snapshot from the snapshot folder of the node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			_, err = client.ChainsAPI.DeleteSnapshot(ctx, args[0]).Execute() //nolint:bodyclose // false positive
+			if err != nil {
+				return err
+			}
+			log.Printf("Snapshot %s deleted\n", args[0])
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initExportSnapshotCmd() *cobra.Command {
+	var (
+		node       string
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [<block index>] --output=<file path>",
+		Short: "Download a snapshot of the given block (default: latest block) from the node",
+		Long:  "The state of the block must still be retained by the node.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			node, err = waspcmd.DefaultWaspNodeFallback(node)
+			if err != nil {
+				return err
+			}
+			blockIndex, err := parseSnapshotBlockIndex(args)
+			if err != nil {
+				return err
+			}
+			if outputFile == "" {
+				outputFile = "snapshot.snap"
+				if blockIndex != nil {
+					outputFile = fmt.Sprintf("snapshot-%d.snap", *blockIndex)
+				}
+			}
+			ctx := context.Background()
+			client := cliclients.WaspClientWithVersionCheck(ctx, node)
+
+			req := client.ChainsAPI.ExportSnapshot(ctx)
+			if blockIndex != nil {
+				req = req.BlockIndex(*blockIndex)
+			}
+			downloaded, _, err := req.Execute() //nolint:bodyclose // false positive
+			if err != nil {
+				return err
+			}
+			defer os.Remove(downloaded.Name())
+			defer downloaded.Close()
+
+			file, err := os.Create(outputFile)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if _, err = io.Copy(file, downloaded); err != nil {
+				return err
+			}
+			log.Printf("Snapshot saved to %s\n", outputFile)
+			return nil
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "file where the snapshot will be saved to")
+	return cmd
+}